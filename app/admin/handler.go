@@ -0,0 +1,69 @@
+// Package admin provides HTTP handlers for operational endpoints.
+package admin
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// LogLevelRequest represents the request body for changing the log level.
+type LogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// LogLevelResponse represents the effective log level in API responses.
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+// AdminHandler handles HTTP requests for the admin endpoints.
+type AdminHandler struct {
+	setLevel func(slog.Level)
+}
+
+// NewAdminHandler creates a new AdminHandler instance.
+// setLevel is called to apply a new log level, typically logger.SetLevel.
+func NewAdminHandler(setLevel func(slog.Level)) *AdminHandler {
+	return &AdminHandler{setLevel: setLevel}
+}
+
+// HandleSetLogLevel handles POST /admin/log-level requests for changing the log level.
+// Accepted levels are debug, info, warn and error.
+func (h *AdminHandler) HandleSetLogLevel(w http.ResponseWriter, r *http.Request) error {
+	var req LogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return services.ErrInvalidInput
+	}
+
+	level, err := parseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+
+	h.setLevel(level)
+	logger.WithContext(r.Context()).Info("Log level changed", slog.String("level", level.String()))
+
+	api.OKResponse(w, r, LogLevelResponse{Level: strings.ToLower(level.String())})
+	return nil
+}
+
+// parseLevel converts a level name to one of the four standard slog levels.
+func parseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, services.ErrInvalidLogLevel
+}
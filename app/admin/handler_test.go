@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleSetLogLevel(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedLevel  *slog.Level
+		expectedBody   string
+	}{
+		{name: "debug", body: `{"level":"debug"}`, expectedStatus: http.StatusOK, expectedLevel: levelPtr(slog.LevelDebug), expectedBody: `{"level":"debug"}`},
+		{name: "uppercase warn", body: `{"level":"WARN"}`, expectedStatus: http.StatusOK, expectedLevel: levelPtr(slog.LevelWarn), expectedBody: `{"level":"warn"}`},
+		{name: "error", body: `{"level":"error"}`, expectedStatus: http.StatusOK, expectedLevel: levelPtr(slog.LevelError), expectedBody: `{"level":"error"}`},
+		{name: "unknown level", body: `{"level":"trace"}`, expectedStatus: http.StatusBadRequest},
+		{name: "numeric level", body: `{"level":"DEBUG+2"}`, expectedStatus: http.StatusBadRequest},
+		{name: "missing level", body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var applied *slog.Level
+			handler := NewAdminHandler(func(l slog.Level) { applied = &l })
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/admin/log-level", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("admin.HandleSetLogLevel", handler.HandleSetLogLevel).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedLevel, applied)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+				assert.Equal(t, tt.expectedBody, w.Body.String(), "expected compact JSON without a trailing newline")
+			}
+		})
+	}
+}
+
+func levelPtr(l slog.Level) *slog.Level {
+	return &l
+}
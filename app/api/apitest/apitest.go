@@ -0,0 +1,25 @@
+// Package apitest provides assertions on API responses for handler tests.
+package apitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+)
+
+// AssertErrorResponse decodes resp as an API error response and checks its code.
+// It returns the decoded body so callers can assert on the message too.
+func AssertErrorResponse(t *testing.T, resp *http.Response, expectedCode api.ErrorCode) api.ErrorResponseBody {
+	t.Helper()
+
+	var body api.ErrorResponseBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if body.Code != expectedCode {
+		t.Errorf("expected error code %q, got %q", expectedCode, body.Code)
+	}
+	return body
+}
@@ -7,10 +7,11 @@ import "net/http"
 type HandlerFunc func(http.ResponseWriter, *http.Request) error
 
 // ErrorHandler wraps a HandlerFunc and centralizes error handling.
-func ErrorHandler(next HandlerFunc) http.Handler {
+// The name identifies the handler, e.g. "catalog.HandleGet", in error logs.
+func ErrorHandler(name string, next HandlerFunc) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if err := next(w, r); err != nil {
-			HandleError(w, r, err)
+			handleError(w, r, err, name)
 		}
 	})
 }
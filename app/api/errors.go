@@ -1,10 +1,11 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
+	"syscall"
 
 	"github.com/mytheresa/go-hiring-challenge/app/logger"
 	"github.com/mytheresa/go-hiring-challenge/app/middleware"
@@ -16,24 +17,74 @@ import (
 type ErrorCode string
 
 const (
-	ErrCodeInvalidInput ErrorCode = "invalid_input"
-	ErrCodeNotFound     ErrorCode = "not_found"
-	ErrCodeInternal     ErrorCode = "internal_error"
+	ErrCodeInvalidInput  ErrorCode = "invalid_input"
+	ErrCodeNotFound      ErrorCode = "not_found"
+	ErrCodeInternal      ErrorCode = "internal_error"
+	ErrCodeUnauthorized  ErrorCode = "unauthorized"
+	ErrCodeConflict      ErrorCode = "conflict"
+	ErrCodeUnprocessable ErrorCode = "unprocessable_entity"
+	ErrCodeClientClosed  ErrorCode = "client_closed_request"
+	ErrCodeUnavailable   ErrorCode = "service_unavailable"
 )
 
-// ErrorResponse represents a standardized error response.
+// StatusClientClosedRequest is the non-standard status, popularised by nginx,
+// for requests the client abandoned before a response was written.
+const StatusClientClosedRequest = 499
+
+// ErrorResponseBody represents a standardized error response.
 type ErrorResponseBody struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
 }
 
-// HandleError maps application errors to HTTP responses.
+// HandlerError is an error that carries its own HTTP response, for one-off
+// failures that don't warrant a sentinel in the services package.
+// Err is the optional underlying cause; it is logged, never sent to clients.
+type HandlerError struct {
+	Status  int
+	Code    ErrorCode
+	Message string
+	Err     error
+}
+
+// Error returns the message, followed by the cause if there is one.
+func (e *HandlerError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// HandleError maps application errors to HTTP responses and logs them.
+// A *HandlerError is written as is, unless its Status is not a valid HTTP status
+// code, in which case it is reported as an internal error; other errors are mapped by type.
 func HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	handleError(w, r, err, "")
+}
+
+// handleError is HandleError for a named handler; a non-empty handler name
+// is added to the log entry.
+func handleError(w http.ResponseWriter, r *http.Request, err error, handler string) {
 	var status int
 	var code ErrorCode
 	var message string
 
+	var handlerErr *HandlerError
 	switch {
+	case errors.As(err, &handlerErr) && (handlerErr.Status < 100 || handlerErr.Status > 999):
+		// WriteHeader panics on such codes, e.g. a HandlerError without a Status.
+		status = http.StatusInternalServerError
+		code = ErrCodeInternal
+		message = "An internal error occurred"
+	case errors.As(err, &handlerErr):
+		status = handlerErr.Status
+		code = handlerErr.Code
+		message = handlerErr.Message
 	case errors.Is(err, services.ErrInvalidOffset):
 		status = http.StatusBadRequest
 		code = ErrCodeInvalidInput
@@ -42,6 +93,14 @@ func HandleError(w http.ResponseWriter, r *http.Request, err error) {
 		status = http.StatusBadRequest
 		code = ErrCodeInvalidInput
 		message = err.Error()
+	case errors.Is(err, services.ErrOffsetExceedsTotal):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrOffsetTooLarge):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
 	case errors.Is(err, services.ErrInvalidPrice):
 		status = http.StatusBadRequest
 		code = ErrCodeInvalidInput
@@ -50,10 +109,106 @@ func HandleError(w http.ResponseWriter, r *http.Request, err error) {
 		status = http.StatusBadRequest
 		code = ErrCodeInvalidInput
 		message = err.Error()
+	case errors.Is(err, services.ErrInvalidCategoryName):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
 	case errors.Is(err, services.ErrInvalidCategoryInput):
 		status = http.StatusBadRequest
 		code = ErrCodeInvalidInput
 		message = err.Error()
+	case errors.Is(err, services.ErrInvalidInStock):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidStockDelta):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInsufficientStock):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidProductPrice):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidUpdatedAfter):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidImageURL):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidProductImage):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidImagePosition):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidImageID):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidSort):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidMinOrderQty):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidFields):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidOnSale):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidDays):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidWebhookURL):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidWebhookEvent):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidWebhookSecret):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidPage):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidLogLevel):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidProductCode):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidSKU):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidLabel):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
+	case errors.Is(err, services.ErrInvalidSalePrice):
+		status = http.StatusBadRequest
+		code = ErrCodeInvalidInput
+		message = err.Error()
 	case errors.Is(err, services.ErrInvalidInput):
 		status = http.StatusBadRequest
 		code = ErrCodeInvalidInput
@@ -62,36 +217,95 @@ func HandleError(w http.ResponseWriter, r *http.Request, err error) {
 		status = http.StatusNotFound
 		code = ErrCodeNotFound
 		message = "Resource not found"
+	case errors.Is(err, services.ErrCodeImmutable):
+		status = http.StatusUnprocessableEntity
+		code = ErrCodeUnprocessable
+		message = err.Error()
+	case errors.Is(err, services.ErrDuplicateCode):
+		status = http.StatusConflict
+		code = ErrCodeConflict
+		message = err.Error()
+	case errors.Is(err, services.ErrCategoryInUse):
+		status = http.StatusConflict
+		code = ErrCodeConflict
+		message = err.Error()
+	case errors.Is(err, services.ErrConflict):
+		status = http.StatusConflict
+		code = ErrCodeConflict
+		message = "Resource conflict"
 	case errors.Is(err, gorm.ErrRecordNotFound):
 		status = http.StatusNotFound
 		code = ErrCodeNotFound
 		message = "Resource not found"
+	case errors.Is(err, context.Canceled):
+		status = StatusClientClosedRequest
+		code = ErrCodeClientClosed
+		message = "Request was cancelled"
+	case errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusServiceUnavailable
+		code = ErrCodeUnavailable
+		message = "Request timed out"
 	default:
 		status = http.StatusInternalServerError
 		code = ErrCodeInternal
 		message = "An internal error occurred"
-
-		// Log internal errors with full details
-		logger.Error("Internal server error",
-			slog.String("request_id", middleware.GetRequestID(r.Context())),
-			slog.String("method", r.Method),
-			slog.String("path", r.URL.Path),
-			slog.String("error", err.Error()),
-		)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+	logError(r, status, err, handler)
 
+	if correlationID := middleware.GetCorrelationID(r.Context()); correlationID != "" {
+		w.Header().Set(middleware.CorrelationIDHeader, correlationID)
+	}
 	response := ErrorResponseBody{
 		Code:    code,
 		Message: message,
 	}
 
-	if encErr := json.NewEncoder(w).Encode(response); encErr != nil {
-		logger.Error("Failed to encode error response",
-			slog.String("request_id", middleware.GetRequestID(r.Context())),
+	if encErr := EncodeJSON(w, status, response); encErr != nil {
+		log := logger.WithContext(r.Context())
+		if clientDisconnected(err) || clientDisconnected(encErr) {
+			log.Debug("client disconnected", slog.String("error", encErr.Error()))
+			return
+		}
+		log.Error("Failed to encode error response",
 			slog.String("error", encErr.Error()),
 		)
 	}
 }
+
+// clientDisconnected reports whether err means the client went away before
+// the response was written: the request was cancelled or the connection broke.
+func clientDisconnected(err error) bool {
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// logError logs err, including its wrapped causes that clients never see.
+// Client errors (4xx) are expected and logged at WARN; server errors (5xx)
+// are logged at ERROR, except timeouts, which signal load rather than a bug
+// and are logged at WARN. Errors caused by the client disconnecting are
+// logged at DEBUG, since there is nobody left to read the response.
+func logError(r *http.Request, status int, err error, handler string) {
+	log := logger.WithContext(r.Context())
+	attrs := []any{
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Int("status", status),
+		slog.String("error", err.Error()),
+	}
+	if handler != "" {
+		attrs = append(attrs, slog.String("handler", handler))
+	}
+
+	switch {
+	case clientDisconnected(err):
+		log.Debug("client disconnected", attrs...)
+	case errors.Is(err, context.DeadlineExceeded):
+		log.Warn("Request timed out", attrs...)
+	case status >= http.StatusInternalServerError:
+		log.Error("Internal server error", attrs...)
+	default:
+		log.Warn("Client error", attrs...)
+	}
+}
@@ -0,0 +1,168 @@
+//go:build test
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// captureErrorLog runs HandleError with the logger at lvl and returns the recorder and log output.
+func captureErrorLog(t *testing.T, lvl slog.Level, err error) (*httptest.ResponseRecorder, *bytes.Buffer) {
+	t.Helper()
+
+	buf := captureLog(t, lvl)
+
+	recorder := httptest.NewRecorder()
+	HandleError(recorder, newLogRequest(), err)
+
+	return recorder, buf
+}
+
+// captureLog redirects the logger to a buffer at the given level for the rest of the test.
+func captureLog(t *testing.T, lvl slog.Level) *bytes.Buffer {
+	t.Helper()
+
+	previousLevel := logger.Level()
+	logger.Reset()
+	t.Cleanup(func() {
+		logger.Reset()
+		logger.SetLevel(previousLevel)
+	})
+
+	var buf bytes.Buffer
+	logger.Init("production", &buf)
+	logger.SetLevel(lvl)
+
+	return &buf
+}
+
+// newLogRequest returns the request whose errors the tests log.
+func newLogRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/MISSING", nil)
+	return req.WithContext(logger.ContextWithRequestID(req.Context(), "req-log"))
+}
+
+func TestHandleError_NotFoundLogsErrorChainAtWarn(t *testing.T) {
+	err := fmt.Errorf("%w: %w", services.ErrNotFound, gorm.ErrRecordNotFound)
+
+	recorder, buf := captureErrorLog(t, slog.LevelDebug, err)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.JSONEq(t, `{"code":"not_found","message":"Resource not found"}`, recorder.Body.String())
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "Client error", entry["msg"])
+	assert.Equal(t, "req-log", entry["request_id"])
+	assert.Equal(t, "/v1/catalog/MISSING", entry["path"])
+	assert.Equal(t, "resource not found: record not found", entry["error"])
+	assert.NotContains(t, entry, "handler")
+}
+
+func TestErrorHandler_LogsHandlerName(t *testing.T) {
+	buf := captureLog(t, slog.LevelDebug)
+
+	handler := ErrorHandler("catalog.HandleGetByCode", func(w http.ResponseWriter, r *http.Request) error {
+		return services.ErrNotFound
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newLogRequest())
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "catalog.HandleGetByCode", entry["handler"])
+	assert.Equal(t, "/v1/catalog/MISSING", entry["path"])
+}
+
+func TestHandleError_ClientErrorsSilentAtErrorLevel(t *testing.T) {
+	err := fmt.Errorf("%w: %w", services.ErrNotFound, gorm.ErrRecordNotFound)
+
+	recorder, buf := captureErrorLog(t, slog.LevelError, err)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Empty(t, buf.String())
+}
+
+func TestHandleError_DisconnectedClientLogsAtDebug(t *testing.T) {
+	for _, err := range []error{context.Canceled, fmt.Errorf("write response: %w", syscall.EPIPE)} {
+		t.Run(err.Error(), func(t *testing.T) {
+			buf := captureLog(t, slog.LevelDebug)
+
+			writer := &brokenWriter{header: make(http.Header)}
+			HandleError(writer, newLogRequest(), err)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			require.NotEmpty(t, lines)
+			for _, line := range lines {
+				var entry map[string]any
+				require.NoError(t, json.Unmarshal([]byte(line), &entry))
+				assert.Equal(t, "DEBUG", entry["level"])
+				assert.Equal(t, "client disconnected", entry["msg"])
+			}
+		})
+	}
+}
+
+func TestHandleError_LogLevelFollowsStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedLevel string
+		expectedMsg   string
+	}{
+		{name: "invalid input", err: services.ErrInvalidInput, expectedLevel: "WARN", expectedMsg: "Client error"},
+		{name: "validation error", err: services.ErrInvalidLimit, expectedLevel: "WARN", expectedMsg: "Client error"},
+		{name: "conflict", err: services.ErrDuplicateCode, expectedLevel: "WARN", expectedMsg: "Client error"},
+		{name: "client cancelled", err: context.Canceled, expectedLevel: "DEBUG", expectedMsg: "client disconnected"},
+		{name: "broken pipe", err: fmt.Errorf("write response: %w", syscall.EPIPE), expectedLevel: "DEBUG", expectedMsg: "client disconnected"},
+		{name: "connection reset", err: fmt.Errorf("read body: %w", syscall.ECONNRESET), expectedLevel: "DEBUG", expectedMsg: "client disconnected"},
+		{
+			name:          "4xx handler error",
+			err:           &HandlerError{Status: http.StatusBadRequest, Code: ErrCodeInvalidInput, Message: "bad"},
+			expectedLevel: "WARN",
+			expectedMsg:   "Client error",
+		},
+		{name: "timeout", err: context.DeadlineExceeded, expectedLevel: "WARN", expectedMsg: "Request timed out"},
+		{name: "unexpected error", err: errors.New("connection refused"), expectedLevel: "ERROR", expectedMsg: "Internal server error"},
+		{
+			name:          "5xx handler error",
+			err:           &HandlerError{Status: http.StatusBadGateway, Code: ErrCodeInternal, Message: "upstream failed"},
+			expectedLevel: "ERROR",
+			expectedMsg:   "Internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, buf := captureErrorLog(t, slog.LevelDebug, tt.err)
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.expectedLevel, entry["level"])
+			assert.Equal(t, tt.expectedMsg, entry["msg"])
+			assert.Equal(t, "req-log", entry["request_id"])
+			assert.Equal(t, "/v1/catalog/MISSING", entry["path"])
+			assert.Equal(t, float64(recorder.Code), entry["status"])
+			assert.Equal(t, tt.err.Error(), entry["error"])
+		})
+	}
+}
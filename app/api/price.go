@@ -0,0 +1,41 @@
+package api
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Price is a monetary amount encoded in JSON as an exact number literal.
+// Unlike float64 it never loses precision, e.g. 10.005 is written as 10.005.
+type Price decimal.Decimal
+
+// NewPrice creates a Price from a decimal value.
+func NewPrice(d decimal.Decimal) Price {
+	return Price(d)
+}
+
+// Decimal returns the price as a decimal value.
+func (p Price) Decimal() decimal.Decimal {
+	return decimal.Decimal(p)
+}
+
+// String returns the exact decimal representation of the price.
+func (p Price) String() string {
+	return decimal.Decimal(p).String()
+}
+
+// MarshalJSON implements json.Marshaler. The price is written as an unquoted
+// number using its exact decimal representation.
+func (p Price) MarshalJSON() ([]byte, error) {
+	return []byte(decimal.Decimal(p).String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Both JSON numbers and quoted
+// decimal strings are accepted.
+func (p *Price) UnmarshalJSON(data []byte) error {
+	var d decimal.Decimal
+	if err := d.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*p = Price(d)
+	return nil
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrice_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "half cent", value: "0.005", expected: "0.005"},
+		{name: "large amount", value: "999999.99", expected: "999999.99"},
+		{name: "tenth of a cent", value: "0.001", expected: "0.001"},
+		{name: "float rounding case", value: "10.005", expected: "10.005"},
+		{name: "whole number", value: "10", expected: "10"},
+		{name: "zero", value: "0", expected: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := NewPrice(decimal.RequireFromString(tt.value))
+
+			encoded, err := json.Marshal(price)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(encoded))
+		})
+	}
+}
+
+func TestPrice_RoundTrip(t *testing.T) {
+	for _, value := range []string{"0.005", "999999.99", "0.001", "10.005", "12345678901234567890.123456789"} {
+		t.Run(value, func(t *testing.T) {
+			original := struct {
+				Price Price `json:"price"`
+			}{Price: NewPrice(decimal.RequireFromString(value))}
+
+			encoded, err := json.Marshal(original)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"price":`+value+`}`, string(encoded))
+
+			var decoded struct {
+				Price Price `json:"price"`
+			}
+			require.NoError(t, json.Unmarshal(encoded, &decoded))
+			assert.True(t, original.Price.Decimal().Equal(decoded.Price.Decimal()),
+				"expected %s, got %s", original.Price, decoded.Price)
+		})
+	}
+}
+
+func TestPrice_UnmarshalJSON(t *testing.T) {
+	var p Price
+
+	require.NoError(t, json.Unmarshal([]byte(`"0.005"`), &p))
+	assert.Equal(t, "0.005", p.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &p))
+}
@@ -1,35 +1,108 @@
-// Package api provides HTTP response utilities for JSON responses.
+// Package api provides HTTP response utilities for JSON and XML responses.
 package api
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/mytheresa/go-hiring-challenge/app/logger"
-	"github.com/mytheresa/go-hiring-challenge/app/middleware"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
 )
 
+// PaginatedResponse is the envelope for a page of items.
+// Total is the number of items across all pages; Offset and Limit describe the page.
+type PaginatedResponse[T any] struct {
+	Items  []T   `json:"items"`
+	Total  int64 `json:"total"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+// TotalCountHeader carries the total number of items of a paginated listing,
+// for clients that read it from the headers rather than the body.
+const TotalCountHeader = "X-Total-Count"
+
+// SetTotalCount sets TotalCountHeader to total. Call it before the body is written.
+func SetTotalCount(w http.ResponseWriter, total int64) {
+	w.Header().Set(TotalCountHeader, strconv.FormatInt(total, 10))
+}
+
+// EncodeJSON sends data as a compact JSON body, with no trailing newline, and the given status.
+// The status is sent even if data cannot be encoded, in which case the body is empty.
+func EncodeJSON(w http.ResponseWriter, status int, data any) error {
+	body, err := json.Marshal(data)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
 // OKResponse sends a JSON response with status 200 OK.
 func OKResponse(w http.ResponseWriter, r *http.Request, data any) {
-	w.Header().Set("Content-Type", "application/json")
+	if err := EncodeJSON(w, http.StatusOK, data); err != nil {
+		logger.WithContext(r.Context()).Error("failed to encode JSON response",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// OKXMLResponse sends an XML document, prefixed with the XML declaration, with status 200 OK.
+func OKXMLResponse(w http.ResponseWriter, r *http.Request, data any) {
+	w.Header().Set("Content-Type", "application/xml")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		logger.Error("failed to encode JSON response",
-			slog.String("request_id", middleware.GetRequestID(r.Context())),
+	_, err := io.WriteString(w, xml.Header)
+	if err == nil {
+		err = xml.NewEncoder(w).Encode(data)
+	}
+	if err != nil {
+		logger.WithContext(r.Context()).Error("failed to encode XML response",
 			slog.String("error", err.Error()),
 		)
 	}
 }
 
+// OKPaginatedResponse sends a page of items wrapped in a PaginatedResponse with status 200 OK.
+// A nil items slice is sent as an empty array. The total is also sent in TotalCountHeader.
+func OKPaginatedResponse[T any](w http.ResponseWriter, r *http.Request, items []T, total int64, params services.PaginationParams) {
+	if items == nil {
+		items = []T{}
+	}
+	SetTotalCount(w, total)
+	OKResponse(w, r, PaginatedResponse[T]{
+		Items:  items,
+		Total:  total,
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	})
+}
+
 // CreatedResponse sends a JSON response with status 201 Created.
 func CreatedResponse(w http.ResponseWriter, r *http.Request, data any) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		logger.Error("failed to encode JSON response",
-			slog.String("request_id", middleware.GetRequestID(r.Context())),
+	if err := EncodeJSON(w, http.StatusCreated, data); err != nil {
+		logger.WithContext(r.Context()).Error("failed to encode JSON response",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// AcceptedResponse sends a JSON response with status 202 Accepted.
+func AcceptedResponse(w http.ResponseWriter, r *http.Request, data any) {
+	if err := EncodeJSON(w, http.StatusAccepted, data); err != nil {
+		logger.WithContext(r.Context()).Error("failed to encode JSON response",
 			slog.String("error", err.Error()),
 		)
 	}
 }
+
+// NoContentResponse sends an empty response with status 204 No Content.
+func NoContentResponse(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
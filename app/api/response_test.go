@@ -1,12 +1,16 @@
 package api
 
 import (
+	"context"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/mytheresa/go-hiring-challenge/app/middleware"
 	"github.com/mytheresa/go-hiring-challenge/app/services"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -31,6 +35,65 @@ func TestOKResponse(t *testing.T) {
 		expected := `{"message":"Success"}`
 		assert.JSONEq(t, expected, recorder.Body.String(), "Response body does not match expected")
 	})
+
+	t.Run("body has no trailing newline", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		OKResponse(recorder, req, sample)
+
+		assert.Equal(t, `{"message":"Success"}`, recorder.Body.String())
+	})
+}
+
+func TestOKXMLResponse(t *testing.T) {
+	type sampleResponse struct {
+		XMLName struct{} `xml:"sample"`
+		Message string   `xml:"message"`
+	}
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	OKXMLResponse(recorder, req, sampleResponse{Message: "Success"})
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, xml.Header+"<sample><message>Success</message></sample>", recorder.Body.String())
+}
+
+func TestOKPaginatedResponse(t *testing.T) {
+	type item struct {
+		Code string `json:"code"`
+	}
+
+	t.Run("wraps items with total and pagination", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		OKPaginatedResponse(recorder, req, []item{{Code: "A"}, {Code: "B"}}, 12, services.PaginationParams{Offset: 10, Limit: 2})
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"items":[{"code":"A"},{"code":"B"}],"total":12,"offset":10,"limit":2}`, recorder.Body.String())
+		assert.Equal(t, `{"items":[{"code":"A"},{"code":"B"}],"total":12,"offset":10,"limit":2}`, recorder.Body.String(), "expected compact JSON without a trailing newline")
+		assert.Equal(t, "12", recorder.Header().Get(TotalCountHeader))
+	})
+
+	t.Run("nil items are sent as an empty array", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		OKPaginatedResponse[item](recorder, req, nil, 0, services.PaginationParams{Offset: 0, Limit: 10})
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"items":[],"total":0,"offset":0,"limit":10}`, recorder.Body.String())
+		assert.Equal(t, "0", recorder.Header().Get(TotalCountHeader))
+	})
+
+	t.Run("works with non-struct items", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		OKPaginatedResponse(recorder, req, []string{"x"}, 1, services.PaginationParams{Limit: 1})
+
+		assert.JSONEq(t, `{"items":["x"],"total":1,"offset":0,"limit":1}`, recorder.Body.String())
+	})
 }
 
 func TestHandleError(t *testing.T) {
@@ -44,6 +107,7 @@ func TestHandleError(t *testing.T) {
 
 		expected := `{"code":"invalid_input","message":"Invalid input provided"}`
 		assert.JSONEq(t, expected, recorder.Body.String())
+		assert.Equal(t, expected, recorder.Body.String(), "expected compact JSON without a trailing newline")
 	})
 
 	t.Run("handles not found error", func(t *testing.T) {
@@ -58,6 +122,51 @@ func TestHandleError(t *testing.T) {
 		assert.JSONEq(t, expected, recorder.Body.String())
 	})
 
+	t.Run("handles conflict error", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/test", nil)
+		HandleError(recorder, req, services.ErrConflict)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		expected := `{"code":"conflict","message":"Resource conflict"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles code immutable error", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/test", nil)
+		HandleError(recorder, req, services.ErrCodeImmutable)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+		expected := `{"code":"unprocessable_entity","message":"code cannot be changed"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles cancelled request", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		HandleError(recorder, req, fmt.Errorf("query products: %w", context.Canceled))
+
+		assert.Equal(t, StatusClientClosedRequest, recorder.Code)
+
+		expected := `{"code":"client_closed_request","message":"Request was cancelled"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles deadline exceeded", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		HandleError(recorder, req, fmt.Errorf("query products: %w", context.DeadlineExceeded))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+		expected := `{"code":"service_unavailable","message":"Request timed out"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
 	t.Run("handles internal error", func(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/test", nil)
@@ -71,12 +180,41 @@ func TestHandleError(t *testing.T) {
 	})
 }
 
+func TestHandleError_CorrelationID(t *testing.T) {
+	handler := middleware.RequestID(ErrorHandler("test.Handle", func(w http.ResponseWriter, r *http.Request) error {
+		return services.ErrNotFound
+	}))
+
+	t.Run("includes correlation ID when present", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(middleware.CorrelationIDHeader, "corr-123")
+		req.Header.Set(middleware.RequestIDHeader, "req-123")
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, "corr-123", recorder.Header().Get(middleware.CorrelationIDHeader))
+		assert.Equal(t, "req-123", recorder.Header().Get(middleware.RequestIDHeader))
+	})
+
+	t.Run("omits correlation ID when absent", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Empty(t, recorder.Header().Get(middleware.CorrelationIDHeader))
+	})
+}
+
 func TestErrorHandler(t *testing.T) {
 	t.Run("writes error response when handler returns error", func(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/test", nil)
 
-		handler := ErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		handler := ErrorHandler("test.Handle", func(w http.ResponseWriter, r *http.Request) error {
 			return services.ErrInvalidInput
 		})
 		handler.ServeHTTP(recorder, req)
@@ -88,11 +226,24 @@ func TestErrorHandler(t *testing.T) {
 		assert.JSONEq(t, expected, recorder.Body.String())
 	})
 
+	t.Run("writes handler error fields directly", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		handler := ErrorHandler("test.Handle", func(w http.ResponseWriter, r *http.Request) error {
+			return &HandlerError{Status: http.StatusTeapot, Code: "teapot", Message: "brewing coffee is not supported"}
+		})
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusTeapot, recorder.Code)
+		assert.JSONEq(t, `{"code":"teapot","message":"brewing coffee is not supported"}`, recorder.Body.String())
+	})
+
 	t.Run("returns nil when handler succeeds", func(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/test", nil)
 
-		handler := ErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		handler := ErrorHandler("test.Handle", func(w http.ResponseWriter, r *http.Request) error {
 			w.WriteHeader(http.StatusOK)
 			return nil
 		})
@@ -102,6 +253,69 @@ func TestErrorHandler(t *testing.T) {
 	})
 }
 
+func TestHandleError_HandlerError(t *testing.T) {
+	cause := errors.New("unexpected EOF")
+
+	t.Run("bypasses sentinel mapping", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		// The cause would map to 404 on its own; the handler error's fields win.
+		HandleError(recorder, req, &HandlerError{
+			Status:  http.StatusBadRequest,
+			Code:    ErrCodeInvalidInput,
+			Message: "sku is malformed",
+			Err:     services.ErrNotFound,
+		})
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.JSONEq(t, `{"code":"invalid_input","message":"sku is malformed"}`, recorder.Body.String())
+	})
+
+	t.Run("is found when wrapped", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		err := fmt.Errorf("decoding: %w", &HandlerError{Status: http.StatusBadRequest, Code: ErrCodeInvalidInput, Message: "bad body", Err: cause})
+		HandleError(recorder, req, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.JSONEq(t, `{"code":"invalid_input","message":"bad body"}`, recorder.Body.String())
+	})
+
+	t.Run("does not expose the cause", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		HandleError(recorder, req, &HandlerError{Status: http.StatusBadGateway, Code: ErrCodeInternal, Message: "upstream failed", Err: cause})
+
+		assert.Equal(t, http.StatusBadGateway, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), cause.Error())
+	})
+
+	t.Run("invalid status is reported as an internal error", func(t *testing.T) {
+		for _, status := range []int{0, 99, 1000} {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+			assert.NotPanics(t, func() {
+				HandleError(recorder, req, &HandlerError{Status: status, Code: ErrCodeInvalidInput, Message: "bad body"})
+			})
+
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code, "status %d", status)
+			assert.JSONEq(t, `{"code":"internal_error","message":"An internal error occurred"}`, recorder.Body.String())
+		}
+	})
+
+	t.Run("error message and unwrap", func(t *testing.T) {
+		err := &HandlerError{Status: http.StatusBadRequest, Message: "bad body", Err: cause}
+
+		assert.Equal(t, "bad body: unexpected EOF", err.Error())
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, "bad body", (&HandlerError{Message: "bad body"}).Error())
+	})
+}
+
 func TestCreatedResponse(t *testing.T) {
 	type sampleResponse struct {
 		ID   int    `json:"id"`
@@ -122,6 +336,14 @@ func TestCreatedResponse(t *testing.T) {
 		assert.JSONEq(t, expected, recorder.Body.String(), "Response body does not match expected")
 	})
 
+	t.Run("body has no trailing newline", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		CreatedResponse(recorder, req, sample)
+
+		assert.Equal(t, `{"id":1,"name":"Created"}`, recorder.Body.String())
+	})
+
 	t.Run("handles encode error gracefully", func(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodPost, "/test", nil)
@@ -134,7 +356,75 @@ func TestCreatedResponse(t *testing.T) {
 	})
 }
 
+func TestAcceptedResponse(t *testing.T) {
+	t.Run("successful http202 json response", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		AcceptedResponse(recorder, req, map[string]string{"status": "queued"})
+
+		assert.Equal(t, http.StatusAccepted, recorder.Code, "Expected status code 202 Accepted")
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"status":"queued"}`, recorder.Body.String())
+		assert.Equal(t, `{"status":"queued"}`, recorder.Body.String(), "expected compact JSON without a trailing newline")
+	})
+}
+
+func TestEncodeJSON(t *testing.T) {
+	t.Run("writes compact JSON with the given status", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		err := EncodeJSON(recorder, http.StatusTeapot, map[string]int{"b": 2, "a": 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTeapot, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"a":1,"b":2}`, recorder.Body.String())
+	})
+
+	t.Run("sends the status without a body when data cannot be encoded", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		err := EncodeJSON(recorder, http.StatusOK, make(chan int))
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+
+	t.Run("returns write errors", func(t *testing.T) {
+		writer := &brokenWriter{header: make(http.Header)}
+
+		err := EncodeJSON(writer, http.StatusCreated, map[string]string{"status": "ok"})
+
+		assert.ErrorIs(t, err, io.ErrClosedPipe)
+		assert.Equal(t, http.StatusCreated, writer.statusCode)
+	})
+}
+
+func TestNoContentResponse(t *testing.T) {
+	t.Run("successful http204 response without body", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/test", nil)
+		NoContentResponse(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code, "Expected status code 204 No Content")
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, 0, recorder.Body.Len(), "Expected no body bytes")
+	})
+}
+
 func TestHandleError_SpecificValidationErrors(t *testing.T) {
+	t.Run("handles ErrOffsetTooLarge", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		HandleError(recorder, req, services.ErrOffsetTooLarge)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"offset must not exceed 10000000"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
 	t.Run("handles ErrInvalidOffset", func(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/test", nil)
@@ -159,6 +449,95 @@ func TestHandleError_SpecificValidationErrors(t *testing.T) {
 		assert.JSONEq(t, expected, recorder.Body.String())
 	})
 
+	t.Run("handles ErrInvalidPage", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidPage)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		expected := `{"code":"invalid_input","message":"page must be a positive integer"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles ErrInvalidFields", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidFields)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"fields must be a comma-separated list of code, slug, brand, image_url, price, sale_price, category, label"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles ErrInvalidProductCode", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidProductCode)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"invalid input: product code must contain only uppercase letters, digits, underscores and hyphens"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles ErrInvalidSKU", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidSKU)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"invalid input: sku must contain only uppercase letters, digits, underscores and hyphens"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles ErrInvalidSalePrice", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidSalePrice)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"invalid input: sale_price must be a non-negative decimal number below the price"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles ErrInvalidDays", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidDays)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"days must be a positive integer"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles ErrInvalidWebhookEvent", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidWebhookEvent)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"event must be one of category.created, product.created"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
+	t.Run("handles ErrInvalidOnSale", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		HandleError(recorder, req, services.ErrInvalidOnSale)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		expected := `{"code":"invalid_input","message":"onSale must be a boolean"}`
+		assert.JSONEq(t, expected, recorder.Body.String())
+	})
+
 	t.Run("handles ErrInvalidPrice", func(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/test", nil)
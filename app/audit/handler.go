@@ -0,0 +1,75 @@
+// Package audit provides HTTP handlers for audit log retrieval.
+package audit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// EntryResponse represents an audit log entry in API responses.
+type EntryResponse struct {
+	RequestID   string    `json:"request_id"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	StatusCode  int       `json:"status_code"`
+	ActorID     string    `json:"actor_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	RequestBody string    `json:"request_body"`
+}
+
+// AuditService defines the interface for audit log business logic.
+type AuditService interface {
+	ListAuditLogs(ctx context.Context, path string, limit int, limitProvided bool) ([]services.AuditLogDTO, error)
+}
+
+// AuditHandler handles HTTP requests for the audit endpoints.
+type AuditHandler struct {
+	service AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler instance.
+func NewAuditHandler(s AuditService) *AuditHandler {
+	return &AuditHandler{service: s}
+}
+
+// HandleGet handles GET /audit requests for listing audit entries.
+// Supports query parameters: path, limit.
+func (h *AuditHandler) HandleGet(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+
+	var limit int
+	limitProvided := false
+	if s := query.Get("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return services.ErrInvalidLimit
+		}
+		limit, limitProvided = v, true
+	}
+
+	entries, err := h.service.ListAuditLogs(r.Context(), query.Get("path"), limit, limitProvided)
+	if err != nil {
+		return err
+	}
+
+	response := make([]EntryResponse, len(entries))
+	for i, e := range entries {
+		response[i] = EntryResponse{
+			RequestID:   e.RequestID,
+			Method:      e.Method,
+			Path:        e.Path,
+			StatusCode:  e.StatusCode,
+			ActorID:     e.ActorID,
+			Timestamp:   e.Timestamp.UTC(),
+			RequestBody: e.RequestBody,
+		}
+	}
+
+	api.OKResponse(w, r, response)
+	return nil
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// mockAuditService is a mock implementation of AuditService for testing.
+type mockAuditService struct {
+	listAuditLogsFunc func(ctx context.Context, path string, limit int, limitProvided bool) ([]services.AuditLogDTO, error)
+}
+
+func (m *mockAuditService) ListAuditLogs(ctx context.Context, path string, limit int, limitProvided bool) ([]services.AuditLogDTO, error) {
+	if m.listAuditLogsFunc != nil {
+		return m.listAuditLogsFunc(ctx, path, limit, limitProvided)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestHandleGet_Success(t *testing.T) {
+	mockSvc := &mockAuditService{
+		listAuditLogsFunc: func(ctx context.Context, path string, limit int, limitProvided bool) ([]services.AuditLogDTO, error) {
+			if path != "/v1/categories" {
+				t.Errorf("expected path /v1/categories, got %s", path)
+			}
+			if limit != 5 || !limitProvided {
+				t.Errorf("expected limit 5 provided, got %d (%v)", limit, limitProvided)
+			}
+			return []services.AuditLogDTO{
+				{RequestID: "req-1", Method: "POST", Path: path, StatusCode: 201, ActorID: "user-1"},
+			}, nil
+		},
+	}
+
+	handler := NewAuditHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?path=/v1/categories&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("audit.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response []EntryResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(response) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(response))
+	}
+
+	if response[0].ActorID != "user-1" {
+		t.Errorf("expected actor user-1, got %s", response[0].ActorID)
+	}
+}
+
+func TestHandleGet_InvalidLimit(t *testing.T) {
+	mockSvc := &mockAuditService{}
+
+	handler := NewAuditHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?limit=abc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("audit.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,105 @@
+// Package cache provides a Redis-backed key/value cache.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrMiss is returned by Get when the key is not present in the cache.
+var ErrMiss = errors.New("cache miss")
+
+// ProductKeyPrefix prefixes the cache keys of product details.
+const ProductKeyPrefix = "product:"
+
+// ProductKey returns the cache key for the details of the product with the given code.
+func ProductKey(code string) string {
+	return ProductKeyPrefix + code
+}
+
+// scanBatchSize is the number of keys requested per SCAN iteration.
+const scanBatchSize = 100
+
+// RedisCache is a cache backed by a Redis server.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// New connects to the Redis server at addr and returns a cache with a cleanup function.
+// Returns an error if the server cannot be reached.
+func New(addr, password string, db int) (cache *RedisCache, close func() error, err error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, nil, fmt.Errorf("failed to connect redis: %w", err)
+	}
+
+	return NewRedisCache(client), client.Close, nil
+}
+
+// NewRedisCache creates a new RedisCache using an existing client.
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Get returns the value stored under key.
+// Returns ErrMiss if the key doesn't exist.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrMiss
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set stores value under key for the given ttl.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete removes the given keys. Missing keys are ignored.
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// DeleteByPrefix removes every key starting with prefix.
+func (c *RedisCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	iter := c.client.Scan(ctx, 0, prefix+"*", scanBatchSize).Iterator()
+
+	keys := make([]string, 0, scanBatchSize)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == scanBatchSize {
+			if err := c.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		return c.client.Del(ctx, keys...).Err()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/shopspring/decimal"
+)
+
+// Response represents the paginated product list response.
+type Response struct {
+	Products []Product `json:"products"`
+	Total    int64     `json:"total"`
+}
+
+// SparseResponse is the paginated product list response when a sparse
+// fieldset is requested: each product only holds the requested fields.
+type SparseResponse struct {
+	Products []map[string]any `json:"products"`
+	Total    int64            `json:"total"`
+}
+
+// Category represents a category in API responses.
+type Category struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Product represents a product in API responses.
+type Product struct {
+	Code     string    `json:"code"`
+	Slug     string    `json:"slug"`
+	Brand    string    `json:"brand,omitempty"`
+	ImageURL string    `json:"image_url,omitempty"`
+	Price    api.Price `json:"price"`
+	Category *Category `json:"category,omitempty"`
+	Label    string    `json:"label"`
+	// SalePrice is omitted when the product is not on sale.
+	SalePrice *api.Price `json:"sale_price,omitempty"`
+}
+
+// Variant represents a product variant in API responses.
+type Variant struct {
+	Name          string    `json:"name"`
+	SKU           string    `json:"sku"`
+	Barcode       string    `json:"barcode,omitempty"`
+	Price         api.Price `json:"price"`
+	StockQuantity int       `json:"stock_quantity"`
+}
+
+// AdjustStockRequest represents the request body for adjusting a variant's stock.
+type AdjustStockRequest struct {
+	Delta *int `json:"delta"`
+}
+
+// ProductDetail represents detailed product information in API responses.
+// Timestamps are encoded in RFC3339 format (UTC).
+type ProductDetail struct {
+	Code      string    `json:"code"`
+	Slug      string    `json:"slug"`
+	Brand     string    `json:"brand,omitempty"`
+	ImageURL  string    `json:"image_url,omitempty"`
+	Price     api.Price `json:"price"`
+	Category  *Category `json:"category,omitempty"`
+	Variants  []Variant `json:"variants"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// MaxOrderQty is null when there is no upper limit.
+	MinOrderQty int    `json:"min_order_quantity"`
+	MaxOrderQty *int   `json:"max_order_quantity"`
+	Label       string `json:"label"`
+	// SalePrice is omitted when the product is not on sale.
+	SalePrice *api.Price `json:"sale_price,omitempty"`
+}
+
+// UpdateProductRequest represents the request body for updating a product.
+// An empty image_url removes the image, a max_order_quantity of 0 removes the upper limit
+// and a sale_price of 0 ends the product's sale.
+type UpdateProductRequest struct {
+	Price       *decimal.Decimal `json:"price"`
+	Brand       *string          `json:"brand"`
+	ImageURL    *string          `json:"image_url"`
+	MinOrderQty *int             `json:"min_order_quantity"`
+	MaxOrderQty *int             `json:"max_order_quantity"`
+	SalePrice   *decimal.Decimal `json:"sale_price"`
+}
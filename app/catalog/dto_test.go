@@ -0,0 +1,47 @@
+package catalog
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/shopspring/decimal"
+)
+
+func TestProductDetail_JSONFields(t *testing.T) {
+	detail := mapDetailToResponse(&services.ProductDetailDTO{
+		Code:        "PROD001",
+		Slug:        "prod001",
+		Price:       decimal.RequireFromString("10.99"),
+		Category:    &services.CategoryDTO{Code: "SHOES", Name: "Shoes"},
+		Variants:    []services.VariantDTO{{Name: "Size 42", SKU: "SKU001-42", Price: decimal.RequireFromString("10.99")}},
+		CreatedAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		MinOrderQty: 1,
+		Label:       "none",
+	})
+
+	body, err := json.Marshal(detail)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Empty brand, image URL and sale price are omitted.
+	expected := []string{"category", "code", "created_at", "label", "max_order_quantity", "min_order_quantity", "price", "slug", "updated_at", "variants"}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected fields %v, got %v", expected, keys)
+	}
+}
@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/shopspring/decimal"
+)
+
+// Feed availability values.
+const (
+	availabilityInStock    = "in stock"
+	availabilityOutOfStock = "out of stock"
+)
+
+// FeedItem is an item of the product feed, in the format of Google Merchant Center.
+// Prices are formatted as the amount followed by the currency code, e.g. "10.99 USD".
+type FeedItem struct {
+	ID string `json:"id"`
+	// ItemGroupID is the product code when the item is one of its variants.
+	ItemGroupID  string `json:"item_group_id,omitempty"`
+	Title        string `json:"title"`
+	Link         string `json:"link"`
+	ImageLink    string `json:"image_link,omitempty"`
+	Brand        string `json:"brand,omitempty"`
+	GTIN         string `json:"gtin,omitempty"`
+	Price        string `json:"price"`
+	SalePrice    string `json:"sale_price,omitempty"`
+	Availability string `json:"availability"`
+}
+
+// HandleFeed handles GET /v1/catalog/feed.json requests.
+// It returns every product as {"items": [...]}, without pagination: products are
+// streamed from the service as they are loaded. Each variant is an item of its own,
+// grouped by the product code; a product without variants is a single item.
+func (h *CatalogHandler) HandleFeed(w http.ResponseWriter, r *http.Request) error {
+	feed := &feedWriter{w: w}
+
+	err := h.service.EachProduct(r.Context(), func(p *services.ProductDetailDTO) error {
+		for _, item := range h.feedItems(p) {
+			if err := feed.write(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		err = feed.close()
+	}
+
+	if err != nil {
+		// Once the body has started, the status can no longer change: the
+		// truncated body tells the client the feed is incomplete.
+		if !feed.started {
+			return err
+		}
+		logger.WithContext(r.Context()).Error("failed to stream product feed",
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
+
+// feedItems maps a product to its feed items.
+func (h *CatalogHandler) feedItems(p *services.ProductDetailDTO) []FeedItem {
+	title := p.Code
+	if p.Brand != "" {
+		title = p.Brand + " " + p.Code
+	}
+
+	product := FeedItem{
+		ID:           p.Code,
+		Title:        title,
+		Link:         h.baseURL + "/v1/catalog/by-slug/" + url.PathEscape(p.Slug),
+		ImageLink:    p.ImageURL,
+		Brand:        p.Brand,
+		Price:        h.feedPrice(p.Price),
+		SalePrice:    h.feedSalePrice(p.SalePrice, p.Price),
+		Availability: availabilityOutOfStock,
+	}
+
+	if len(p.Variants) == 0 {
+		return []FeedItem{product}
+	}
+
+	items := make([]FeedItem, len(p.Variants))
+	for i, v := range p.Variants {
+		item := product
+		item.ID = v.SKU
+		item.ItemGroupID = p.Code
+		item.Title = title + " " + v.Name
+		item.GTIN = v.Barcode
+		item.Price = h.feedPrice(v.Price)
+		item.SalePrice = h.feedSalePrice(p.SalePrice, v.Price)
+		if v.StockQuantity > 0 {
+			item.Availability = availabilityInStock
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// feedPrice formats a price for the feed, e.g. "10.99 USD".
+func (h *CatalogHandler) feedPrice(d decimal.Decimal) string {
+	return d.StringFixed(2) + " " + h.currency
+}
+
+// feedSalePrice formats the product's sale price for an item priced at price.
+// It is empty unless the sale price is below it, as variants may be priced
+// below the product's sale price.
+func (h *CatalogHandler) feedSalePrice(salePrice *decimal.Decimal, price decimal.Decimal) string {
+	if salePrice == nil || !salePrice.LessThan(price) {
+		return ""
+	}
+	return h.feedPrice(*salePrice)
+}
+
+// feedWriter streams the {"items": [...]} feed document. The status and the
+// opening of the document are written along with the first item.
+type feedWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+// write appends item to the document.
+func (f *feedWriter) write(item FeedItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if !f.started {
+		f.start()
+		separator = ""
+	}
+	_, err = io.WriteString(f.w, separator+string(data))
+	return err
+}
+
+// close terminates the document.
+func (f *feedWriter) close() error {
+	if !f.started {
+		f.start()
+	}
+	_, err := io.WriteString(f.w, "]}\n")
+	return err
+}
+
+// start writes the status and the opening of the document.
+func (f *feedWriter) start() {
+	f.started = true
+	f.w.Header().Set("Content-Type", "application/json")
+	f.w.WriteHeader(http.StatusOK)
+	// Errors surface on the next write.
+	_, _ = io.WriteString(f.w, `{"items":[`)
+}
@@ -0,0 +1,161 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleFeed_JSONStructure(t *testing.T) {
+	salePrice := decimal.RequireFromString("8.50")
+	mockSvc := &mockCatalogService{
+		eachProductFunc: func(ctx context.Context, fn func(*services.ProductDetailDTO) error) error {
+			products := []*services.ProductDetailDTO{
+				{
+					Code:     "PROD001",
+					Slug:     "prod001",
+					Brand:    "Acme",
+					ImageURL: "https://cdn.example.com/prod001.jpg",
+					Price:    decimal.RequireFromString("10.99"),
+					Variants: []services.VariantDTO{
+						{Name: "Small", SKU: "SKU001S", Barcode: "4006381333931", Price: decimal.RequireFromString("10.99"), StockQuantity: 3},
+						{Name: "Large", SKU: "SKU001L", Price: decimal.RequireFromString("12"), StockQuantity: 0},
+					},
+				},
+				{Code: "PROD002", Slug: "prod002", Price: decimal.RequireFromString("10"), SalePrice: &salePrice},
+			}
+			for _, p := range products {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{BaseURL: "https://shop.example.com", Currency: "EUR"})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/feed.json", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleFeed", handler.HandleFeed).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	expected := `{"items":[
+		{"id":"SKU001S","item_group_id":"PROD001","title":"Acme PROD001 Small","link":"https://shop.example.com/v1/catalog/by-slug/prod001",
+		 "image_link":"https://cdn.example.com/prod001.jpg","brand":"Acme","gtin":"4006381333931","price":"10.99 EUR","availability":"in stock"},
+		{"id":"SKU001L","item_group_id":"PROD001","title":"Acme PROD001 Large","link":"https://shop.example.com/v1/catalog/by-slug/prod001",
+		 "image_link":"https://cdn.example.com/prod001.jpg","brand":"Acme","price":"12.00 EUR","availability":"out of stock"},
+		{"id":"PROD002","title":"PROD002","link":"https://shop.example.com/v1/catalog/by-slug/prod002",
+		 "price":"10.00 EUR","sale_price":"8.50 EUR","availability":"out of stock"}
+	]}`
+	assert.JSONEq(t, expected, w.Body.String())
+}
+
+func TestHandleFeed_SalePriceOnlyBelowItemPrice(t *testing.T) {
+	salePrice := decimal.RequireFromString("9")
+	mockSvc := &mockCatalogService{
+		eachProductFunc: func(ctx context.Context, fn func(*services.ProductDetailDTO) error) error {
+			return fn(&services.ProductDetailDTO{
+				Code:      "PROD001",
+				Slug:      "prod001",
+				Price:     decimal.RequireFromString("12"),
+				SalePrice: &salePrice,
+				Variants: []services.VariantDTO{
+					{Name: "Small", SKU: "SKU001S", Price: decimal.RequireFromString("8")},
+					{Name: "Medium", SKU: "SKU001M", Price: decimal.RequireFromString("9")},
+					{Name: "Large", SKU: "SKU001L", Price: decimal.RequireFromString("12")},
+				},
+			})
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/feed.json", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleFeed", NewCatalogHandler(mockSvc, CatalogHandlerConfig{}).HandleFeed).ServeHTTP(w, req)
+
+	var feed struct {
+		Items []FeedItem `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+	require.Len(t, feed.Items, 3)
+	assert.Empty(t, feed.Items[0].SalePrice, "variant priced below the sale price")
+	assert.Empty(t, feed.Items[1].SalePrice, "variant priced at the sale price")
+	assert.Equal(t, "9.00 USD", feed.Items[2].SalePrice)
+}
+
+func TestHandleFeed_DefaultsToUSD(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		eachProductFunc: func(ctx context.Context, fn func(*services.ProductDetailDTO) error) error {
+			return fn(&services.ProductDetailDTO{Code: "PROD001", Slug: "prod001", Price: decimal.RequireFromString("10.99")})
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/feed.json", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleFeed", NewCatalogHandler(mockSvc, CatalogHandlerConfig{}).HandleFeed).ServeHTTP(w, req)
+
+	var feed struct {
+		Items []FeedItem `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+	require.Len(t, feed.Items, 1)
+	assert.Equal(t, "10.99 USD", feed.Items[0].Price)
+}
+
+func TestHandleFeed_EmptyCatalog(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		eachProductFunc: func(ctx context.Context, fn func(*services.ProductDetailDTO) error) error {
+			return nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/feed.json", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleFeed", NewCatalogHandler(mockSvc, CatalogHandlerConfig{}).HandleFeed).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[]}`, w.Body.String())
+}
+
+func TestHandleFeed_ErrorBeforeFirstItem(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		eachProductFunc: func(ctx context.Context, fn func(*services.ProductDetailDTO) error) error {
+			return errors.New("database error")
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/feed.json", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleFeed", NewCatalogHandler(mockSvc, CatalogHandlerConfig{}).HandleFeed).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestHandleFeed_ErrorMidStreamTruncatesFeed(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		eachProductFunc: func(ctx context.Context, fn func(*services.ProductDetailDTO) error) error {
+			if err := fn(&services.ProductDetailDTO{Code: "PROD001", Slug: "prod001", Price: decimal.RequireFromString("10.99")}); err != nil {
+				return err
+			}
+			return errors.New("database error")
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/feed.json", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleFeed", NewCatalogHandler(mockSvc, CatalogHandlerConfig{}).HandleFeed).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, json.Valid(w.Body.Bytes()), "expected a truncated feed, got %s", w.Body.String())
+	assert.NotContains(t, w.Body.String(), "internal_error")
+}
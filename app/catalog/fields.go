@@ -0,0 +1,56 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// productFields maps the top-level JSON field names of Product to accessors
+// for sparse fieldsets. An accessor reports false for a value that the full
+// Product omits (see its omitempty tags), so both representations agree.
+var productFields = map[string]func(p Product) (any, bool){
+	"code":       func(p Product) (any, bool) { return p.Code, true },
+	"slug":       func(p Product) (any, bool) { return p.Slug, true },
+	"brand":      func(p Product) (any, bool) { return p.Brand, p.Brand != "" },
+	"image_url":  func(p Product) (any, bool) { return p.ImageURL, p.ImageURL != "" },
+	"price":      func(p Product) (any, bool) { return p.Price, true },
+	"sale_price": func(p Product) (any, bool) { return p.SalePrice, p.SalePrice != nil },
+	"category":   func(p Product) (any, bool) { return p.Category, p.Category != nil },
+	"label":      func(p Product) (any, bool) { return p.Label, true },
+}
+
+// parseFields parses the fields query parameter, a comma-separated list of
+// Product field names. An empty value selects all fields and yields nil.
+// Returns services.ErrInvalidFields for an empty or unknown field name.
+func parseFields(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	names := strings.Split(value, ",")
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if _, ok := productFields[name]; !ok {
+			return nil, services.ErrInvalidFields
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+// selectFields returns the products reduced to the given fields.
+// Nested objects such as category are kept or dropped as a whole.
+func selectFields(products []Product, fields []string) []map[string]any {
+	result := make([]map[string]any, len(products))
+	for i, p := range products {
+		selected := make(map[string]any, len(fields))
+		for _, name := range fields {
+			if value, ok := productFields[name](p); ok {
+				selected[name] = value
+			}
+		}
+		result[i] = selected
+	}
+	return result
+}
@@ -3,114 +3,278 @@ package catalog
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/mytheresa/go-hiring-challenge/app/api"
 	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/mytheresa/go-hiring-challenge/models"
 	"github.com/shopspring/decimal"
 )
 
-// Response represents the paginated product list response.
-type Response struct {
-	Products []Product `json:"products"`
-	Total    int64     `json:"total"`
-}
-
-// Category represents a category in API responses.
-type Category struct {
-	Code string `json:"code"`
-	Name string `json:"name"`
-}
-
-// Product represents a product in API responses.
-type Product struct {
-	Code     string    `json:"code"`
-	Price    float64   `json:"price"`
-	Category *Category `json:"category,omitempty"`
-}
-
-// Variant represents a product variant in API responses.
-type Variant struct {
-	Name  string  `json:"name"`
-	SKU   string  `json:"sku"`
-	Price float64 `json:"price"`
-}
-
-// ProductDetail represents detailed product information in API responses.
-type ProductDetail struct {
-	Code     string    `json:"code"`
-	Price    float64   `json:"price"`
-	Category *Category `json:"category,omitempty"`
-	Variants []Variant `json:"variants"`
-}
-
 // CatalogService defines the interface for catalog business logic.
 type CatalogService interface {
 	ValidatePagination(offset, limit int, limitProvided bool) services.PaginationParams
 	ListProducts(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error)
+	ListNewArrivals(ctx context.Context, days int, params services.PaginationParams) (*services.ProductListResult, error)
 	GetProductByCode(ctx context.Context, code string) (*services.ProductDetailDTO, error)
+	GetProductBySlug(ctx context.Context, slug string) (*services.ProductDetailDTO, error)
+	ListProductSlugs(ctx context.Context, offset, limit int) ([]string, int64, error)
+	EachProduct(ctx context.Context, fn func(*services.ProductDetailDTO) error) error
+	GetProductByBarcode(ctx context.Context, barcode string) (*services.ProductDetailDTO, error)
+	AdjustVariantStock(ctx context.Context, code, sku string, delta int) (*services.VariantDTO, error)
+	UpdateProduct(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error)
+}
+
+// CatalogHandlerConfig holds the settings of a CatalogHandler.
+type CatalogHandlerConfig struct {
+	// BaseURL is the public URL of the API, without a trailing slash,
+	// used to build the absolute links of the sitemap and the product feed.
+	BaseURL string
+	// Currency is the ISO 4217 code of the currency prices are in,
+	// as stated by the product feed. It defaults to USD.
+	Currency string
 }
 
 // CatalogHandler handles HTTP requests for the catalog endpoints.
 type CatalogHandler struct {
-	service CatalogService
+	service  CatalogService
+	baseURL  string
+	currency string
 }
 
 // NewCatalogHandler creates a new CatalogHandler instance.
-func NewCatalogHandler(s CatalogService) *CatalogHandler {
-	return &CatalogHandler{service: s}
+func NewCatalogHandler(s CatalogService, cfg CatalogHandlerConfig) *CatalogHandler {
+	h := &CatalogHandler{service: s, baseURL: cfg.BaseURL, currency: cfg.Currency}
+	if h.currency == "" {
+		h.currency = "USD"
+	}
+	return h
 }
 
 // HandleGet handles GET /catalog requests for listing products.
-// Supports query parameters: offset, limit, category, priceLessThan.
+// Supports query parameters: offset, limit, category, brand, priceLessThan, inStock,
+// updatedAfter, minOrderQtyLessThan, label, onSale, and fields, a comma-separated list of
+// product fields to return (e.g. fields=code,price).
 func (h *CatalogHandler) HandleGet(w http.ResponseWriter, r *http.Request) error {
+	fields, err := parseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		return err
+	}
+
+	result, _, err := h.listProducts(r)
+	if err != nil {
+		return err
+	}
+
+	if fields != nil {
+		api.SetTotalCount(w, result.Total)
+		api.OKResponse(w, r, SparseResponse{
+			Products: selectFields(mapProductsToResponse(result.Products), fields),
+			Total:    result.Total,
+		})
+		return nil
+	}
+
+	writeProducts(w, r, result)
+	return nil
+}
+
+// HandleGetV2 handles GET /v2/catalog requests. It accepts the same query
+// parameters as HandleGet, but wraps the page in an api.PaginatedResponse, so
+// products are listed under "items" along with the offset and limit applied.
+func (h *CatalogHandler) HandleGetV2(w http.ResponseWriter, r *http.Request) error {
+	fields, err := parseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		return err
+	}
+
+	result, params, err := h.listProducts(r)
+	if err != nil {
+		return err
+	}
+
+	products := mapProductsToResponse(result.Products)
+	if fields != nil {
+		api.OKPaginatedResponse(w, r, selectFields(products, fields), result.Total, params)
+		return nil
+	}
+
+	api.OKPaginatedResponse(w, r, products, result.Total, params)
+	return nil
+}
+
+// HandleNewArrivals handles GET /v1/catalog/new-arrivals requests for listing
+// the products created in the last days days (default 7, at most 90).
+// Supports the offset and limit pagination parameters.
+func (h *CatalogHandler) HandleNewArrivals(w http.ResponseWriter, r *http.Request) error {
+	days := services.DefaultNewArrivalsDays
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 {
+			return services.ErrInvalidDays
+		}
+		days = parsed
+	}
+
+	params, err := h.parsePagination(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.ListNewArrivals(r.Context(), days, params)
+	if err != nil {
+		return err
+	}
+	if err := checkOffset(result, params); err != nil {
+		return err
+	}
+
+	writeProducts(w, r, result)
+	return nil
+}
+
+// HandleFeatured handles GET /v1/catalog/featured requests, a shortcut for
+// GET /v1/catalog?label=featured. Supports the offset and limit pagination
+// parameters; the label is implied.
+func (h *CatalogHandler) HandleFeatured(w http.ResponseWriter, r *http.Request) error {
+	params, err := h.parsePagination(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.ListProducts(r.Context(), params, services.FilterParams{Label: models.LabelFeatured})
+	if err != nil {
+		return err
+	}
+	if err := checkOffset(result, params); err != nil {
+		return err
+	}
+
+	writeProducts(w, r, result)
+	return nil
+}
+
+// writeProducts writes a page of products in the v1 listing format.
+// The total is also sent in the api.TotalCountHeader.
+func writeProducts(w http.ResponseWriter, r *http.Request, result *services.ProductListResult) {
+	api.SetTotalCount(w, result.Total)
+	api.OKResponse(w, r, Response{
+		Products: mapProductsToResponse(result.Products),
+		Total:    result.Total,
+	})
+}
+
+// parsePagination parses and validates the offset and limit query parameters.
+func (h *CatalogHandler) parsePagination(r *http.Request) (services.PaginationParams, error) {
 	query := r.URL.Query()
 
-	// Parse and validate pagination
 	offset, err := parseQueryIntWithValidation(query.Get("offset"))
 	if err != nil {
-		return services.ErrInvalidOffset
+		return services.PaginationParams{}, services.ErrInvalidOffset
 	}
 	if offset < 0 {
-		return services.ErrInvalidOffset
+		return services.PaginationParams{}, services.ErrInvalidOffset
+	}
+	if offset > services.MaxOffset {
+		return services.PaginationParams{}, services.ErrOffsetTooLarge
 	}
 
 	limit, limitProvided, err := parseQueryIntWithFlagAndValidation(query.Get("limit"))
 	if err != nil {
-		return services.ErrInvalidLimit
+		return services.PaginationParams{}, services.ErrInvalidLimit
 	}
 
-	params := h.service.ValidatePagination(offset, limit, limitProvided)
+	return h.service.ValidatePagination(offset, limit, limitProvided), nil
+}
+
+// checkOffset reports paging past the last product, which is a client error;
+// an empty result set is not.
+func checkOffset(result *services.ProductListResult, params services.PaginationParams) error {
+	if result.Total > 0 && int64(params.Offset) >= result.Total {
+		return services.ErrOffsetExceedsTotal
+	}
+	return nil
+}
+
+// listProducts parses the pagination and filter query parameters of a catalog
+// listing request and returns the requested page along with the pagination applied.
+func (h *CatalogHandler) listProducts(r *http.Request) (*services.ProductListResult, services.PaginationParams, error) {
+	query := r.URL.Query()
+
+	params, err := h.parsePagination(r)
+	if err != nil {
+		return nil, services.PaginationParams{}, err
+	}
 
 	// Parse filters
 	filter := services.FilterParams{
 		Category: query.Get("category"),
+		Brand:    query.Get("brand"),
 	}
 
 	if priceLessThanStr := query.Get("priceLessThan"); priceLessThanStr != "" {
 		price, err := decimal.NewFromString(priceLessThanStr)
 		if err != nil {
-			return services.ErrInvalidPrice
+			return nil, services.PaginationParams{}, services.ErrInvalidPrice
 		}
 		if price.IsNegative() {
-			return services.ErrNegativePrice
+			return nil, services.PaginationParams{}, services.ErrNegativePrice
 		}
 		filter.PriceLessThan = &price
 	}
 
+	if inStockStr := query.Get("inStock"); inStockStr != "" {
+		inStock, err := strconv.ParseBool(inStockStr)
+		if err != nil {
+			return nil, services.PaginationParams{}, services.ErrInvalidInStock
+		}
+		filter.InStock = inStock
+	}
+
+	if updatedAfterStr := query.Get("updatedAfter"); updatedAfterStr != "" {
+		updatedAfter, err := time.Parse(time.RFC3339, updatedAfterStr)
+		if err != nil {
+			return nil, services.PaginationParams{}, services.ErrInvalidUpdatedAfter
+		}
+		filter.UpdatedAfter = &updatedAfter
+	}
+
+	if minOrderQtyStr := query.Get("minOrderQtyLessThan"); minOrderQtyStr != "" {
+		minOrderQty, err := strconv.Atoi(minOrderQtyStr)
+		if err != nil {
+			return nil, services.PaginationParams{}, services.ErrInvalidMinOrderQty
+		}
+		filter.MinOrderQtyLessThan = &minOrderQty
+	}
+
+	if label := query.Get("label"); label != "" {
+		if err := services.ValidateLabel(label); err != nil {
+			return nil, services.PaginationParams{}, err
+		}
+		filter.Label = label
+	}
+
+	if onSaleStr := query.Get("onSale"); onSaleStr != "" {
+		onSale, err := strconv.ParseBool(onSaleStr)
+		if err != nil {
+			return nil, services.PaginationParams{}, services.ErrInvalidOnSale
+		}
+		filter.OnSale = onSale
+	}
+
 	result, err := h.service.ListProducts(r.Context(), params, filter)
 	if err != nil {
-		return err
+		return nil, services.PaginationParams{}, err
 	}
 
-	response := Response{
-		Products: mapProductsToResponse(result.Products),
-		Total:    result.Total,
+	if err := checkOffset(result, params); err != nil {
+		return nil, services.PaginationParams{}, err
 	}
 
-	api.OKResponse(w, r, response)
-	return nil
+	return result, params, nil
 }
 
 // HandleGetByCode handles GET /catalog/{code} requests for product details.
@@ -127,12 +291,94 @@ func (h *CatalogHandler) HandleGetByCode(w http.ResponseWriter, r *http.Request)
 	return nil
 }
 
+// HandleGetBySlug handles GET /catalog/by-slug/{slug} requests for product details.
+func (h *CatalogHandler) HandleGetBySlug(w http.ResponseWriter, r *http.Request) error {
+	detail, err := h.service.GetProductBySlug(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		return err
+	}
+
+	api.OKResponse(w, r, mapDetailToResponse(detail))
+	return nil
+}
+
+// HandleGetByBarcode handles GET /catalog/by-barcode/{barcode} requests for product details.
+func (h *CatalogHandler) HandleGetByBarcode(w http.ResponseWriter, r *http.Request) error {
+	detail, err := h.service.GetProductByBarcode(r.Context(), r.PathValue("barcode"))
+	if err != nil {
+		return err
+	}
+
+	api.OKResponse(w, r, mapDetailToResponse(detail))
+	return nil
+}
+
+// HandleUpdate handles PUT /catalog/{code} requests for updating a product.
+func (h *CatalogHandler) HandleUpdate(w http.ResponseWriter, r *http.Request) error {
+	var req UpdateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return invalidBodyError(err)
+	}
+
+	input := services.UpdateProductInput{
+		Price:       req.Price,
+		Brand:       req.Brand,
+		ImageURL:    req.ImageURL,
+		MinOrderQty: req.MinOrderQty,
+		MaxOrderQty: req.MaxOrderQty,
+		SalePrice:   req.SalePrice,
+	}
+
+	detail, err := h.service.UpdateProduct(r.Context(), r.PathValue("code"), input)
+	if err != nil {
+		return err
+	}
+
+	api.OKResponse(w, r, mapDetailToResponse(detail))
+	return nil
+}
+
+// HandleAdjustStock handles PATCH /catalog/{code}/variants/{sku}/stock requests.
+// The request body {"delta": n} is added to the variant's current stock quantity.
+func (h *CatalogHandler) HandleAdjustStock(w http.ResponseWriter, r *http.Request) error {
+	var req AdjustStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return invalidBodyError(err)
+	}
+	if req.Delta == nil {
+		return services.ErrInvalidStockDelta
+	}
+
+	variant, err := h.service.AdjustVariantStock(r.Context(), r.PathValue("code"), r.PathValue("sku"), *req.Delta)
+	if err != nil {
+		return err
+	}
+
+	response := Variant{
+		Name:          variant.Name,
+		SKU:           variant.SKU,
+		Price:         api.NewPrice(variant.Price),
+		StockQuantity: variant.StockQuantity,
+	}
+
+	api.OKResponse(w, r, response)
+	return nil
+}
+
+// mapProductsToResponse maps products to their API representation. The result
+// is never nil, even for nil products, so an empty page encodes as [] rather than null.
 func mapProductsToResponse(products []services.ProductDTO) []Product {
 	result := make([]Product, len(products))
 	for i, p := range products {
 		result[i] = Product{
-			Code:  p.Code,
-			Price: p.Price,
+			Code:     p.Code,
+			Slug:     p.Slug,
+			Brand:    p.Brand,
+			ImageURL: p.ImageURL,
+			Price:    api.NewPrice(p.Price),
+			Label:    p.Label,
+
+			SalePrice: salePrice(p.SalePrice),
 		}
 		if p.Category != nil {
 			result[i].Category = &Category{
@@ -144,11 +390,43 @@ func mapProductsToResponse(products []services.ProductDTO) []Product {
 	return result
 }
 
+// salePrice formats an optional sale price, keeping nil for products that are
+// not on sale.
+func salePrice(d *decimal.Decimal) *api.Price {
+	if d == nil {
+		return nil
+	}
+	price := api.NewPrice(*d)
+	return &price
+}
+
+// invalidBodyError reports a request body that could not be decoded as JSON.
+func invalidBodyError(err error) error {
+	return &api.HandlerError{
+		Status:  http.StatusBadRequest,
+		Code:    api.ErrCodeInvalidInput,
+		Message: "request body must be valid JSON",
+		Err:     err,
+	}
+}
+
+// mapDetailToResponse maps a product's details to their API representation.
+// Variants are never nil, so a product without variants encodes them as [].
 func mapDetailToResponse(detail *services.ProductDetailDTO) ProductDetail {
 	response := ProductDetail{
-		Code:     detail.Code,
-		Price:    detail.Price,
-		Variants: make([]Variant, len(detail.Variants)),
+		Code:      detail.Code,
+		Slug:      detail.Slug,
+		Brand:     detail.Brand,
+		ImageURL:  detail.ImageURL,
+		Price:     api.NewPrice(detail.Price),
+		Variants:  make([]Variant, len(detail.Variants)),
+		CreatedAt: detail.CreatedAt.UTC(),
+		UpdatedAt: detail.UpdatedAt.UTC(),
+
+		MinOrderQty: detail.MinOrderQty,
+		MaxOrderQty: detail.MaxOrderQty,
+		Label:       detail.Label,
+		SalePrice:   salePrice(detail.SalePrice),
 	}
 
 	if detail.Category != nil {
@@ -160,9 +438,11 @@ func mapDetailToResponse(detail *services.ProductDetailDTO) ProductDetail {
 
 	for i, v := range detail.Variants {
 		response.Variants[i] = Variant{
-			Name:  v.Name,
-			SKU:   v.SKU,
-			Price: v.Price,
+			Name:          v.Name,
+			SKU:           v.SKU,
+			Barcode:       v.Barcode,
+			Price:         api.NewPrice(v.Price),
+			StockQuantity: v.StockQuantity,
 		}
 	}
 
@@ -1,23 +1,58 @@
 package catalog
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/api/apitest"
 	"github.com/mytheresa/go-hiring-challenge/app/services"
 	"github.com/shopspring/decimal"
 )
 
 // mockCatalogService is a mock implementation of CatalogService for testing.
 type mockCatalogService struct {
-	validatePaginationFunc func(offset, limit int, limitProvided bool) services.PaginationParams
-	listProductsFunc       func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error)
-	getProductByCodeFunc   func(ctx context.Context, code string) (*services.ProductDetailDTO, error)
+	validatePaginationFunc  func(offset, limit int, limitProvided bool) services.PaginationParams
+	listProductsFunc        func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error)
+	listNewArrivalsFunc     func(ctx context.Context, days int, params services.PaginationParams) (*services.ProductListResult, error)
+	getProductByCodeFunc    func(ctx context.Context, code string) (*services.ProductDetailDTO, error)
+	getProductBySlugFunc    func(ctx context.Context, slug string) (*services.ProductDetailDTO, error)
+	listProductSlugsFunc    func(ctx context.Context, offset, limit int) ([]string, int64, error)
+	eachProductFunc         func(ctx context.Context, fn func(*services.ProductDetailDTO) error) error
+	getProductByBarcodeFunc func(ctx context.Context, barcode string) (*services.ProductDetailDTO, error)
+	adjustStockFunc         func(ctx context.Context, code, sku string, delta int) (*services.VariantDTO, error)
+	updateProductFunc       func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error)
+	// calls counts the calls to the service methods, see newMockCatalogService.
+	// ValidatePagination is left out: handlers call it before validating the
+	// filters, so it does not show that the request reached the service.
+	calls atomic.Int32
+}
+
+// newMockCatalogService returns an empty mock whose function fields the test sets.
+// The test fails if the handler never calls the mock, so tests that expect the
+// service to be reached cannot pass by rejecting the request early.
+func newMockCatalogService(t *testing.T) *mockCatalogService {
+	t.Helper()
+
+	m := &mockCatalogService{}
+	t.Cleanup(func() {
+		if m.calls.Load() == 0 {
+			t.Errorf("mock catalog service was never called")
+		}
+	})
+	return m
 }
 
 func (m *mockCatalogService) ValidatePagination(offset, limit int, limitProvided bool) services.PaginationParams {
@@ -28,42 +63,99 @@ func (m *mockCatalogService) ValidatePagination(offset, limit int, limitProvided
 }
 
 func (m *mockCatalogService) ListProducts(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+	m.calls.Add(1)
 	if m.listProductsFunc != nil {
 		return m.listProductsFunc(ctx, params, filter)
 	}
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockCatalogService) ListNewArrivals(ctx context.Context, days int, params services.PaginationParams) (*services.ProductListResult, error) {
+	m.calls.Add(1)
+	if m.listNewArrivalsFunc != nil {
+		return m.listNewArrivalsFunc(ctx, days, params)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockCatalogService) GetProductByCode(ctx context.Context, code string) (*services.ProductDetailDTO, error) {
+	m.calls.Add(1)
 	if m.getProductByCodeFunc != nil {
 		return m.getProductByCodeFunc(ctx, code)
 	}
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockCatalogService) GetProductBySlug(ctx context.Context, slug string) (*services.ProductDetailDTO, error) {
+	m.calls.Add(1)
+	if m.getProductBySlugFunc != nil {
+		return m.getProductBySlugFunc(ctx, slug)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCatalogService) ListProductSlugs(ctx context.Context, offset, limit int) ([]string, int64, error) {
+	m.calls.Add(1)
+	if m.listProductSlugsFunc != nil {
+		return m.listProductSlugsFunc(ctx, offset, limit)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *mockCatalogService) EachProduct(ctx context.Context, fn func(*services.ProductDetailDTO) error) error {
+	m.calls.Add(1)
+	if m.eachProductFunc != nil {
+		return m.eachProductFunc(ctx, fn)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockCatalogService) GetProductByBarcode(ctx context.Context, barcode string) (*services.ProductDetailDTO, error) {
+	m.calls.Add(1)
+	if m.getProductByBarcodeFunc != nil {
+		return m.getProductByBarcodeFunc(ctx, barcode)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCatalogService) AdjustVariantStock(ctx context.Context, code, sku string, delta int) (*services.VariantDTO, error) {
+	m.calls.Add(1)
+	if m.adjustStockFunc != nil {
+		return m.adjustStockFunc(ctx, code, sku, delta)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCatalogService) UpdateProduct(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+	m.calls.Add(1)
+	if m.updateProductFunc != nil {
+		return m.updateProductFunc(ctx, code, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func TestHandleGetByCode_Success(t *testing.T) {
 	// Setup mock service
-	mockSvc := &mockCatalogService{
-		getProductByCodeFunc: func(ctx context.Context, code string) (*services.ProductDetailDTO, error) {
-			if code == "PROD001" {
-				return &services.ProductDetailDTO{
-					Code:  "PROD001",
-					Price: 10.99,
-					Category: &services.CategoryDTO{
-						Code: "CLOTHING",
-						Name: "Clothing",
-					},
-					Variants: []services.VariantDTO{
-						{Name: "Variant A", SKU: "SKU001A", Price: 11.99},
-						{Name: "Variant B", SKU: "SKU001B", Price: 10.99}, // Inherited price
-					},
-				}, nil
-			}
-			return nil, services.ErrNotFound
-		},
+	mockSvc := newMockCatalogService(t)
+	mockSvc.getProductByCodeFunc = func(ctx context.Context, code string) (*services.ProductDetailDTO, error) {
+		if code == "PROD001" {
+			return &services.ProductDetailDTO{
+				Code:  "PROD001",
+				Price: decimal.RequireFromString("10.99"),
+				Category: &services.CategoryDTO{
+					Code: "CLOTHING",
+					Name: "Clothing",
+				},
+				Variants: []services.VariantDTO{
+					{Name: "Variant A", SKU: "SKU001A", Price: decimal.RequireFromString("11.99")},
+					{Name: "Variant B", SKU: "SKU001B", Price: decimal.RequireFromString("10.99")}, // Inherited price
+				},
+			}, nil
+		}
+		return nil, services.ErrNotFound
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001", nil)
@@ -71,7 +163,7 @@ func TestHandleGetByCode_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGetByCode).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusOK {
@@ -88,8 +180,8 @@ func TestHandleGetByCode_Success(t *testing.T) {
 		t.Errorf("expected code PROD001, got %s", response.Code)
 	}
 
-	if response.Price != 10.99 {
-		t.Errorf("expected price 10.99, got %f", response.Price)
+	if !response.Price.Decimal().Equal(decimal.RequireFromString("10.99")) {
+		t.Errorf("expected price 10.99, got %s", response.Price)
 	}
 
 	// Verify category
@@ -111,13 +203,13 @@ func TestHandleGetByCode_Success(t *testing.T) {
 	}
 
 	// First variant should have its own price
-	if response.Variants[0].Price != 11.99 {
-		t.Errorf("expected variant A price 11.99, got %f", response.Variants[0].Price)
+	if !response.Variants[0].Price.Decimal().Equal(decimal.RequireFromString("11.99")) {
+		t.Errorf("expected variant A price 11.99, got %s", response.Variants[0].Price)
 	}
 
 	// Second variant should inherit product price
-	if response.Variants[1].Price != 10.99 {
-		t.Errorf("expected variant B to inherit product price 10.99, got %f", response.Variants[1].Price)
+	if !response.Variants[1].Price.Decimal().Equal(decimal.RequireFromString("10.99")) {
+		t.Errorf("expected variant B to inherit product price 10.99, got %s", response.Variants[1].Price)
 	}
 }
 
@@ -129,7 +221,7 @@ func TestHandleGetByCode_ProductNotFound(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/catalog/INVALID", nil)
@@ -137,12 +229,13 @@ func TestHandleGetByCode_ProductNotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGetByCode).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusNotFound {
 		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
+	apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeNotFound)
 }
 
 func TestHandleGetByCode_MissingCode(t *testing.T) {
@@ -151,14 +244,14 @@ func TestHandleGetByCode_MissingCode(t *testing.T) {
 			return nil, services.ErrInvalidInput
 		},
 	}
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request without code
 	req := httptest.NewRequest(http.MethodGet, "/catalog/", nil)
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGetByCode).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusBadRequest {
@@ -172,14 +265,14 @@ func TestHandleGetByCode_NoCategory(t *testing.T) {
 		getProductByCodeFunc: func(ctx context.Context, code string) (*services.ProductDetailDTO, error) {
 			return &services.ProductDetailDTO{
 				Code:     "PROD001",
-				Price:    10.99,
+				Price:    decimal.RequireFromString("10.99"),
 				Category: nil, // No category
 				Variants: []services.VariantDTO{},
 			}, nil
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001", nil)
@@ -187,7 +280,7 @@ func TestHandleGetByCode_NoCategory(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGetByCode).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusOK {
@@ -205,6 +298,40 @@ func TestHandleGetByCode_NoCategory(t *testing.T) {
 	}
 }
 
+func TestHandleGetByCode_ExactPrices(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*services.ProductDetailDTO, error) {
+			return &services.ProductDetailDTO{
+				Code:  "PROD001",
+				Price: decimal.RequireFromString("10.005"),
+				Variants: []services.VariantDTO{
+					{Name: "Variant A", SKU: "SKU001A", Price: decimal.RequireFromString("0.001")},
+					{Name: "Variant B", SKU: "SKU001B", Price: decimal.RequireFromString("999999.99")},
+				},
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001", nil)
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	body := w.Body.String()
+	for _, expected := range []string{`"price":10.005`, `"price":0.001`, `"price":999999.99`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected response to contain %s, got %s", expected, body)
+		}
+	}
+}
+
 func TestHandleGet_WithPagination(t *testing.T) {
 	// Setup mock service
 	mockSvc := &mockCatalogService{
@@ -221,7 +348,7 @@ func TestHandleGet_WithPagination(t *testing.T) {
 				Products: []services.ProductDTO{
 					{
 						Code:  "PROD006",
-						Price: 5.50,
+						Price: decimal.RequireFromString("5.50"),
 						Category: &services.CategoryDTO{
 							Code: "SHOES",
 							Name: "Shoes",
@@ -233,14 +360,14 @@ func TestHandleGet_WithPagination(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request with pagination parameters
 	req := httptest.NewRequest(http.MethodGet, "/catalog?offset=5&limit=20", nil)
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusOK {
@@ -256,6 +383,9 @@ func TestHandleGet_WithPagination(t *testing.T) {
 	if response.Total != 8 {
 		t.Errorf("expected total 8, got %d", response.Total)
 	}
+	if got := w.Header().Get(api.TotalCountHeader); got != strconv.FormatInt(response.Total, 10) {
+		t.Errorf("expected %s header %d, got %q", api.TotalCountHeader, response.Total, got)
+	}
 
 	// Verify products
 	if len(response.Products) != 1 {
@@ -263,6 +393,64 @@ func TestHandleGet_WithPagination(t *testing.T) {
 	}
 }
 
+func TestHandleGetV2_PaginatedResponse(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		validatePaginationFunc: func(offset, limit int, limitProvided bool) services.PaginationParams {
+			return services.PaginationParams{Offset: 5, Limit: 20}
+		},
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			if filter.Brand != "acme" {
+				t.Errorf("expected brand filter acme, got %q", filter.Brand)
+			}
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{
+					{Code: "PROD006", Price: decimal.RequireFromString("5.50")},
+				},
+				Total: 8,
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/catalog?offset=5&limit=20&brand=acme", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGetV2", handler.HandleGetV2).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response api.PaginatedResponse[Product]
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Total != 8 || response.Offset != 5 || response.Limit != 20 {
+		t.Errorf("expected total 8, offset 5, limit 20, got %d, %d, %d", response.Total, response.Offset, response.Limit)
+	}
+	if got := w.Header().Get(api.TotalCountHeader); got != "8" {
+		t.Errorf("expected %s header 8, got %q", api.TotalCountHeader, got)
+	}
+	if len(response.Items) != 1 || response.Items[0].Code != "PROD006" {
+		t.Errorf("expected PROD006 in items, got %+v", response.Items)
+	}
+}
+
+func TestHandleGetV2_InvalidQuery(t *testing.T) {
+	handler := NewCatalogHandler(&mockCatalogService{}, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/catalog?offset=abc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGetV2", handler.HandleGetV2).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 func TestHandleGet_DefaultPagination(t *testing.T) {
 	// Setup mock service
 	mockSvc := &mockCatalogService{
@@ -286,14 +474,14 @@ func TestHandleGet_DefaultPagination(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request without pagination parameters
 	req := httptest.NewRequest(http.MethodGet, "/catalog", nil)
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusOK {
@@ -301,6 +489,52 @@ func TestHandleGet_DefaultPagination(t *testing.T) {
 	}
 }
 
+func TestHandleGet_NilProductsEncodeAsEmptyArray(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			return &services.ProductListResult{Products: nil, Total: 0}, nil
+		},
+	}
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	for _, target := range []string{"/catalog", "/catalog?fields=code"} {
+		t.Run(target, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+			}
+			if body := strings.TrimSpace(w.Body.String()); body != `{"products":[],"total":0}` {
+				t.Errorf("expected an empty products array, got %s", body)
+			}
+		})
+	}
+}
+
+func TestHandleGetByCode_NilVariantsEncodeAsEmptyArray(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*services.ProductDetailDTO, error) {
+			return &services.ProductDetailDTO{Code: "PROD001", Price: decimal.RequireFromString("10.99"), Variants: nil}, nil
+		},
+	}
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001", nil)
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
+
+	var response map[string]json.RawMessage
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if variants := string(response["variants"]); variants != "[]" {
+		t.Errorf("expected an empty variants array, got %s", variants)
+	}
+}
+
 func TestHandleGet_WithCategory(t *testing.T) {
 	// Setup mock service
 	mockSvc := &mockCatalogService{
@@ -312,7 +546,7 @@ func TestHandleGet_WithCategory(t *testing.T) {
 				Products: []services.ProductDTO{
 					{
 						Code:  "PROD001",
-						Price: 10.99,
+						Price: decimal.RequireFromString("10.99"),
 						Category: &services.CategoryDTO{
 							Code: "CLOTHING",
 							Name: "Clothing",
@@ -324,14 +558,14 @@ func TestHandleGet_WithCategory(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/catalog", nil)
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusOK {
@@ -364,14 +598,14 @@ func TestHandleGet_RepositoryError(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/catalog", nil)
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusInternalServerError {
@@ -387,7 +621,7 @@ func TestHandleGetByCode_InternalError(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001", nil)
@@ -395,7 +629,7 @@ func TestHandleGetByCode_InternalError(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGetByCode).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
 
 	// Assert response - should be 500, not 404
 	if w.Code != http.StatusInternalServerError {
@@ -492,12 +726,12 @@ func TestHandleGet_WithCategoryFilter(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	req := httptest.NewRequest(http.MethodGet, "/catalog?category=CLOTHING", nil)
 	w := httptest.NewRecorder()
 
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
@@ -525,127 +759,1352 @@ func TestHandleGet_WithPriceFilter(t *testing.T) {
 		},
 	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
 	req := httptest.NewRequest(http.MethodGet, "/catalog?priceLessThan=50", nil)
 	w := httptest.NewRecorder()
 
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
 	}
 }
 
-func TestHandleGet_LimitProvidedFlag(t *testing.T) {
+func TestHandleGet_PriceFilterBoundary(t *testing.T) {
 	tests := []struct {
-		name             string
-		url              string
-		expectedProvided bool
+		name     string
+		price    decimal.Decimal
+		included bool
 	}{
-		{"limit not provided", "/catalog", false},
-		{"limit provided as 0", "/catalog?limit=0", true},
-		{"limit provided as 10", "/catalog?limit=10", true},
+		{name: "price equal to the filter is excluded", price: decimal.NewFromFloat(50.00), included: false},
+		{name: "price one cent below the filter is included", price: decimal.NewFromFloat(49.99), included: true},
+		{name: "price one cent above the filter is excluded", price: decimal.NewFromFloat(50.01), included: false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// The service applies the repository's strict price < priceLessThan
+			// to the one stored product.
 			mockSvc := &mockCatalogService{
 				validatePaginationFunc: func(offset, limit int, limitProvided bool) services.PaginationParams {
-					if limitProvided != tt.expectedProvided {
-						t.Errorf("expected limitProvided=%v, got %v", tt.expectedProvided, limitProvided)
-					}
 					return services.PaginationParams{Offset: 0, Limit: 10}
 				},
 				listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
-					return &services.ProductListResult{Products: []services.ProductDTO{}, Total: 0}, nil
+					if !filter.PriceLessThan.Equal(decimal.NewFromFloat(50)) {
+						t.Errorf("expected price filter 50, got %s", filter.PriceLessThan)
+					}
+					if !tt.price.LessThan(*filter.PriceLessThan) {
+						return &services.ProductListResult{Products: []services.ProductDTO{}}, nil
+					}
+					return &services.ProductListResult{
+						Products: []services.ProductDTO{{Code: "PROD001", Price: tt.price}},
+						Total:    1,
+					}, nil
 				},
 			}
 
-			handler := NewCatalogHandler(mockSvc)
-			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/catalog?priceLessThan=50.00", nil)
 			w := httptest.NewRecorder()
 
-			api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 			if w.Code != http.StatusOK {
-				t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+				t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+			}
+
+			var response Response
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if included := len(response.Products) == 1; included != tt.included {
+				t.Errorf("expected product at %s included=%v with priceLessThan=50.00, got %v", tt.price, tt.included, included)
+			}
+			if tt.included && !response.Products[0].Price.Decimal().Equal(tt.price) {
+				t.Errorf("expected price %s, got %s", tt.price, response.Products[0].Price)
 			}
 		})
 	}
 }
 
-func TestHandleGet_InvalidPriceFilter(t *testing.T) {
-	mockSvc := &mockCatalogService{}
+func TestHandleGetBySlug_Success(t *testing.T) {
+	mockSvc := newMockCatalogService(t)
+	mockSvc.getProductBySlugFunc = func(ctx context.Context, slug string) (*services.ProductDetailDTO, error) {
+		if slug != "prod001" {
+			t.Errorf("expected slug prod001, got %s", slug)
+		}
+		return &services.ProductDetailDTO{Code: "PROD001", Slug: slug, Price: decimal.RequireFromString("10.99"), Variants: []services.VariantDTO{}}, nil
+	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
-	req := httptest.NewRequest(http.MethodGet, "/catalog?priceLessThan=abc", nil)
+	req := httptest.NewRequest(http.MethodGet, "/catalog/by-slug/prod001", nil)
+	req.SetPathValue("slug", "prod001")
 	w := httptest.NewRecorder()
 
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetBySlug", handler.HandleGetBySlug).ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response ProductDetail
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Code != "PROD001" || response.Slug != "prod001" {
+		t.Errorf("unexpected product: code %s, slug %s", response.Code, response.Slug)
 	}
 }
 
-func TestHandleGet_NegativePriceFilter(t *testing.T) {
-	mockSvc := &mockCatalogService{}
+func TestHandleGetBySlug_NotFound(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		getProductBySlugFunc: func(ctx context.Context, slug string) (*services.ProductDetailDTO, error) {
+			return nil, services.ErrNotFound
+		},
+	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
-	req := httptest.NewRequest(http.MethodGet, "/catalog?priceLessThan=-10", nil)
+	req := httptest.NewRequest(http.MethodGet, "/catalog/by-slug/missing", nil)
+	req.SetPathValue("slug", "missing")
 	w := httptest.NewRecorder()
 
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetBySlug", handler.HandleGetBySlug).ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
+	apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeNotFound)
 }
 
-func TestHandleGet_InvalidOffset(t *testing.T) {
-	mockSvc := &mockCatalogService{}
+func TestHandleGetByBarcode_Success(t *testing.T) {
+	mockSvc := newMockCatalogService(t)
+	mockSvc.getProductByBarcodeFunc = func(ctx context.Context, barcode string) (*services.ProductDetailDTO, error) {
+		if barcode != "4006381333931" {
+			t.Errorf("expected barcode 4006381333931, got %s", barcode)
+		}
+		return &services.ProductDetailDTO{
+			Code:  "PROD001",
+			Price: decimal.RequireFromString("10.99"),
+			Variants: []services.VariantDTO{
+				{Name: "Variant A", SKU: "SKU001A", Barcode: barcode, Price: decimal.RequireFromString("11.99")},
+				{Name: "Variant B", SKU: "SKU001B", Price: decimal.RequireFromString("10.99")},
+			},
+		}, nil
+	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
-	req := httptest.NewRequest(http.MethodGet, "/catalog?offset=abc", nil)
+	req := httptest.NewRequest(http.MethodGet, "/catalog/by-barcode/4006381333931", nil)
+	req.SetPathValue("barcode", "4006381333931")
 	w := httptest.NewRecorder()
 
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetByBarcode", handler.HandleGetByBarcode).ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response ProductDetail
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Code != "PROD001" || len(response.Variants) != 2 {
+		t.Fatalf("unexpected product: %+v", response)
+	}
+	if response.Variants[0].Barcode != "4006381333931" {
+		t.Errorf("expected barcode 4006381333931, got %q", response.Variants[0].Barcode)
+	}
+	if response.Variants[1].Barcode != "" {
+		t.Errorf("expected no barcode for variant B, got %q", response.Variants[1].Barcode)
 	}
 }
 
-func TestHandleGet_InvalidLimit(t *testing.T) {
-	mockSvc := &mockCatalogService{}
+func TestHandleGetByBarcode_NotFound(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		getProductByBarcodeFunc: func(ctx context.Context, barcode string) (*services.ProductDetailDTO, error) {
+			return nil, services.ErrNotFound
+		},
+	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
-	req := httptest.NewRequest(http.MethodGet, "/catalog?limit=abc", nil)
+	req := httptest.NewRequest(http.MethodGet, "/catalog/by-barcode/0000000000000", nil)
+	req.SetPathValue("barcode", "0000000000000")
 	w := httptest.NewRecorder()
 
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGetByBarcode", handler.HandleGetByBarcode).ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
+	apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeNotFound)
 }
 
-func TestHandleGet_NegativeOffset(t *testing.T) {
-	mockSvc := &mockCatalogService{}
+func TestHandleGet_WithBrandFilter(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			if filter.Brand != "Acme" {
+				t.Errorf("expected brand Acme, got %q", filter.Brand)
+			}
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{
+					{Code: "PROD001", Brand: "Acme", Price: decimal.RequireFromString("10.99")},
+				},
+				Total: 1,
+			}, nil
+		},
+	}
 
-	handler := NewCatalogHandler(mockSvc)
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
 
-	req := httptest.NewRequest(http.MethodGet, "/catalog?offset=-5", nil)
+	req := httptest.NewRequest(http.MethodGet, "/catalog?brand=Acme", nil)
 	w := httptest.NewRecorder()
 
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response Response
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Products[0].Brand != "Acme" {
+		t.Errorf("expected brand Acme, got %q", response.Products[0].Brand)
+	}
+}
+
+func TestHandleGet_WithUpdatedAfterFilter(t *testing.T) {
+	expected := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			if filter.UpdatedAfter == nil {
+				t.Fatal("expected updatedAfter filter to be set")
+			}
+			if !filter.UpdatedAfter.Equal(expected) {
+				t.Errorf("expected updatedAfter %s, got %s", expected, filter.UpdatedAfter)
+			}
+			return &services.ProductListResult{Products: []services.ProductDTO{}}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?updatedAfter=2024-05-01T14:00:00%2B02:00", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestHandleGet_InvalidUpdatedAfterFilter(t *testing.T) {
+	for _, value := range []string{"yesterday", "2024-05-01", "1714564800"} {
+		t.Run(value, func(t *testing.T) {
+			handler := NewCatalogHandler(&mockCatalogService{}, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/catalog?updatedAfter="+value, nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandleGet_WithMinOrderQtyLessThanFilter(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			if filter.MinOrderQtyLessThan == nil || *filter.MinOrderQtyLessThan != 3 {
+				t.Errorf("expected minOrderQtyLessThan 3, got %v", filter.MinOrderQtyLessThan)
+			}
+			return &services.ProductListResult{Products: []services.ProductDTO{}}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?minOrderQtyLessThan=3", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestHandleGet_WithLabelFilter(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			if filter.Label != "featured" {
+				t.Errorf("expected label featured, got %q", filter.Label)
+			}
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{{Code: "PROD001", Label: "featured"}},
+				Total:    1,
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?label=featured", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response Response
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Products) != 1 || response.Products[0].Label != "featured" {
+		t.Errorf("expected a featured product, got %+v", response.Products)
+	}
+}
+
+func TestHandleGet_InvalidLabelFilter(t *testing.T) {
+	handler := NewCatalogHandler(&mockCatalogService{}, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?label=clearance", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	assertErrorMessage(t, w, services.ErrInvalidLabel.Error())
+}
+
+func TestHandleGet_WithOnSaleFilter(t *testing.T) {
+	salePrice := decimal.RequireFromString("7.50")
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			if !filter.OnSale {
+				t.Error("expected onSale filter to be set")
+			}
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{
+					{Code: "PROD001", Price: decimal.NewFromInt(10), SalePrice: &salePrice},
+				},
+				Total: 1,
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?onSale=true", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"sale_price":7.5`) {
+		t.Errorf("expected sale_price 7.5 in body, got %s", w.Body.String())
+	}
+}
+
+func TestHandleGet_OmitsSalePriceWhenNotOnSale(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{{Code: "PROD001", Price: decimal.NewFromInt(10)}},
+				Total:    1,
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if strings.Contains(w.Body.String(), "sale_price") {
+		t.Errorf("expected no sale_price in body, got %s", w.Body.String())
+	}
+}
+
+func TestHandleGet_InvalidOnSaleFilter(t *testing.T) {
+	handler := NewCatalogHandler(&mockCatalogService{}, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?onSale=maybe", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	assertErrorMessage(t, w, services.ErrInvalidOnSale.Error())
+}
+
+func TestHandleNewArrivals(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"default window", "", services.DefaultNewArrivalsDays},
+		{"explicit window", "?days=30", 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockCatalogService{
+				listNewArrivalsFunc: func(ctx context.Context, days int, params services.PaginationParams) (*services.ProductListResult, error) {
+					if days != tt.expected {
+						t.Errorf("expected %d days, got %d", tt.expected, days)
+					}
+					return &services.ProductListResult{
+						Products: []services.ProductDTO{{Code: "PROD004", Price: decimal.NewFromInt(5)}},
+						Total:    1,
+					}, nil
+				},
+			}
+
+			handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/catalog/new-arrivals"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleNewArrivals", handler.HandleNewArrivals).ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+			}
+
+			var response Response
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Total != 1 || len(response.Products) != 1 || response.Products[0].Code != "PROD004" {
+				t.Errorf("expected PROD004, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestHandleFeatured(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		validatePaginationFunc: func(offset, limit int, limitProvided bool) services.PaginationParams {
+			return services.PaginationParams{Offset: offset, Limit: limit}
+		},
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			if filter != (services.FilterParams{Label: "featured"}) {
+				t.Errorf("expected only the featured label filter, got %+v", filter)
+			}
+			if params.Offset != 1 || params.Limit != 5 {
+				t.Errorf("expected offset 1 and limit 5, got %+v", params)
+			}
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{{Code: "PROD001", Label: "featured"}},
+				Total:    2,
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	// The label is implied, so a label parameter is ignored.
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/featured?offset=1&limit=5&label=sale", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleFeatured", handler.HandleFeatured).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response Response
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Total != 2 || len(response.Products) != 1 || response.Products[0].Code != "PROD001" {
+		t.Errorf("expected PROD001 of 2 products, got %+v", response)
+	}
+}
+
+func TestHandleFeatured_InvalidLimit(t *testing.T) {
+	handler := NewCatalogHandler(&mockCatalogService{}, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/featured?limit=abc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleFeatured", handler.HandleFeatured).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	assertErrorMessage(t, w, services.ErrInvalidLimit.Error())
+}
+
+func TestHandleNewArrivals_InvalidDays(t *testing.T) {
+	for _, value := range []string{"week", "0", "-3"} {
+		t.Run(value, func(t *testing.T) {
+			handler := NewCatalogHandler(&mockCatalogService{}, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/catalog/new-arrivals?days="+value, nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleNewArrivals", handler.HandleNewArrivals).ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			assertErrorMessage(t, w, services.ErrInvalidDays.Error())
+		})
+	}
+}
+
+func TestHandleGet_InvalidMinOrderQtyLessThanFilter(t *testing.T) {
+	for _, value := range []string{"many", "1.5"} {
+		t.Run(value, func(t *testing.T) {
+			handler := NewCatalogHandler(&mockCatalogService{}, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/catalog?minOrderQtyLessThan="+value, nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandleGetByCode_Timestamps(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := time.Date(2024, 6, 7, 8, 9, 10, 0, time.UTC)
+
+	mockSvc := &mockCatalogService{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*services.ProductDetailDTO, error) {
+			return &services.ProductDetailDTO{
+				Code:      code,
+				Price:     decimal.RequireFromString("10.99"),
+				Variants:  []services.VariantDTO{},
+				CreatedAt: createdAt,
+				UpdatedAt: updatedAt,
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001", nil)
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGetByCode", handler.HandleGetByCode).ServeHTTP(w, req)
+
+	body := w.Body.String()
+	for _, expected := range []string{`"created_at":"2024-01-02T03:04:05Z"`, `"updated_at":"2024-06-07T08:09:10Z"`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected response to contain %s, got %s", expected, body)
+		}
+	}
+}
+
+func TestHandleGet_LimitProvidedFlag(t *testing.T) {
+	tests := []struct {
+		name             string
+		url              string
+		expectedProvided bool
+	}{
+		{"limit not provided", "/catalog", false},
+		{"limit provided as 0", "/catalog?limit=0", true},
+		{"limit provided as 10", "/catalog?limit=10", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockCatalogService{
+				validatePaginationFunc: func(offset, limit int, limitProvided bool) services.PaginationParams {
+					if limitProvided != tt.expectedProvided {
+						t.Errorf("expected limitProvided=%v, got %v", tt.expectedProvided, limitProvided)
+					}
+					return services.PaginationParams{Offset: 0, Limit: 10}
+				},
+				listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+					return &services.ProductListResult{Products: []services.ProductDTO{}, Total: 0}, nil
+				},
+			}
+
+			handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandleGet_InvalidPriceFilter(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?priceLessThan=abc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleGet_NegativePriceFilter(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?priceLessThan=-10", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleGet_InvalidOffset(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?offset=abc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleGet_OffsetLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		offset         string
+		expectedStatus int
+	}{
+		{name: "at the limit", offset: "10000000", expectedStatus: http.StatusOK},
+		{name: "above the limit", offset: "10000001", expectedStatus: http.StatusBadRequest},
+		{name: "max int64", offset: "9223372036854775807", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listCalled := false
+			mockSvc := &mockCatalogService{
+				validatePaginationFunc: func(offset, limit int, limitProvided bool) services.PaginationParams {
+					return services.PaginationParams{Offset: offset, Limit: 10}
+				},
+				listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+					listCalled = true
+					return &services.ProductListResult{Products: []services.ProductDTO{}}, nil
+				},
+			}
+
+			handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/catalog?offset="+tt.offset, nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusBadRequest {
+				assertErrorMessage(t, w, services.ErrOffsetTooLarge.Error())
+				if listCalled {
+					t.Error("expected the listing not to be queried")
+				}
+			}
+		})
+	}
+}
+
+func TestHandleGet_InvalidLimit(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?limit=abc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleGet_LimitOverflowsInt(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	// The value does not fit in an int, so strconv.Atoi fails with a range error.
+	req := httptest.NewRequest(http.MethodGet, "/catalog?limit=99999999999999999999", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	assertErrorMessage(t, w, services.ErrInvalidLimit.Error())
+}
+
+func TestHandleGet_OffsetAgainstTotal(t *testing.T) {
+	tests := []struct {
+		name           string
+		offset         int
+		total          int64
+		expectedStatus int
+	}{
+		{name: "empty result with zero offset", offset: 0, total: 0, expectedStatus: http.StatusOK},
+		{name: "offset equal to total", offset: 5, total: 5, expectedStatus: http.StatusBadRequest},
+		{name: "offset within total", offset: 3, total: 5, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockCatalogService{
+				validatePaginationFunc: func(offset, limit int, limitProvided bool) services.PaginationParams {
+					return services.PaginationParams{Offset: offset, Limit: 10}
+				},
+				listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+					return &services.ProductListResult{Products: []services.ProductDTO{}, Total: tt.total}, nil
+				},
+			}
+
+			handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/catalog?offset=%d", tt.offset), nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusBadRequest {
+				assertErrorMessage(t, w, services.ErrOffsetExceedsTotal.Error())
+			}
+		})
+	}
+}
+
+func TestHandleGet_NegativeOffset(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?offset=-5", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleGet_WithInStockFilter(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		validatePaginationFunc: func(offset, limit int, limitProvided bool) services.PaginationParams {
+			return services.PaginationParams{Offset: 0, Limit: 10}
+		},
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			// Verify in-stock filter is passed correctly
+			if !filter.InStock {
+				t.Error("expected in-stock filter to be set")
+			}
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{},
+				Total:    0,
+			}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?inStock=true", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestHandleGet_InvalidInStockFilter(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog?inStock=maybe", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleAdjustStock_Success(t *testing.T) {
+	mockSvc := newMockCatalogService(t)
+	mockSvc.adjustStockFunc = func(ctx context.Context, code, sku string, delta int) (*services.VariantDTO, error) {
+		if code != "PROD001" || sku != "SKU001A" || delta != 5 {
+			t.Errorf("unexpected arguments: %s %s %d", code, sku, delta)
+		}
+		return &services.VariantDTO{Name: "Variant A", SKU: sku, Price: decimal.RequireFromString("11.99"), StockQuantity: 10}, nil
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPatch, "/catalog/PROD001/variants/SKU001A/stock", bytes.NewReader([]byte(`{"delta":5}`)))
+	req.SetPathValue("code", "PROD001")
+	req.SetPathValue("sku", "SKU001A")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleAdjustStock", handler.HandleAdjustStock).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response Variant
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.StockQuantity != 10 {
+		t.Errorf("expected stock quantity 10, got %d", response.StockQuantity)
+	}
+}
+
+func TestHandleAdjustStock_MissingDelta(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPatch, "/catalog/PROD001/variants/SKU001A/stock", bytes.NewReader([]byte(`{}`)))
+	req.SetPathValue("code", "PROD001")
+	req.SetPathValue("sku", "SKU001A")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleAdjustStock", handler.HandleAdjustStock).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleAdjustStock_InvalidJSON(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPatch, "/catalog/PROD001/variants/SKU001A/stock", bytes.NewReader([]byte("invalid json")))
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleAdjustStock", handler.HandleAdjustStock).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	assertErrorMessage(t, w, "request body must be valid JSON")
+}
+
+func TestHandleAdjustStock_VariantNotFound(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		adjustStockFunc: func(ctx context.Context, code, sku string, delta int) (*services.VariantDTO, error) {
+			return nil, services.ErrNotFound
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPatch, "/catalog/PROD001/variants/MISSING/stock", bytes.NewReader([]byte(`{"delta":1}`)))
+	req.SetPathValue("code", "PROD001")
+	req.SetPathValue("sku", "MISSING")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleAdjustStock", handler.HandleAdjustStock).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeNotFound)
+}
+
+func TestHandleUpdate_Success(t *testing.T) {
+	mockSvc := newMockCatalogService(t)
+	mockSvc.updateProductFunc = func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+		if code != "PROD001" {
+			t.Errorf("expected code PROD001, got %s", code)
+		}
+		if input.Price == nil || !input.Price.Equal(decimal.RequireFromString("12.99")) {
+			t.Errorf("expected price 12.99, got %v", input.Price)
+		}
+		return &services.ProductDetailDTO{Code: code, Price: decimal.RequireFromString("12.99"), Variants: []services.VariantDTO{}}, nil
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/PROD001", bytes.NewReader([]byte(`{"price":12.99}`)))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response ProductDetail
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if !response.Price.Decimal().Equal(decimal.RequireFromString("12.99")) {
+		t.Errorf("expected price 12.99, got %s", response.Price)
+	}
+}
+
+func TestHandleUpdate_Brand(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		updateProductFunc: func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+			if input.Price != nil {
+				t.Errorf("expected no price, got %s", input.Price)
+			}
+			if input.Brand == nil || *input.Brand != "Acme" {
+				t.Fatalf("expected brand Acme, got %v", input.Brand)
+			}
+			return &services.ProductDetailDTO{Code: code, Brand: *input.Brand, Price: decimal.RequireFromString("10.99"), Variants: []services.VariantDTO{}}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/PROD001", bytes.NewReader([]byte(`{"brand":"Acme"}`)))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response ProductDetail
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Brand != "Acme" {
+		t.Errorf("expected brand Acme, got %q", response.Brand)
+	}
+}
+
+func TestHandleUpdate_ImageURL(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		updateProductFunc: func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+			if input.ImageURL == nil || *input.ImageURL != "https://cdn.example.com/prod001.jpg" {
+				t.Fatalf("expected image URL https://cdn.example.com/prod001.jpg, got %v", input.ImageURL)
+			}
+			return &services.ProductDetailDTO{Code: code, ImageURL: *input.ImageURL, Price: decimal.RequireFromString("10.99"), Variants: []services.VariantDTO{}}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/PROD001", bytes.NewReader([]byte(`{"image_url":"https://cdn.example.com/prod001.jpg"}`)))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response ProductDetail
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.ImageURL != "https://cdn.example.com/prod001.jpg" {
+		t.Errorf("expected image_url https://cdn.example.com/prod001.jpg, got %q", response.ImageURL)
+	}
+}
+
+func TestHandleUpdate_InvalidImageURL(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		updateProductFunc: func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+			return nil, services.ErrInvalidProductImage
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/PROD001", bytes.NewReader([]byte(`{"image_url":"/prod001.jpg"}`)))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	assertErrorMessage(t, w, services.ErrInvalidProductImage.Error())
+}
+
+func TestHandleUpdate_SalePrice(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		updateProductFunc: func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+			if input.SalePrice == nil || !input.SalePrice.Equal(decimal.RequireFromString("7.50")) {
+				t.Fatalf("expected sale price 7.50, got %v", input.SalePrice)
+			}
+			return &services.ProductDetailDTO{Code: code, Price: decimal.NewFromInt(10), SalePrice: input.SalePrice, Variants: []services.VariantDTO{}}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/PROD001", bytes.NewReader([]byte(`{"sale_price":7.50}`)))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response ProductDetail
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.SalePrice == nil || !response.SalePrice.Decimal().Equal(decimal.RequireFromString("7.50")) {
+		t.Errorf("expected sale price 7.50, got %v", response.SalePrice)
+	}
+}
+
+func TestHandleUpdate_OrderQuantity(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		updateProductFunc: func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+			if input.MinOrderQty == nil || *input.MinOrderQty != 2 {
+				t.Fatalf("expected min order quantity 2, got %v", input.MinOrderQty)
+			}
+			if input.MaxOrderQty != nil {
+				t.Errorf("expected no max order quantity, got %v", input.MaxOrderQty)
+			}
+			return &services.ProductDetailDTO{Code: code, Price: decimal.RequireFromString("10.99"), MinOrderQty: 2, Variants: []services.VariantDTO{}}, nil
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/PROD001", bytes.NewReader([]byte(`{"min_order_quantity":2}`)))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response["min_order_quantity"] != float64(2) {
+		t.Errorf("expected min_order_quantity 2, got %v", response["min_order_quantity"])
+	}
+	if max, ok := response["max_order_quantity"]; !ok || max != nil {
+		t.Errorf("expected max_order_quantity null, got %v", max)
+	}
+}
+
+func TestHandleUpdate_InvalidJSON(t *testing.T) {
+	mockSvc := &mockCatalogService{}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/PROD001", bytes.NewReader([]byte("invalid json")))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	assertErrorMessage(t, w, "request body must be valid JSON")
+}
+
+// assertErrorMessage checks that a JSON error response reports invalid input with the given message.
+func assertErrorMessage(t *testing.T, w *httptest.ResponseRecorder, expected string) {
+	t.Helper()
+
+	response := apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeInvalidInput)
+	if response.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, response.Message)
+	}
+}
+
+func TestHandleUpdate_ProductNotFound(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		updateProductFunc: func(ctx context.Context, code string, input services.UpdateProductInput) (*services.ProductDetailDTO, error) {
+			return nil, services.ErrNotFound
+		},
+	}
+
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPut, "/catalog/MISSING", bytes.NewReader([]byte(`{"price":1}`)))
+	req.SetPathValue("code", "MISSING")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeNotFound)
+}
+
+func BenchmarkHandleGet(b *testing.B) {
+	products := make([]services.ProductDTO, 100)
+	for i := range products {
+		products[i] = services.ProductDTO{
+			Code:     fmt.Sprintf("PROD%03d", i+1),
+			Price:    decimal.RequireFromString("10.99"),
+			Category: &services.CategoryDTO{Code: "CLOTHING", Name: "Clothing"},
+		}
+	}
+
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			return &services.ProductListResult{Products: products, Total: int64(len(products))}, nil
+		},
+	}
+
+	handler := api.ErrorHandler("catalog.HandleGet", NewCatalogHandler(mockSvc, CatalogHandlerConfig{}).HandleGet)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/catalog?limit=100", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			b.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	}
+}
+
+func TestHandleGet_Fields(t *testing.T) {
+	products := []services.ProductDTO{
+		{
+			Code:     "PROD001",
+			Slug:     "prod001",
+			Brand:    "Acme",
+			Price:    decimal.RequireFromString("10.99"),
+			Category: &services.CategoryDTO{Code: "CLOTHING", Name: "Clothing"},
+		},
+		{
+			Code:  "PROD002",
+			Slug:  "prod002",
+			Price: decimal.RequireFromString("12.49"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fields   string
+		expected []map[string]any
+	}{
+		{
+			name:   "code and price",
+			fields: "code,price",
+			expected: []map[string]any{
+				{"code": "PROD001", "price": 10.99},
+				{"code": "PROD002", "price": 12.49},
+			},
+		},
+		{
+			name:   "category is kept whole",
+			fields: "code,category",
+			expected: []map[string]any{
+				{"code": "PROD001", "category": map[string]any{"code": "CLOTHING", "name": "Clothing"}},
+				{"code": "PROD002"},
+			},
+		},
+		{
+			name:   "empty optional fields are omitted",
+			fields: "slug,brand,image_url",
+			expected: []map[string]any{
+				{"slug": "prod001", "brand": "Acme"},
+				{"slug": "prod002"},
+			},
+		},
+		{
+			name:   "spaces and repeated names",
+			fields: " code , code",
+			expected: []map[string]any{
+				{"code": "PROD001"},
+				{"code": "PROD002"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockCatalogService{
+				listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+					return &services.ProductListResult{Products: products, Total: 2}, nil
+				},
+			}
+			handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/catalog?fields="+url.QueryEscape(tt.fields), nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+			}
+
+			var response struct {
+				Products []map[string]any `json:"products"`
+				Total    int64            `json:"total"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if response.Total != 2 {
+				t.Errorf("expected total 2, got %d", response.Total)
+			}
+			if got := w.Header().Get(api.TotalCountHeader); got != "2" {
+				t.Errorf("expected %s header 2, got %q", api.TotalCountHeader, got)
+			}
+			if !reflect.DeepEqual(response.Products, tt.expected) {
+				t.Errorf("expected products %v, got %v", tt.expected, response.Products)
+			}
+		})
+	}
+}
+
+func TestHandleGet_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+	}{
+		{name: "unknown field", fields: "code,color"},
+		{name: "trailing comma", fields: "code,"},
+		{name: "nested field", fields: "category.name"},
+		{name: "wrong case", fields: "Code"},
+		{name: "detail-only field", fields: "variants"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockCatalogService{
+				listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+					t.Error("expected ListProducts not to be called")
+					return &services.ProductListResult{}, nil
+				},
+			}
+			handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, "/catalog?fields="+url.QueryEscape(tt.fields), nil)
+			w := httptest.NewRecorder()
+
+			api.ErrorHandler("catalog.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			assertErrorMessage(t, w, services.ErrInvalidFields.Error())
+		})
+	}
+}
+
+func TestHandleGetV2_Fields(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductsFunc: func(ctx context.Context, params services.PaginationParams, filter services.FilterParams) (*services.ProductListResult, error) {
+			return &services.ProductListResult{
+				Products: []services.ProductDTO{{Code: "PROD001", Slug: "prod001", Price: decimal.RequireFromString("10.99")}},
+				Total:    1,
+			}, nil
+		},
+	}
+	handler := NewCatalogHandler(mockSvc, CatalogHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/catalog?fields=code", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.HandleGetV2", handler.HandleGetV2).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response api.PaginatedResponse[map[string]any]
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	expected := []map[string]any{{"code": "PROD001"}}
+	if !reflect.DeepEqual(response.Items, expected) {
+		t.Errorf("expected items %v, got %v", expected, response.Items)
 	}
 }
@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// ProductImage represents an image of a product's gallery in API responses.
+type ProductImage struct {
+	ID       uint   `json:"id"`
+	URL      string `json:"url"`
+	Position int    `json:"position"`
+}
+
+// AddImageRequest represents the request body for adding an image to a product's gallery.
+type AddImageRequest struct {
+	URL      string `json:"url"`
+	Position int    `json:"position"`
+}
+
+// ProductImageService defines the interface for product image gallery business logic.
+type ProductImageService interface {
+	ListImages(ctx context.Context, code string) ([]services.ProductImageDTO, error)
+	AddImage(ctx context.Context, code string, input services.AddProductImageInput) (*services.ProductImageDTO, error)
+	DeleteImage(ctx context.Context, code string, id uint) error
+}
+
+// ProductImageHandler handles HTTP requests for product image galleries.
+type ProductImageHandler struct {
+	service ProductImageService
+}
+
+// NewProductImageHandler creates a new ProductImageHandler instance.
+func NewProductImageHandler(s ProductImageService) *ProductImageHandler {
+	return &ProductImageHandler{service: s}
+}
+
+// HandleGet handles GET /catalog/{code}/images requests.
+func (h *ProductImageHandler) HandleGet(w http.ResponseWriter, r *http.Request) error {
+	images, err := h.service.ListImages(r.Context(), r.PathValue("code"))
+	if err != nil {
+		return err
+	}
+
+	response := make([]ProductImage, len(images))
+	for i, img := range images {
+		response[i] = mapImageToResponse(img)
+	}
+
+	api.OKResponse(w, r, response)
+	return nil
+}
+
+// HandlePost handles POST /catalog/{code}/images requests.
+func (h *ProductImageHandler) HandlePost(w http.ResponseWriter, r *http.Request) error {
+	var req AddImageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return invalidBodyError(err)
+	}
+
+	image, err := h.service.AddImage(r.Context(), r.PathValue("code"), services.AddProductImageInput{
+		URL:      req.URL,
+		Position: req.Position,
+	})
+	if err != nil {
+		return err
+	}
+
+	api.CreatedResponse(w, r, mapImageToResponse(*image))
+	return nil
+}
+
+// HandleDelete handles DELETE /catalog/{code}/images/{id} requests.
+func (h *ProductImageHandler) HandleDelete(w http.ResponseWriter, r *http.Request) error {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 0)
+	if err != nil || id == 0 {
+		return services.ErrInvalidImageID
+	}
+
+	if err := h.service.DeleteImage(r.Context(), r.PathValue("code"), uint(id)); err != nil {
+		return err
+	}
+
+	api.NoContentResponse(w, r)
+	return nil
+}
+
+func mapImageToResponse(img services.ProductImageDTO) ProductImage {
+	return ProductImage{
+		ID:       img.ID,
+		URL:      img.URL,
+		Position: img.Position,
+	}
+}
@@ -0,0 +1,178 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockProductImageService is a mock implementation of ProductImageService for testing.
+type mockProductImageService struct {
+	listImagesFunc  func(ctx context.Context, code string) ([]services.ProductImageDTO, error)
+	addImageFunc    func(ctx context.Context, code string, input services.AddProductImageInput) (*services.ProductImageDTO, error)
+	deleteImageFunc func(ctx context.Context, code string, id uint) error
+}
+
+func (m *mockProductImageService) ListImages(ctx context.Context, code string) ([]services.ProductImageDTO, error) {
+	if m.listImagesFunc != nil {
+		return m.listImagesFunc(ctx, code)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductImageService) AddImage(ctx context.Context, code string, input services.AddProductImageInput) (*services.ProductImageDTO, error) {
+	if m.addImageFunc != nil {
+		return m.addImageFunc(ctx, code, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductImageService) DeleteImage(ctx context.Context, code string, id uint) error {
+	if m.deleteImageFunc != nil {
+		return m.deleteImageFunc(ctx, code, id)
+	}
+	return errors.New("not implemented")
+}
+
+func TestProductImageHandleGet_Success(t *testing.T) {
+	mockSvc := &mockProductImageService{
+		listImagesFunc: func(ctx context.Context, code string) ([]services.ProductImageDTO, error) {
+			return []services.ProductImageDTO{
+				{ID: 1, URL: "https://cdn.example.com/front.jpg", Position: 0},
+				{ID: 2, URL: "https://cdn.example.com/back.jpg", Position: 1},
+			}, nil
+		},
+	}
+
+	handler := NewProductImageHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001/images", nil)
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.ProductImageHandler.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	expected := `[{"id":1,"url":"https://cdn.example.com/front.jpg","position":0},{"id":2,"url":"https://cdn.example.com/back.jpg","position":1}]`
+	assert.JSONEq(t, expected, w.Body.String())
+	assert.Equal(t, expected, w.Body.String(), "expected compact JSON without a trailing newline")
+}
+
+func TestProductImageHandleGet_NotFound(t *testing.T) {
+	mockSvc := &mockProductImageService{
+		listImagesFunc: func(ctx context.Context, code string) ([]services.ProductImageDTO, error) {
+			return nil, services.ErrNotFound
+		},
+	}
+
+	handler := NewProductImageHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/MISSING/images", nil)
+	req.SetPathValue("code", "MISSING")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.ProductImageHandler.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestProductImageHandlePost_Success(t *testing.T) {
+	mockSvc := &mockProductImageService{
+		addImageFunc: func(ctx context.Context, code string, input services.AddProductImageInput) (*services.ProductImageDTO, error) {
+			assert.Equal(t, "PROD001", code)
+			assert.Equal(t, "https://cdn.example.com/side.jpg", input.URL)
+			assert.Equal(t, 2, input.Position)
+			return &services.ProductImageDTO{ID: 9, URL: input.URL, Position: input.Position}, nil
+		},
+	}
+
+	handler := NewProductImageHandler(mockSvc)
+
+	body := strings.NewReader(`{"url":"https://cdn.example.com/side.jpg","position":2}`)
+	req := httptest.NewRequest(http.MethodPost, "/catalog/PROD001/images", body)
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.ProductImageHandler.HandlePost", handler.HandlePost).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"id":9,"url":"https://cdn.example.com/side.jpg","position":2}`, w.Body.String())
+	assert.Equal(t, `{"id":9,"url":"https://cdn.example.com/side.jpg","position":2}`, w.Body.String(), "expected compact JSON without a trailing newline")
+}
+
+func TestProductImageHandlePost_InvalidJSON(t *testing.T) {
+	handler := NewProductImageHandler(&mockProductImageService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/catalog/PROD001/images", strings.NewReader(`{`))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.ProductImageHandler.HandlePost", handler.HandlePost).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"code":"invalid_input","message":"request body must be valid JSON"}`, w.Body.String())
+	assert.Equal(t, `{"code":"invalid_input","message":"request body must be valid JSON"}`, w.Body.String(), "expected compact JSON without a trailing newline")
+}
+
+func TestProductImageHandlePost_InvalidURL(t *testing.T) {
+	mockSvc := &mockProductImageService{
+		addImageFunc: func(ctx context.Context, code string, input services.AddProductImageInput) (*services.ProductImageDTO, error) {
+			return nil, services.ErrInvalidImageURL
+		},
+	}
+
+	handler := NewProductImageHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPost, "/catalog/PROD001/images", strings.NewReader(`{"url":"nope"}`))
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.ProductImageHandler.HandlePost", handler.HandlePost).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), services.ErrInvalidImageURL.Error())
+}
+
+func TestProductImageHandleDelete_Success(t *testing.T) {
+	mockSvc := &mockProductImageService{
+		deleteImageFunc: func(ctx context.Context, code string, id uint) error {
+			assert.Equal(t, "PROD001", code)
+			assert.Equal(t, uint(5), id)
+			return nil
+		},
+	}
+
+	handler := NewProductImageHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/catalog/PROD001/images/5", nil)
+	req.SetPathValue("code", "PROD001")
+	req.SetPathValue("id", "5")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.ProductImageHandler.HandleDelete", handler.HandleDelete).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestProductImageHandleDelete_InvalidID(t *testing.T) {
+	handler := NewProductImageHandler(&mockProductImageService{})
+
+	for _, id := range []string{"abc", "0", "-1"} {
+		req := httptest.NewRequest(http.MethodDelete, "/catalog/PROD001/images/"+id, nil)
+		req.SetPathValue("code", "PROD001")
+		req.SetPathValue("id", id)
+		w := httptest.NewRecorder()
+
+		api.ErrorHandler("catalog.ProductImageHandler.HandleDelete", handler.HandleDelete).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "id %q", id)
+	}
+}
@@ -0,0 +1,53 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// PriceChange represents a product price change in API responses.
+// Prices are encoded as fixed two-decimal strings to avoid float rounding.
+type PriceChange struct {
+	OldPrice  string    `json:"old_price"`
+	NewPrice  string    `json:"new_price"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// PriceHistoryService defines the interface for price history business logic.
+type PriceHistoryService interface {
+	GetPriceHistory(ctx context.Context, code string) ([]services.PriceChangeDTO, error)
+}
+
+// PriceHistoryHandler handles HTTP requests for product price history.
+type PriceHistoryHandler struct {
+	service PriceHistoryService
+}
+
+// NewPriceHistoryHandler creates a new PriceHistoryHandler instance.
+func NewPriceHistoryHandler(s PriceHistoryService) *PriceHistoryHandler {
+	return &PriceHistoryHandler{service: s}
+}
+
+// HandleGet handles GET /catalog/{code}/price-history requests.
+func (h *PriceHistoryHandler) HandleGet(w http.ResponseWriter, r *http.Request) error {
+	history, err := h.service.GetPriceHistory(r.Context(), r.PathValue("code"))
+	if err != nil {
+		return err
+	}
+
+	response := make([]PriceChange, len(history))
+	for i, c := range history {
+		response[i] = PriceChange{
+			OldPrice:  c.OldPrice.StringFixed(2),
+			NewPrice:  c.NewPrice.StringFixed(2),
+			ChangedAt: c.ChangedAt.UTC(),
+		}
+	}
+
+	api.OKResponse(w, r, response)
+	return nil
+}
@@ -0,0 +1,92 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPriceHistoryService is a mock implementation of PriceHistoryService for testing.
+type mockPriceHistoryService struct {
+	getPriceHistoryFunc func(ctx context.Context, code string) ([]services.PriceChangeDTO, error)
+}
+
+func (m *mockPriceHistoryService) GetPriceHistory(ctx context.Context, code string) ([]services.PriceChangeDTO, error) {
+	if m.getPriceHistoryFunc != nil {
+		return m.getPriceHistoryFunc(ctx, code)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestPriceHistoryHandleGet_Success(t *testing.T) {
+	mockSvc := &mockPriceHistoryService{
+		getPriceHistoryFunc: func(ctx context.Context, code string) ([]services.PriceChangeDTO, error) {
+			return []services.PriceChangeDTO{
+				{
+					OldPrice:  decimal.RequireFromString("10.99"),
+					NewPrice:  decimal.RequireFromString("12.9"),
+					ChangedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				},
+			}, nil
+		},
+	}
+
+	handler := NewPriceHistoryHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001/price-history", nil)
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.PriceHistoryHandler.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	expected := `[{"old_price":"10.99","new_price":"12.90","changed_at":"2025-01-02T03:04:05Z"}]`
+	assert.JSONEq(t, expected, w.Body.String())
+	assert.Equal(t, expected, w.Body.String(), "expected compact JSON without a trailing newline")
+}
+
+func TestPriceHistoryHandleGet_Empty(t *testing.T) {
+	mockSvc := &mockPriceHistoryService{
+		getPriceHistoryFunc: func(ctx context.Context, code string) ([]services.PriceChangeDTO, error) {
+			return nil, nil
+		},
+	}
+
+	handler := NewPriceHistoryHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/PROD001/price-history", nil)
+	req.SetPathValue("code", "PROD001")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.PriceHistoryHandler.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.Equal(t, `[]`, w.Body.String(), "expected compact JSON without a trailing newline")
+}
+
+func TestPriceHistoryHandleGet_NotFound(t *testing.T) {
+	mockSvc := &mockPriceHistoryService{
+		getPriceHistoryFunc: func(ctx context.Context, code string) ([]services.PriceChangeDTO, error) {
+			return nil, services.ErrNotFound
+		},
+	}
+
+	handler := NewPriceHistoryHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/catalog/MISSING/price-history", nil)
+	req.SetPathValue("code", "MISSING")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("catalog.PriceHistoryHandler.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
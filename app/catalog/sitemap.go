@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// SitemapMaxURLs is the maximum number of URLs of a single sitemap, as set
+// by the sitemaps protocol.
+const SitemapMaxURLs = 50000
+
+// sitemapNamespace is the XML namespace of sitemaps and sitemap indexes.
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URLSet is a sitemap listing the URLs of product pages.
+type URLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+// SitemapURL is a URL entry of a sitemap.
+type SitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+// SitemapIndex lists the pages of a sitemap too large for a single document.
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []SitemapRef `xml:"sitemap"`
+}
+
+// SitemapRef is a sitemap entry of a sitemap index.
+type SitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+// HandleSitemap handles GET /v1/catalog/sitemap.xml requests.
+// It returns a sitemap of the product pages, addressed by slug. When there are
+// more than SitemapMaxURLs products, it returns a sitemap index instead, whose
+// entries point to the pages of the sitemap (?page=1, ?page=2, ...).
+func (h *CatalogHandler) HandleSitemap(w http.ResponseWriter, r *http.Request) error {
+	page := 1
+	pageProvided := r.URL.Query().Has("page")
+	if pageProvided {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			return services.ErrInvalidPage
+		}
+	}
+
+	if !pageProvided {
+		// Count first, so that serving the index does not load a page of slugs.
+		_, total, err := h.service.ListProductSlugs(r.Context(), 0, 0)
+		if err != nil {
+			return err
+		}
+		if total > SitemapMaxURLs {
+			api.OKXMLResponse(w, r, h.sitemapIndex(total))
+			return nil
+		}
+	}
+
+	slugs, _, err := h.service.ListProductSlugs(r.Context(), (page-1)*SitemapMaxURLs, SitemapMaxURLs)
+	if err != nil {
+		return err
+	}
+	if page > 1 && len(slugs) == 0 {
+		return services.ErrNotFound
+	}
+
+	urlSet := URLSet{Xmlns: sitemapNamespace, URLs: make([]SitemapURL, len(slugs))}
+	for i, slug := range slugs {
+		urlSet.URLs[i] = SitemapURL{Loc: h.baseURL + "/v1/catalog/by-slug/" + url.PathEscape(slug)}
+	}
+
+	api.OKXMLResponse(w, r, urlSet)
+	return nil
+}
+
+// sitemapIndex returns the sitemap index referencing every page of a sitemap
+// of total products.
+func (h *CatalogHandler) sitemapIndex(total int64) SitemapIndex {
+	pages := int((total + SitemapMaxURLs - 1) / SitemapMaxURLs)
+	index := SitemapIndex{Xmlns: sitemapNamespace, Sitemaps: make([]SitemapRef, pages)}
+	for i := range index.Sitemaps {
+		index.Sitemaps[i] = SitemapRef{Loc: h.baseURL + "/v1/catalog/sitemap.xml?page=" + strconv.Itoa(i+1)}
+	}
+	return index
+}
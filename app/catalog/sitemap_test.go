@@ -0,0 +1,146 @@
+package catalog
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSitemapHandler(svc *mockCatalogService) *CatalogHandler {
+	return NewCatalogHandler(svc, CatalogHandlerConfig{BaseURL: "https://shop.example.com"})
+}
+
+func TestHandleSitemap_ListsProductURLs(t *testing.T) {
+	var gotOffset, gotLimit int
+	mockSvc := &mockCatalogService{
+		listProductSlugsFunc: func(ctx context.Context, offset, limit int) ([]string, int64, error) {
+			gotOffset, gotLimit = offset, limit
+			return []string{"prod001", "red shirt"}, 2, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleSitemap", newSitemapHandler(mockSvc).HandleSitemap).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+	assert.Equal(t, 0, gotOffset)
+	assert.Equal(t, SitemapMaxURLs, gotLimit)
+
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, xml.Header), "expected the XML declaration, got %q", body)
+
+	var urlSet struct {
+		XMLName xml.Name
+		URLs    []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+	}
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &urlSet))
+	assert.Equal(t, "urlset", urlSet.XMLName.Local)
+	assert.Equal(t, sitemapNamespace, urlSet.XMLName.Space)
+	require.Len(t, urlSet.URLs, 2)
+	assert.Equal(t, "https://shop.example.com/v1/catalog/by-slug/prod001", urlSet.URLs[0].Loc)
+	assert.Equal(t, "https://shop.example.com/v1/catalog/by-slug/red%20shirt", urlSet.URLs[1].Loc)
+}
+
+func TestHandleSitemap_EmptyCatalog(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductSlugsFunc: func(ctx context.Context, offset, limit int) ([]string, int64, error) {
+			return nil, 0, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleSitemap", newSitemapHandler(mockSvc).HandleSitemap).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></urlset>`)
+}
+
+func TestHandleSitemap_IndexWhenTooManyProducts(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductSlugsFunc: func(ctx context.Context, offset, limit int) ([]string, int64, error) {
+			if limit != 0 {
+				t.Errorf("expected only a count for the index, got a page of %d slugs", limit)
+			}
+			return make([]string, limit), 2*SitemapMaxURLs + 1, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleSitemap", newSitemapHandler(mockSvc).HandleSitemap).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+
+	var index struct {
+		XMLName  xml.Name
+		Sitemaps []struct {
+			Loc string `xml:"loc"`
+		} `xml:"sitemap"`
+	}
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &index))
+	assert.Equal(t, "sitemapindex", index.XMLName.Local)
+	require.Len(t, index.Sitemaps, 3)
+	assert.Equal(t, "https://shop.example.com/v1/catalog/sitemap.xml?page=1", index.Sitemaps[0].Loc)
+	assert.Equal(t, "https://shop.example.com/v1/catalog/sitemap.xml?page=3", index.Sitemaps[2].Loc)
+}
+
+func TestHandleSitemap_Page(t *testing.T) {
+	var gotOffset int
+	mockSvc := &mockCatalogService{
+		listProductSlugsFunc: func(ctx context.Context, offset, limit int) ([]string, int64, error) {
+			gotOffset = offset
+			return []string{"prod050001"}, SitemapMaxURLs + 1, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/sitemap.xml?page=2", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleSitemap", newSitemapHandler(mockSvc).HandleSitemap).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, SitemapMaxURLs, gotOffset)
+	assert.Contains(t, w.Body.String(), "<url><loc>https://shop.example.com/v1/catalog/by-slug/prod050001</loc></url>")
+	assert.NotContains(t, w.Body.String(), "sitemapindex")
+}
+
+func TestHandleSitemap_PageBeyondLast(t *testing.T) {
+	mockSvc := &mockCatalogService{
+		listProductSlugsFunc: func(ctx context.Context, offset, limit int) ([]string, int64, error) {
+			return nil, 10, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog/sitemap.xml?page=2", nil)
+	w := httptest.NewRecorder()
+	api.ErrorHandler("catalog.HandleSitemap", newSitemapHandler(mockSvc).HandleSitemap).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleSitemap_InvalidPage(t *testing.T) {
+	for _, page := range []string{"0", "-1", "two", ""} {
+		t.Run(page, func(t *testing.T) {
+			mockSvc := &mockCatalogService{}
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/catalog/sitemap.xml?page="+page, nil)
+			w := httptest.NewRecorder()
+			api.ErrorHandler("catalog.HandleSitemap", newSitemapHandler(mockSvc).HandleSitemap).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "page must be a positive integer")
+		})
+	}
+}
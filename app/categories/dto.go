@@ -0,0 +1,21 @@
+package categories
+
+// CategoryResponse represents a category in API responses.
+type CategoryResponse struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	ProductCount int64  `json:"product_count"`
+}
+
+// CreateCategoryRequest represents the request body for creating a category.
+type CreateCategoryRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// UpdateCategoryRequest represents the request body for updating a category.
+// Code is optional; if present it must match the category code in the path.
+type UpdateCategoryRequest struct {
+	Code *string `json:"code"`
+	Name string  `json:"name"`
+}
@@ -0,0 +1,33 @@
+package categories
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+func TestCategoryResponse_JSONFields(t *testing.T) {
+	response := mapCategoryToResponse(services.CategoryDTO{Code: "SHOES", Name: "Shoes", ProductCount: 3})
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"code":"SHOES","name":"Shoes","product_count":3}`
+	if string(body) != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestCreateCategoryRequest_JSONFields(t *testing.T) {
+	var req CreateCategoryRequest
+	if err := json.Unmarshal([]byte(`{"code":"SHOES","name":"Shoes"}`), &req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.Code != "SHOES" || req.Name != "Shoes" {
+		t.Errorf("expected SHOES/Shoes, got %+v", req)
+	}
+}
@@ -10,22 +10,13 @@ import (
 	"github.com/mytheresa/go-hiring-challenge/app/services"
 )
 
-// CategoryResponse represents a category in API responses.
-type CategoryResponse struct {
-	Code string `json:"code"`
-	Name string `json:"name"`
-}
-
-// CreateCategoryRequest represents the request body for creating a category.
-type CreateCategoryRequest struct {
-	Code string `json:"code"`
-	Name string `json:"name"`
-}
-
 // CategoriesService defines the interface for category business logic.
 type CategoriesService interface {
-	ListCategories(ctx context.Context) ([]services.CategoryDTO, error)
+	ListCategories(ctx context.Context, sort string) ([]services.CategoryDTO, error)
 	CreateCategory(ctx context.Context, input services.CreateCategoryInput) (*services.CategoryDTO, error)
+	UpdateCategory(ctx context.Context, code string, input services.UpdateCategoryInput) (*services.CategoryDTO, error)
+	DeleteCategory(ctx context.Context, code string) error
+	RestoreCategory(ctx context.Context, code string) (*services.CategoryDTO, error)
 }
 
 // CategoriesHandler handles HTTP requests for the categories endpoints.
@@ -39,18 +30,17 @@ func NewCategoriesHandler(s CategoriesService) *CategoriesHandler {
 }
 
 // HandleGet handles GET /categories requests for listing categories.
+// Supports the query parameter sort: code_asc (default), code_desc, name_asc, name_desc.
 func (h *CategoriesHandler) HandleGet(w http.ResponseWriter, r *http.Request) error {
-	categories, err := h.service.ListCategories(r.Context())
+	categories, err := h.service.ListCategories(r.Context(), r.URL.Query().Get("sort"))
 	if err != nil {
 		return err
 	}
 
+	// make keeps the response non-nil, so no categories encode as [] rather than null.
 	response := make([]CategoryResponse, len(categories))
 	for i, c := range categories {
-		response[i] = CategoryResponse{
-			Code: c.Code,
-			Name: c.Name,
-		}
+		response[i] = mapCategoryToResponse(c)
 	}
 
 	api.OKResponse(w, r, response)
@@ -74,11 +64,60 @@ func (h *CategoriesHandler) HandlePost(w http.ResponseWriter, r *http.Request) e
 		return err
 	}
 
-	response := CategoryResponse{
-		Code: category.Code,
-		Name: category.Name,
-	}
+	response := mapCategoryToResponse(*category)
 
 	api.CreatedResponse(w, r, response)
 	return nil
 }
+
+// HandleUpdate handles PUT /categories/{code} requests for renaming a category.
+func (h *CategoriesHandler) HandleUpdate(w http.ResponseWriter, r *http.Request) error {
+	var req UpdateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return services.ErrInvalidInput
+	}
+
+	input := services.UpdateCategoryInput{
+		Code: req.Code,
+		Name: req.Name,
+	}
+
+	category, err := h.service.UpdateCategory(r.Context(), r.PathValue("code"), input)
+	if err != nil {
+		return err
+	}
+
+	api.OKResponse(w, r, mapCategoryToResponse(*category))
+	return nil
+}
+
+// HandleDelete handles DELETE /categories/{code} requests for soft-deleting a category.
+func (h *CategoriesHandler) HandleDelete(w http.ResponseWriter, r *http.Request) error {
+	if err := h.service.DeleteCategory(r.Context(), r.PathValue("code")); err != nil {
+		return err
+	}
+
+	api.NoContentResponse(w, r)
+	return nil
+}
+
+// HandleRestore handles PUT /categories/{code}/restore requests for restoring a soft-deleted category.
+func (h *CategoriesHandler) HandleRestore(w http.ResponseWriter, r *http.Request) error {
+	category, err := h.service.RestoreCategory(r.Context(), r.PathValue("code"))
+	if err != nil {
+		return err
+	}
+
+	response := mapCategoryToResponse(*category)
+
+	api.OKResponse(w, r, response)
+	return nil
+}
+
+func mapCategoryToResponse(c services.CategoryDTO) CategoryResponse {
+	return CategoryResponse{
+		Code:         c.Code,
+		Name:         c.Name,
+		ProductCount: c.ProductCount,
+	}
+}
@@ -7,42 +7,90 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/api/apitest"
 	"github.com/mytheresa/go-hiring-challenge/app/services"
 )
 
 // mockCategoriesService is a mock implementation of CategoriesService for testing.
 type mockCategoriesService struct {
-	listCategoriesFunc func(ctx context.Context) ([]services.CategoryDTO, error)
-	createCategoryFunc func(ctx context.Context, input services.CreateCategoryInput) (*services.CategoryDTO, error)
+	listCategoriesFunc  func(ctx context.Context, sort string) ([]services.CategoryDTO, error)
+	createCategoryFunc  func(ctx context.Context, input services.CreateCategoryInput) (*services.CategoryDTO, error)
+	updateCategoryFunc  func(ctx context.Context, code string, input services.UpdateCategoryInput) (*services.CategoryDTO, error)
+	deleteCategoryFunc  func(ctx context.Context, code string) error
+	restoreCategoryFunc func(ctx context.Context, code string) (*services.CategoryDTO, error)
+	// calls counts the calls to any method, see newMockCategoriesService.
+	calls atomic.Int32
 }
 
-func (m *mockCategoriesService) ListCategories(ctx context.Context) ([]services.CategoryDTO, error) {
+// newMockCategoriesService returns an empty mock whose function fields the test sets.
+// The test fails if the handler never calls the mock, so tests that expect the
+// service to be reached cannot pass by rejecting the request early.
+func newMockCategoriesService(t *testing.T) *mockCategoriesService {
+	t.Helper()
+
+	m := &mockCategoriesService{}
+	t.Cleanup(func() {
+		if m.calls.Load() == 0 {
+			t.Errorf("mock categories service was never called")
+		}
+	})
+	return m
+}
+
+func (m *mockCategoriesService) ListCategories(ctx context.Context, sort string) ([]services.CategoryDTO, error) {
+	m.calls.Add(1)
 	if m.listCategoriesFunc != nil {
-		return m.listCategoriesFunc(ctx)
+		return m.listCategoriesFunc(ctx, sort)
 	}
 	return nil, errors.New("not implemented")
 }
 
 func (m *mockCategoriesService) CreateCategory(ctx context.Context, input services.CreateCategoryInput) (*services.CategoryDTO, error) {
+	m.calls.Add(1)
 	if m.createCategoryFunc != nil {
 		return m.createCategoryFunc(ctx, input)
 	}
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockCategoriesService) UpdateCategory(ctx context.Context, code string, input services.UpdateCategoryInput) (*services.CategoryDTO, error) {
+	m.calls.Add(1)
+	if m.updateCategoryFunc != nil {
+		return m.updateCategoryFunc(ctx, code, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCategoriesService) DeleteCategory(ctx context.Context, code string) error {
+	m.calls.Add(1)
+	if m.deleteCategoryFunc != nil {
+		return m.deleteCategoryFunc(ctx, code)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoriesService) RestoreCategory(ctx context.Context, code string) (*services.CategoryDTO, error) {
+	m.calls.Add(1)
+	if m.restoreCategoryFunc != nil {
+		return m.restoreCategoryFunc(ctx, code)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func TestHandleGet_Success(t *testing.T) {
 	// Setup mock service
-	mockSvc := &mockCategoriesService{
-		listCategoriesFunc: func(ctx context.Context) ([]services.CategoryDTO, error) {
-			return []services.CategoryDTO{
-				{Code: "CLOTHING", Name: "Clothing"},
-				{Code: "SHOES", Name: "Shoes"},
-				{Code: "ACCESSORIES", Name: "Accessories"},
-			}, nil
-		},
+	mockSvc := newMockCategoriesService(t)
+	mockSvc.listCategoriesFunc = func(ctx context.Context, sort string) ([]services.CategoryDTO, error) {
+		return []services.CategoryDTO{
+			{Code: "CLOTHING", Name: "Clothing", ProductCount: 2},
+			{Code: "SHOES", Name: "Shoes", ProductCount: 1},
+			{Code: "ACCESSORIES", Name: "Accessories"},
+		}, nil
 	}
 
 	handler := NewCategoriesHandler(mockSvc)
@@ -52,7 +100,7 @@ func TestHandleGet_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("categories.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusOK {
@@ -76,12 +124,44 @@ func TestHandleGet_Success(t *testing.T) {
 	if response[1].Name != "Shoes" {
 		t.Errorf("expected second category name Shoes, got %s", response[1].Name)
 	}
+
+	if response[0].ProductCount != 2 || response[1].ProductCount != 1 || response[2].ProductCount != 0 {
+		t.Errorf("unexpected product counts: %d, %d, %d", response[0].ProductCount, response[1].ProductCount, response[2].ProductCount)
+	}
+}
+
+func TestHandleGet_NoCategories(t *testing.T) {
+	mockSvc := &mockCategoriesService{
+		listCategoriesFunc: func(ctx context.Context, sort string) ([]services.CategoryDTO, error) {
+			return nil, nil
+		},
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	// Decoding null would leave the slice nil.
+	var response []CategoryResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response == nil || len(response) != 0 {
+		t.Errorf("expected an empty JSON array, got %v", response)
+	}
 }
 
 func TestHandleGet_RepositoryError(t *testing.T) {
 	// Setup mock service that returns error
 	mockSvc := &mockCategoriesService{
-		listCategoriesFunc: func(ctx context.Context) ([]services.CategoryDTO, error) {
+		listCategoriesFunc: func(ctx context.Context, sort string) ([]services.CategoryDTO, error) {
 			return nil, errors.New("database error")
 		},
 	}
@@ -93,7 +173,7 @@ func TestHandleGet_RepositoryError(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandleGet).ServeHTTP(w, req)
+	api.ErrorHandler("categories.HandleGet", handler.HandleGet).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusInternalServerError {
@@ -101,19 +181,62 @@ func TestHandleGet_RepositoryError(t *testing.T) {
 	}
 }
 
-func TestHandlePost_Success(t *testing.T) {
-	// Setup mock service
+func TestHandleGet_SortParam(t *testing.T) {
 	mockSvc := &mockCategoriesService{
-		createCategoryFunc: func(ctx context.Context, input services.CreateCategoryInput) (*services.CategoryDTO, error) {
-			return &services.CategoryDTO{
-				Code: input.Code,
-				Name: input.Name,
-			}, nil
+		listCategoriesFunc: func(ctx context.Context, sort string) ([]services.CategoryDTO, error) {
+			if sort != "name_desc" {
+				t.Errorf("expected sort name_desc, got %q", sort)
+			}
+			return []services.CategoryDTO{}, nil
+		},
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories?sort=name_desc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestHandleGet_InvalidSort(t *testing.T) {
+	mockSvc := &mockCategoriesService{
+		listCategoriesFunc: func(ctx context.Context, sort string) ([]services.CategoryDTO, error) {
+			return nil, services.ErrInvalidSort
 		},
 	}
 
 	handler := NewCategoriesHandler(mockSvc)
 
+	req := httptest.NewRequest(http.MethodGet, "/categories?sort=price_asc", nil)
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleGet", handler.HandleGet).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), services.ErrInvalidSort.Error()) {
+		t.Errorf("expected error message %q, got %s", services.ErrInvalidSort, w.Body.String())
+	}
+}
+
+func TestHandlePost_Success(t *testing.T) {
+	// Setup mock service
+	mockSvc := newMockCategoriesService(t)
+	mockSvc.createCategoryFunc = func(ctx context.Context, input services.CreateCategoryInput) (*services.CategoryDTO, error) {
+		return &services.CategoryDTO{
+			Code: input.Code,
+			Name: input.Name,
+		}, nil
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
 	// Create request
 	reqBody := CreateCategoryRequest{
 		Code: "ELECTRONICS",
@@ -125,7 +248,7 @@ func TestHandlePost_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandlePost).ServeHTTP(w, req)
+	api.ErrorHandler("categories.HandlePost", handler.HandlePost).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusCreated {
@@ -157,7 +280,7 @@ func TestHandlePost_InvalidJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandlePost).ServeHTTP(w, req)
+	api.ErrorHandler("categories.HandlePost", handler.HandlePost).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusBadRequest {
@@ -183,7 +306,7 @@ func TestHandlePost_MissingCode(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandlePost).ServeHTTP(w, req)
+	api.ErrorHandler("categories.HandlePost", handler.HandlePost).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusBadRequest {
@@ -209,7 +332,7 @@ func TestHandlePost_MissingName(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandlePost).ServeHTTP(w, req)
+	api.ErrorHandler("categories.HandlePost", handler.HandlePost).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusBadRequest {
@@ -238,10 +361,235 @@ func TestHandlePost_RepositoryError(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Execute handler
-	api.ErrorHandler(handler.HandlePost).ServeHTTP(w, req)
+	api.ErrorHandler("categories.HandlePost", handler.HandlePost).ServeHTTP(w, req)
 
 	// Assert response
 	if w.Code != http.StatusInternalServerError {
 		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
 	}
 }
+
+func TestHandlePost_DuplicateCode(t *testing.T) {
+	mockSvc := &mockCategoriesService{
+		createCategoryFunc: func(ctx context.Context, input services.CreateCategoryInput) (*services.CategoryDTO, error) {
+			return nil, services.ErrDuplicateCode
+		},
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	body, _ := json.Marshal(CreateCategoryRequest{Code: "CLOTHING", Name: "Clothing"})
+	req := httptest.NewRequest(http.MethodPost, "/categories", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandlePost", handler.HandlePost).ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	response := apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeConflict)
+	if response.Message != services.ErrDuplicateCode.Error() {
+		t.Errorf("expected message %q, got %q", services.ErrDuplicateCode.Error(), response.Message)
+	}
+}
+
+func TestHandleDelete_Success(t *testing.T) {
+	var capturedCode string
+	mockSvc := newMockCategoriesService(t)
+	mockSvc.deleteCategoryFunc = func(ctx context.Context, code string) error {
+		capturedCode = code
+		return nil
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/categories/SHOES", nil)
+	req.SetPathValue("code", "SHOES")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleDelete", handler.HandleDelete).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", w.Body.String())
+	}
+
+	if capturedCode != "SHOES" {
+		t.Errorf("expected code SHOES to be passed to service, got %s", capturedCode)
+	}
+}
+
+func TestHandleDelete_NotFound(t *testing.T) {
+	mockSvc := &mockCategoriesService{
+		deleteCategoryFunc: func(ctx context.Context, code string) error {
+			return services.ErrNotFound
+		},
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/categories/MISSING", nil)
+	req.SetPathValue("code", "MISSING")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleDelete", handler.HandleDelete).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeNotFound)
+}
+
+func TestHandleDelete_InUse(t *testing.T) {
+	mockSvc := &mockCategoriesService{
+		deleteCategoryFunc: func(ctx context.Context, code string) error {
+			return services.ErrCategoryInUse
+		},
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/categories/CLOTHING", nil)
+	req.SetPathValue("code", "CLOTHING")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleDelete", handler.HandleDelete).ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	response := apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeConflict)
+	if response.Message != services.ErrCategoryInUse.Error() {
+		t.Errorf("expected message %q, got %q", services.ErrCategoryInUse.Error(), response.Message)
+	}
+}
+
+func TestHandleUpdate_Success(t *testing.T) {
+	mockSvc := newMockCategoriesService(t)
+	mockSvc.updateCategoryFunc = func(ctx context.Context, code string, input services.UpdateCategoryInput) (*services.CategoryDTO, error) {
+		if code != "SHOES" {
+			t.Errorf("expected code SHOES, got %s", code)
+		}
+		if input.Code != nil {
+			t.Errorf("expected no body code, got %q", *input.Code)
+		}
+		return &services.CategoryDTO{Code: code, Name: input.Name}, nil
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPut, "/categories/SHOES", bytes.NewReader([]byte(`{"name":"Footwear"}`)))
+	req.SetPathValue("code", "SHOES")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response CategoryResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Name != "Footwear" {
+		t.Errorf("expected name Footwear, got %s", response.Name)
+	}
+}
+
+func TestHandleUpdate_CodeImmutable(t *testing.T) {
+	mockSvc := &mockCategoriesService{
+		updateCategoryFunc: func(ctx context.Context, code string, input services.UpdateCategoryInput) (*services.CategoryDTO, error) {
+			if input.Code == nil || *input.Code != "FOOTWEAR" {
+				t.Errorf("expected body code FOOTWEAR to be passed, got %v", input.Code)
+			}
+			return nil, services.ErrCodeImmutable
+		},
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPut, "/categories/SHOES", bytes.NewReader([]byte(`{"code":"FOOTWEAR","name":"Footwear"}`)))
+	req.SetPathValue("code", "SHOES")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+}
+
+func TestHandleUpdate_InvalidJSON(t *testing.T) {
+	handler := NewCategoriesHandler(&mockCategoriesService{})
+
+	req := httptest.NewRequest(http.MethodPut, "/categories/SHOES", bytes.NewReader([]byte(`{`)))
+	req.SetPathValue("code", "SHOES")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleUpdate", handler.HandleUpdate).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandleRestore_Success(t *testing.T) {
+	mockSvc := newMockCategoriesService(t)
+	mockSvc.restoreCategoryFunc = func(ctx context.Context, code string) (*services.CategoryDTO, error) {
+		return &services.CategoryDTO{Code: code, Name: "Shoes"}, nil
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPut, "/categories/SHOES/restore", nil)
+	req.SetPathValue("code", "SHOES")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleRestore", handler.HandleRestore).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response CategoryResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Code != "SHOES" {
+		t.Errorf("expected code SHOES, got %s", response.Code)
+	}
+
+	if response.Name != "Shoes" {
+		t.Errorf("expected name Shoes, got %s", response.Name)
+	}
+}
+
+func TestHandleRestore_NotFound(t *testing.T) {
+	mockSvc := &mockCategoriesService{
+		restoreCategoryFunc: func(ctx context.Context, code string) (*services.CategoryDTO, error) {
+			return nil, services.ErrNotFound
+		},
+	}
+
+	handler := NewCategoriesHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPut, "/categories/MISSING/restore", nil)
+	req.SetPathValue("code", "MISSING")
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("categories.HandleRestore", handler.HandleRestore).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	apitest.AssertErrorResponse(t, w.Result(), api.ErrCodeNotFound)
+}
@@ -0,0 +1,280 @@
+// Package config loads application configuration from environment variables.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+)
+
+// DefaultShutdownTimeout is used when SHUTDOWN_TIMEOUT_SECONDS is unset or not positive.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// DefaultCurrency is used when CATALOG_CURRENCY is unset.
+const DefaultCurrency = "USD"
+
+// currencyPattern is the format of ISO 4217 currency codes.
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// DatabaseConfig holds the PostgreSQL connection settings.
+type DatabaseConfig struct {
+	User     string
+	Password string
+	// Host is a hostname, an IP address or a Unix socket directory; see database.New.
+	Host string
+	Name string
+	Port string
+}
+
+// Config holds the application configuration.
+type Config struct {
+	// HTTPPort is the port the HTTP server listens on.
+	HTTPPort string
+	Database DatabaseConfig
+	// RedisAddr and RedisPassword locate the cache server.
+	RedisAddr     string
+	RedisPassword string
+	// CatalogCacheTTL and ProductCacheTTL are how long listings and product
+	// details stay cached.
+	CatalogCacheTTL time.Duration
+	ProductCacheTTL time.Duration
+	// JWTSecret is the key mutation requests' tokens are signed with.
+	JWTSecret string
+	// AdminToken enables the admin routes when set.
+	AdminToken string
+	// RequestIDHeader is the header the request ID is read from and written to;
+	// empty for the default X-Request-ID.
+	RequestIDHeader string
+
+	// DefaultPageLimit is the page size used when a request doesn't set a limit.
+	DefaultPageLimit int
+	// MaxPageLimit is the largest page size a request may ask for.
+	MaxPageLimit int
+	// ShutdownTimeout is how long the server waits for in-flight requests on shutdown.
+	ShutdownTimeout time.Duration
+	// BaseURL is the public URL the API is reached at, without a trailing slash.
+	// Absolute links, e.g. in the sitemap, are built from it.
+	BaseURL string
+	// Currency is the ISO 4217 code of the currency catalog prices are in.
+	Currency string
+}
+
+// Load reads the configuration from environment variables, applying defaults
+// for unset values. Returns an error if a required value is missing or a
+// value is invalid, so the server fails at startup rather than on first use.
+// HTTP_PORT, POSTGRES_USER, POSTGRES_DB, POSTGRES_PORT and JWT_SECRET are required.
+func Load() (*Config, error) {
+	httpPort, err := envPort("HTTP_PORT")
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := loadDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	catalogCacheTTL, err := envSeconds("CATALOG_CACHE_TTL_SECONDS", 30)
+	if err != nil {
+		return nil, err
+	}
+	productCacheTTL, err := envSeconds("PRODUCT_CACHE_TTL_SECONDS", 60)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSecret, err := envRequired("JWT_SECRET")
+	if err != nil {
+		return nil, err
+	}
+
+	defaultLimit, err := envInt("DEFAULT_PAGE_LIMIT", 10)
+	if err != nil {
+		return nil, err
+	}
+	maxLimit, err := envInt("MAX_PAGE_LIMIT", 100)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaultLimit < 1 {
+		return nil, fmt.Errorf("DEFAULT_PAGE_LIMIT must be a positive integer, got %d", defaultLimit)
+	}
+	if maxLimit < defaultLimit {
+		return nil, fmt.Errorf("MAX_PAGE_LIMIT (%d) must not be less than DEFAULT_PAGE_LIMIT (%d)", maxLimit, defaultLimit)
+	}
+
+	shutdownTimeout, err := parseShutdownTimeout(os.Getenv("SHUTDOWN_TIMEOUT_SECONDS"))
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := parseBaseURL(os.Getenv("BASE_URL"), httpPort)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := parseCurrency(os.Getenv("CATALOG_CURRENCY"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		HTTPPort:        httpPort,
+		Database:        db,
+		RedisAddr:       envString("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:   os.Getenv("REDIS_PASSWORD"),
+		CatalogCacheTTL: catalogCacheTTL,
+		ProductCacheTTL: productCacheTTL,
+		JWTSecret:       jwtSecret,
+		AdminToken:      os.Getenv("ADMIN_TOKEN"),
+		RequestIDHeader: os.Getenv("REQUEST_ID_HEADER"),
+
+		DefaultPageLimit: defaultLimit,
+		MaxPageLimit:     maxLimit,
+		ShutdownTimeout:  shutdownTimeout,
+		BaseURL:          baseURL,
+		Currency:         currency,
+	}, nil
+}
+
+// parseCurrency validates a CATALOG_CURRENCY value. An empty value yields
+// DefaultCurrency. Returns an error if the value is not three uppercase letters.
+func parseCurrency(value string) (string, error) {
+	if value == "" {
+		return DefaultCurrency, nil
+	}
+	if !currencyPattern.MatchString(value) {
+		return "", fmt.Errorf("CATALOG_CURRENCY must be an ISO 4217 currency code, got %q", value)
+	}
+	return value, nil
+}
+
+// parseBaseURL validates a BASE_URL value and strips its trailing slashes.
+// An empty value yields http://localhost on port, the address the server
+// listens on. Returns an error if the value is not an absolute http or https URL.
+func parseBaseURL(value, port string) (string, error) {
+	if value == "" {
+		return "http://localhost:" + port, nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("BASE_URL must be an absolute http or https URL, got %q", value)
+	}
+	return strings.TrimRight(value, "/"), nil
+}
+
+// loadDatabase reads the PostgreSQL settings. POSTGRES_HOST defaults to localhost
+// and POSTGRES_PASSWORD may be empty; the other settings are required.
+func loadDatabase() (DatabaseConfig, error) {
+	user, err := envRequired("POSTGRES_USER")
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+	name, err := envRequired("POSTGRES_DB")
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+	port, err := envPort("POSTGRES_PORT")
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+
+	return DatabaseConfig{
+		User:     user,
+		Password: os.Getenv("POSTGRES_PASSWORD"),
+		Host:     envString("POSTGRES_HOST", "localhost"),
+		Name:     name,
+		Port:     port,
+	}, nil
+}
+
+// parseShutdownTimeout converts a SHUTDOWN_TIMEOUT_SECONDS value to a duration.
+// An empty value yields DefaultShutdownTimeout; so does a zero or negative one,
+// after logging a warning. Returns an error if the value is not an integer.
+func parseShutdownTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultShutdownTimeout, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("SHUTDOWN_TIMEOUT_SECONDS must be an integer, got %q", value)
+	}
+	if seconds <= 0 {
+		logger.Warn("SHUTDOWN_TIMEOUT_SECONDS must be positive, using default",
+			"value", seconds, "default", DefaultShutdownTimeout)
+		return DefaultShutdownTimeout, nil
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// envInt reads an integer from the environment variable key.
+// Returns def if the variable is not set.
+func envInt(key string, def int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer, got %q", key, value)
+	}
+	return n, nil
+}
+
+// envString reads the environment variable key, returning def if it is not set.
+func envString(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// envRequired reads the environment variable key.
+// Returns an error if the variable is not set.
+func envRequired(key string) (string, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return "", fmt.Errorf("%s must be set", key)
+	}
+	return value, nil
+}
+
+// envPort reads a TCP port number from the environment variable key.
+// Returns an error if the variable is not set or not a port number.
+func envPort(key string) (string, error) {
+	value, err := envRequired(key)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return "", fmt.Errorf("%s must be a port number between 1 and 65535, got %q", key, value)
+	}
+	return value, nil
+}
+
+// envSeconds reads a duration in whole seconds from the environment variable key.
+// Returns def seconds if the variable is not set.
+func envSeconds(key string, def int) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Duration(def) * time.Second, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", key, value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setRequiredEnv sets every variable Load requires to a valid value.
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("HTTP_PORT", "8484")
+	t.Setenv("POSTGRES_USER", "catalog")
+	t.Setenv("POSTGRES_DB", "catalog")
+	t.Setenv("POSTGRES_PORT", "5432")
+	t.Setenv("JWT_SECRET", "secret")
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("POSTGRES_HOST", "")
+	t.Setenv("REDIS_ADDR", "")
+	t.Setenv("CATALOG_CACHE_TTL_SECONDS", "")
+	t.Setenv("PRODUCT_CACHE_TTL_SECONDS", "")
+	t.Setenv("DEFAULT_PAGE_LIMIT", "")
+	t.Setenv("MAX_PAGE_LIMIT", "")
+	t.Setenv("SHUTDOWN_TIMEOUT_SECONDS", "")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, 10, cfg.DefaultPageLimit)
+	assert.Equal(t, 100, cfg.MaxPageLimit)
+	assert.Equal(t, DefaultShutdownTimeout, cfg.ShutdownTimeout)
+	assert.Equal(t, "8484", cfg.HTTPPort)
+	assert.Equal(t, DatabaseConfig{User: "catalog", Host: "localhost", Name: "catalog", Port: "5432"}, cfg.Database)
+	assert.Equal(t, "localhost:6379", cfg.RedisAddr)
+	assert.Equal(t, 30*time.Second, cfg.CatalogCacheTTL)
+	assert.Equal(t, 60*time.Second, cfg.ProductCacheTTL)
+	assert.Equal(t, "secret", cfg.JWTSecret)
+}
+
+func TestLoad_Required(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "missing HTTP_PORT", key: "HTTP_PORT", value: ""},
+		{name: "non-numeric HTTP_PORT", key: "HTTP_PORT", value: "http"},
+		{name: "out of range HTTP_PORT", key: "HTTP_PORT", value: "70000"},
+		{name: "missing POSTGRES_USER", key: "POSTGRES_USER", value: ""},
+		{name: "missing POSTGRES_DB", key: "POSTGRES_DB", value: ""},
+		{name: "missing POSTGRES_PORT", key: "POSTGRES_PORT", value: ""},
+		{name: "non-numeric POSTGRES_PORT", key: "POSTGRES_PORT", value: "pg"},
+		{name: "missing JWT_SECRET", key: "JWT_SECRET", value: ""},
+		{name: "non-numeric catalog cache TTL", key: "CATALOG_CACHE_TTL_SECONDS", value: "half"},
+		{name: "negative product cache TTL", key: "PRODUCT_CACHE_TTL_SECONDS", value: "-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			t.Setenv(tt.key, tt.value)
+
+			_, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.key)
+		})
+	}
+}
+
+func TestLoad_ShutdownTimeout(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SHUTDOWN_TIMEOUT_SECONDS", "45")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, cfg.ShutdownTimeout)
+
+	t.Setenv("SHUTDOWN_TIMEOUT_SECONDS", "soon")
+
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+func TestParseShutdownTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "unset", value: "", expected: DefaultShutdownTimeout},
+		{name: "custom", value: "30", expected: 30 * time.Second},
+		{name: "zero falls back to default", value: "0", expected: DefaultShutdownTimeout},
+		{name: "negative falls back to default", value: "-5", expected: DefaultShutdownTimeout},
+		{name: "non-numeric", value: "ten", expectErr: true},
+		{name: "fractional", value: "1.5", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout, err := parseShutdownTimeout(tt.value)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
+
+func TestParseBaseURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expected  string
+		expectErr bool
+	}{
+		{name: "unset", value: "", expected: "http://localhost:8484"},
+		{name: "custom", value: "https://shop.example.com", expected: "https://shop.example.com"},
+		{name: "trailing slash", value: "https://shop.example.com/api/", expected: "https://shop.example.com/api"},
+		{name: "relative", value: "/api", expectErr: true},
+		{name: "unsupported scheme", value: "ftp://shop.example.com", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, err := parseBaseURL(tt.value, "8484")
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, baseURL)
+		})
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expected  string
+		expectErr bool
+	}{
+		{name: "unset", value: "", expected: DefaultCurrency},
+		{name: "custom", value: "EUR", expected: "EUR"},
+		{name: "lowercase", value: "eur", expectErr: true},
+		{name: "too long", value: "EURO", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency, err := parseCurrency(tt.value)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, currency)
+		})
+	}
+}
+
+func TestLoad_PageLimits(t *testing.T) {
+	tests := []struct {
+		name            string
+		defaultLimit    string
+		maxLimit        string
+		expectedDefault int
+		expectedMax     int
+		expectErr       bool
+	}{
+		{name: "custom limits", defaultLimit: "25", maxLimit: "500", expectedDefault: 25, expectedMax: 500},
+		{name: "equal limits", defaultLimit: "50", maxLimit: "50", expectedDefault: 50, expectedMax: 50},
+		{name: "non-numeric default", defaultLimit: "ten", maxLimit: "100", expectErr: true},
+		{name: "non-numeric max", defaultLimit: "10", maxLimit: "lots", expectErr: true},
+		{name: "zero default", defaultLimit: "0", maxLimit: "100", expectErr: true},
+		{name: "max below default", defaultLimit: "50", maxLimit: "20", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			t.Setenv("DEFAULT_PAGE_LIMIT", tt.defaultLimit)
+			t.Setenv("MAX_PAGE_LIMIT", tt.maxLimit)
+
+			cfg, err := Load()
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDefault, cfg.DefaultPageLimit)
+			assert.Equal(t, tt.expectedMax, cfg.MaxPageLimit)
+		})
+	}
+}
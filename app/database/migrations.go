@@ -0,0 +1,126 @@
+package database
+
+import (
+	"embed"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration is a schema change applied once per database.
+type migration struct {
+	// Version identifies the migration in the schema_migrations table.
+	Version string
+	// Table the migration alters. The migration is deferred until it exists,
+	// since tables may still be created afterwards by the seed scripts or AutoMigrate.
+	Table string
+	// File is the SQL script in the migrations directory.
+	File string
+	// Index, if set, is the index the script builds CONCURRENTLY. A failed
+	// concurrent build leaves an INVALID index that IF NOT EXISTS accepts, so
+	// an invalid index is dropped before the script runs and the migration is
+	// only recorded once the index is valid.
+	Index string
+}
+
+// migrations are applied in order. Scripts must be idempotent and must not rely
+// on a transaction, so that statements such as CREATE INDEX CONCURRENTLY can be used.
+var migrations = []migration{
+	{Version: "001", Table: "products", File: "001-products-code-unique.sql", Index: "idx_products_code"},
+	{Version: "002", Table: "categories", File: "002-categories-not-blank.sql"},
+	{Version: "003", Table: "product_variants", File: "003-variants-product-sku-unique.sql", Index: "idx_product_variants_product_sku"},
+	{Version: "004", Table: "products", File: "004-products-price-non-negative.sql"},
+	// Products predate categories in the sql/ scripts, so wait for the referenced table.
+	{Version: "005", Table: "categories", File: "005-products-category-fk.sql"},
+}
+
+// Migrate applies the pending migrations and records them in the schema_migrations table.
+// It is safe to run repeatedly: applied migrations are skipped.
+func Migrate(db *gorm.DB) error {
+	if err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version VARCHAR(64) PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+	)`).Error; err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var applied []string
+	if err := db.Table("schema_migrations").Pluck("version", &applied).Error; err != nil {
+		return fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	done := make(map[string]bool, len(applied))
+	for _, version := range applied {
+		done[version] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.Version] || !db.Migrator().HasTable(m.Table) {
+			continue
+		}
+
+		script, err := migrationFiles.ReadFile("migrations/" + m.File)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", m.Version, err)
+		}
+		if m.Index != "" {
+			if err := dropInvalidIndex(db, m.Index); err != nil {
+				return fmt.Errorf("failed to prepare migration %s: %w", m.Version, err)
+			}
+		}
+		if err := db.Exec(string(script)).Error; err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.Version, err)
+		}
+		if m.Index != "" {
+			valid, err := indexValid(db, m.Index)
+			if err != nil {
+				return fmt.Errorf("failed to verify migration %s: %w", m.Version, err)
+			}
+			if !valid {
+				return fmt.Errorf("migration %s left index %s missing or invalid", m.Version, m.Index)
+			}
+		}
+		if err := db.Exec("INSERT INTO schema_migrations (version) VALUES (?) ON CONFLICT DO NOTHING", m.Version).Error; err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", m.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// indexState reports whether the named index exists and whether it is valid.
+func indexState(db *gorm.DB, name string) (exists, valid bool, err error) {
+	var states []bool
+	if err := db.Raw(`SELECT i.indisvalid FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid WHERE c.relname = ?`, name).
+		Scan(&states).Error; err != nil {
+		return false, false, fmt.Errorf("failed to check index %s: %w", name, err)
+	}
+	if len(states) == 0 {
+		return false, false, nil
+	}
+	return true, states[0], nil
+}
+
+// indexValid reports whether the named index exists and is valid.
+func indexValid(db *gorm.DB, name string) (bool, error) {
+	exists, valid, err := indexState(db, name)
+	return exists && valid, err
+}
+
+// dropInvalidIndex drops the named index if an earlier concurrent build left it invalid.
+func dropInvalidIndex(db *gorm.DB, name string) error {
+	exists, valid, err := indexState(db, name)
+	if err != nil {
+		return err
+	}
+	if !exists || valid {
+		return nil
+	}
+	// name comes from the static migrations list, never from input.
+	if err := db.Exec("DROP INDEX CONCURRENTLY IF EXISTS " + name).Error; err != nil {
+		return fmt.Errorf("failed to drop invalid index %s: %w", name, err)
+	}
+	return nil
+}
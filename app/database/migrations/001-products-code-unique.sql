@@ -0,0 +1,3 @@
+-- Enforce unique product codes on databases created without the GORM tag,
+-- e.g. from the scripts in sql/. CONCURRENTLY avoids locking writes.
+CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_products_code ON products(code);
@@ -0,0 +1,11 @@
+-- Reject blank category codes and names at the database level, so rows written
+-- outside the service layer (seed scripts, manual fixes) are validated too.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_categories_code_not_blank') THEN
+        ALTER TABLE categories ADD CONSTRAINT chk_categories_code_not_blank CHECK (length(trim(code)) > 0);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_categories_name_not_blank') THEN
+        ALTER TABLE categories ADD CONSTRAINT chk_categories_name_not_blank CHECK (length(trim(name)) > 0);
+    END IF;
+END $$;
@@ -0,0 +1,3 @@
+-- Enforce one row per SKU within each product, so a variant can never be
+-- duplicated under a product, whatever created the table. CONCURRENTLY avoids locking writes.
+CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_product_variants_product_sku ON product_variants(product_id, sku);
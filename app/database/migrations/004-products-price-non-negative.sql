@@ -0,0 +1,8 @@
+-- Reject negative product prices at the database level, so rows written
+-- outside the service layer (seed scripts, manual fixes) are validated too.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_products_price_non_negative') THEN
+        ALTER TABLE products ADD CONSTRAINT chk_products_price_non_negative CHECK (price >= 0);
+    END IF;
+END $$;
@@ -0,0 +1,23 @@
+-- Give the products -> categories foreign key a predictable name and make it
+-- null out category_id when a category row is removed. The sql/ scripts create
+-- it unnamed, and older AutoMigrate runs created it without ON DELETE SET NULL,
+-- so any other form of the key is dropped first.
+DO $$
+DECLARE
+    fk RECORD;
+BEGIN
+    FOR fk IN
+        SELECT conname FROM pg_constraint
+        WHERE conrelid = 'products'::regclass
+          AND confrelid = 'categories'::regclass
+          AND contype = 'f'
+          AND NOT (conname = 'fk_products_category' AND confdeltype = 'n')
+    LOOP
+        EXECUTE format('ALTER TABLE products DROP CONSTRAINT %I', fk.conname);
+    END LOOP;
+
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'products'::regclass AND conname = 'fk_products_category') THEN
+        ALTER TABLE products ADD CONSTRAINT fk_products_category
+            FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL;
+    END IF;
+END $$;
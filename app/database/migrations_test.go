@@ -0,0 +1,187 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMockDB returns a GORM connection backed by sqlmock.
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:               logger.Discard,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	return db, mock
+}
+
+func expectVersionTable(mock sqlmock.Sqlmock, applied ...string) {
+	mock.ExpectExec(`^CREATE TABLE IF NOT EXISTS schema_migrations`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, version := range applied {
+		rows.AddRow(version)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "version" FROM "schema_migrations"`)).WillReturnRows(rows)
+}
+
+func expectHasTable(mock sqlmock.Sqlmock, exists bool) {
+	count := 0
+	if exists {
+		count = 1
+	}
+	mock.ExpectQuery(`SELECT count\(\*\) FROM information_schema.tables`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+// expectIndexState expects a validity lookup for an index; no states means it does not exist.
+func expectIndexState(mock sqlmock.Sqlmock, name string, states ...bool) {
+	rows := sqlmock.NewRows([]string{"indisvalid"})
+	for _, valid := range states {
+		rows.AddRow(valid)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT i.indisvalid FROM pg_index i")).
+		WithArgs(name).
+		WillReturnRows(rows)
+}
+
+func TestMigrate_AppliesPendingMigrationsOnce(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	// First run: the index is created and the version recorded.
+	expectVersionTable(mock)
+	expectHasTable(mock, true)
+	expectIndexState(mock, "idx_products_code")
+	mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_products_code ON products(code)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectIndexState(mock, "idx_products_code", true)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING")).
+		WithArgs("001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectHasTable(mock, true)
+	mock.ExpectExec(`ALTER TABLE categories ADD CONSTRAINT chk_categories_code_not_blank`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING")).
+		WithArgs("002").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectHasTable(mock, true)
+	expectIndexState(mock, "idx_product_variants_product_sku")
+	mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_product_variants_product_sku ON product_variants(product_id, sku)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectIndexState(mock, "idx_product_variants_product_sku", true)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING")).
+		WithArgs("003").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectHasTable(mock, true)
+	mock.ExpectExec(`ALTER TABLE products ADD CONSTRAINT chk_products_price_non_negative`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING")).
+		WithArgs("004").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectHasTable(mock, true)
+	mock.ExpectExec(`ALTER TABLE products ADD CONSTRAINT fk_products_category`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING")).
+		WithArgs("005").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Second run: nothing left to apply.
+	expectVersionTable(mock, "001", "002", "003", "004", "005")
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("first run: unexpected error: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("second run: unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestMigrate_DefersMigrationUntilTableExists(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	expectVersionTable(mock)
+	expectHasTable(mock, false)
+	expectHasTable(mock, false)
+	expectHasTable(mock, false)
+	expectHasTable(mock, false)
+	expectHasTable(mock, false)
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestMigrate_RebuildsInvalidIndex(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	expectVersionTable(mock, "002", "003", "004", "005")
+	expectHasTable(mock, true)
+	expectIndexState(mock, "idx_products_code", false)
+	mock.ExpectExec(regexp.QuoteMeta("DROP INDEX CONCURRENTLY IF EXISTS idx_products_code")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_products_code ON products(code)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectIndexState(mock, "idx_products_code", true)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING")).
+		WithArgs("001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestMigrate_FailsWhenIndexStaysInvalid(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	expectVersionTable(mock, "002", "003", "004", "005")
+	expectHasTable(mock, true)
+	expectIndexState(mock, "idx_products_code", false)
+	mock.ExpectExec(regexp.QuoteMeta("DROP INDEX CONCURRENTLY IF EXISTS idx_products_code")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_products_code ON products(code)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectIndexState(mock, "idx_products_code", false)
+
+	err := Migrate(db)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "idx_products_code") {
+		t.Errorf("expected the error to name the index, got %v", err)
+	}
+
+	// The version must not be recorded, so the next start retries the build.
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
@@ -2,20 +2,28 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// New creates a new PostgreSQL database connection and returns a cleanup function.
-// Returns an error if the connection fails, allowing the caller to handle it appropriately.
-func New(user, password, dbname, port string) (db *gorm.DB, close func() error, err error) {
-	dsn := fmt.Sprintf("postgres://%s:%s@localhost:%s/%s?sslmode=disable", user, password, port, dbname)
+// New creates a new PostgreSQL database connection, applies pending migrations
+// (see Migrate) and returns a cleanup function.
+// Returns an error if the connection or a migration fails, allowing the caller to handle it appropriately.
+//
+// The host may be a hostname, an IP address, or the directory containing the
+// Postgres Unix socket (e.g. "/var/run/postgresql"). A host starting with "/"
+// is treated as a socket directory and passed via the host query parameter.
+func New(user, password, host, dbname, port string) (db *gorm.DB, close func() error, err error) {
+	dsn := DSN(user, password, host, dbname, port)
 
 	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect database: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect database (%s): %w", RedactDSN(dsn), err)
 	}
 
 	sqlDB, err := db.DB()
@@ -23,5 +31,41 @@ func New(user, password, dbname, port string) (db *gorm.DB, close func() error,
 		return nil, nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
+	if err := Migrate(db); err != nil {
+		sqlDB.Close()
+		return nil, nil, err
+	}
+
 	return db, sqlDB.Close, nil
 }
+
+// DSN returns the connection URL New connects with. See New for the accepted hosts.
+func DSN(user, password, host, dbname, port string) string {
+	if strings.HasPrefix(host, "/") {
+		return fmt.Sprintf("postgres://%s:%s@/%s?host=%s&port=%s&sslmode=disable", user, password, dbname, host, port)
+	}
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", user, password, host, port, dbname)
+}
+
+// dsnPasswordPattern matches the credentials of a connection URL. The password
+// match runs up to the last "@", as passwords are not escaped and may contain one.
+var dsnPasswordPattern = regexp.MustCompile(`postgres://([^:]+):.*@`)
+
+// RedactDSN replaces the password of a connection URL with "***", so the URL
+// can be logged or reported in errors.
+func RedactDSN(dsn string) string {
+	return dsnPasswordPattern.ReplaceAllString(dsn, "postgres://$1:***@")
+}
+
+// Ping checks that the database behind db is reachable and accepts connections.
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Package events provides a publish/subscribe bus for domain events, so that
+// services can announce changes without knowing about their side effects.
+package events
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+)
+
+// Event names.
+const (
+	ProductCreated  = "product.created"
+	ProductUpdated  = "product.updated"
+	CategoryCreated = "category.created"
+	CategoryUpdated = "category.updated"
+	CategoryDeleted = "category.deleted"
+)
+
+// Event is a domain event. Payload describes the affected entity.
+type Event struct {
+	Name    string
+	Payload any
+}
+
+// Bus delivers published events to the handlers subscribed to their name.
+type Bus interface {
+	Publish(ctx context.Context, event Event) error
+	Subscribe(name string, handler func(Event))
+}
+
+// InMemoryBus is a Bus delivering events within the process.
+type InMemoryBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]func(Event)
+}
+
+// NewInMemoryBus creates a new InMemoryBus instance.
+func NewInMemoryBus() *InMemoryBus {
+	return &InMemoryBus{handlers: make(map[string][]func(Event))}
+}
+
+// Subscribe registers handler for the events named name.
+func (b *InMemoryBus) Subscribe(name string, handler func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish runs every handler subscribed to the event in its own goroutine and
+// waits for them to return, so side effects such as cache invalidation are
+// done when Publish returns. Handlers doing slow work, like network calls,
+// should hand it off instead of blocking the publisher.
+// A panicking handler is logged and does not affect the others. Returns
+// ctx.Err() if ctx is done first; the remaining handlers still run.
+func (b *InMemoryBus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	handlers := slices.Clone(b.handlers[event.Name])
+	b.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, handler := range handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error("Event handler panicked", "event", event.Name, "panic", r)
+				}
+			}()
+			handler(event)
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInMemoryBus_DeliversToSubscribers(t *testing.T) {
+	bus := NewInMemoryBus()
+
+	var mu sync.Mutex
+	var received []string
+	record := func(prefix string) func(Event) {
+		return func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, prefix+":"+e.Payload.(string))
+		}
+	}
+	bus.Subscribe(ProductCreated, record("a"))
+	bus.Subscribe(ProductCreated, record("b"))
+	bus.Subscribe(ProductUpdated, record("c"))
+
+	if err := bus.Publish(context.Background(), Event{Name: ProductCreated, Payload: "PROD001"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Publish waits for the handlers, so no further synchronisation is needed.
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 {
+		t.Fatalf("expected both product.created handlers to run, got %v", received)
+	}
+	for _, want := range []string{"a:PROD001", "b:PROD001"} {
+		found := false
+		for _, got := range received {
+			found = found || got == want
+		}
+		if !found {
+			t.Errorf("expected %s in %v", want, received)
+		}
+	}
+}
+
+func TestInMemoryBus_NoSubscribers(t *testing.T) {
+	bus := NewInMemoryBus()
+
+	if err := bus.Publish(context.Background(), Event{Name: CategoryDeleted}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestInMemoryBus_HandlersRunConcurrently(t *testing.T) {
+	bus := NewInMemoryBus()
+
+	// Each handler waits for the other, which only completes if they run in
+	// separate goroutines.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	handler := func(Event) {
+		wg.Done()
+		wg.Wait()
+	}
+	bus.Subscribe(CategoryCreated, handler)
+	bus.Subscribe(CategoryCreated, handler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bus.Publish(ctx, Event{Name: CategoryCreated}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestInMemoryBus_PanickingHandler(t *testing.T) {
+	bus := NewInMemoryBus()
+
+	ran := false
+	bus.Subscribe(ProductUpdated, func(Event) { panic("boom") })
+	bus.Subscribe(ProductUpdated, func(Event) { ran = true })
+
+	if err := bus.Publish(context.Background(), Event{Name: ProductUpdated}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Error("expected the other handler to run")
+	}
+}
+
+func TestInMemoryBus_ContextDone(t *testing.T) {
+	bus := NewInMemoryBus()
+
+	release := make(chan struct{})
+	defer close(release)
+	bus.Subscribe(ProductUpdated, func(Event) { <-release })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := bus.Publish(ctx, Event{Name: ProductUpdated}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
+// ContextWithRequestID returns a copy of ctx carrying the given request ID.
+//
+// The request ID is stored here rather than in the middleware package so that
+// the logger can read it without importing middleware, which depends on logger.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext retrieves the request ID from context.
+// Returns an empty string if no request ID is set.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if reqID, ok := ctx.Value(requestIDKey).(string); ok {
+		return reqID
+	}
+	return ""
+}
+
+// ContextHandler is a slog.Handler that adds the request ID to every record.
+// The request ID is taken from the context passed to the log call or, if that
+// has none, from the context the handler was bound to by WithContext.
+type ContextHandler struct {
+	slog.Handler
+	ctx context.Context
+}
+
+// NewContextHandler wraps h so that records carry the request ID of their context.
+func NewContextHandler(h slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: h}
+}
+
+// Handle adds the request_id attribute to r, if known, and passes it on.
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = RequestIDFromContext(h.ctx)
+	}
+	if requestID != "" {
+		r.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a ContextHandler whose underlying handler has the given attributes.
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs), ctx: h.ctx}
+}
+
+// WithGroup returns a ContextHandler whose underlying handler has the given group.
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name), ctx: h.ctx}
+}
+
+// WithContext returns a logger that adds the request ID of ctx to every entry.
+func WithContext(ctx context.Context) *slog.Logger {
+	h := Get().Handler()
+	if ch, ok := h.(*ContextHandler); ok {
+		h = ch.Handler
+	}
+	return slog.New(&ContextHandler{Handler: h, ctx: ctx})
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLogs replaces the default logger with one writing JSON to the returned buffer.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	previous := defaultLogger
+	t.Cleanup(func() { defaultLogger = previous })
+
+	var buf bytes.Buffer
+	defaultLogger = slog.New(NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+	return &buf
+}
+
+func decodeEntry(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry
+}
+
+func TestWithContext(t *testing.T) {
+	t.Run("adds request ID to entries", func(t *testing.T) {
+		buf := captureLogs(t)
+		ctx := ContextWithRequestID(context.Background(), "req-123")
+
+		WithContext(ctx).Info("hello", slog.String("key", "value"))
+
+		entry := decodeEntry(t, buf)
+		assert.Equal(t, "req-123", entry["request_id"])
+		assert.Equal(t, "value", entry["key"])
+	})
+
+	t.Run("keeps request ID on derived loggers", func(t *testing.T) {
+		buf := captureLogs(t)
+		ctx := ContextWithRequestID(context.Background(), "req-456")
+
+		WithContext(ctx).With("component", "test").Warn("derived")
+
+		entry := decodeEntry(t, buf)
+		assert.Equal(t, "req-456", entry["request_id"])
+		assert.Equal(t, "test", entry["component"])
+	})
+
+	t.Run("omits request ID when context has none", func(t *testing.T) {
+		buf := captureLogs(t)
+
+		WithContext(context.Background()).Info("no id")
+
+		entry := decodeEntry(t, buf)
+		assert.NotContains(t, entry, "request_id")
+	})
+}
+
+func TestContextHandler(t *testing.T) {
+	t.Run("uses request ID of log call context", func(t *testing.T) {
+		buf := captureLogs(t)
+		ctx := ContextWithRequestID(context.Background(), "req-789")
+
+		Get().InfoContext(ctx, "context call")
+
+		entry := decodeEntry(t, buf)
+		assert.Equal(t, "req-789", entry["request_id"])
+	})
+
+	t.Run("log call context takes precedence over bound context", func(t *testing.T) {
+		buf := captureLogs(t)
+		bound := ContextWithRequestID(context.Background(), "bound")
+		call := ContextWithRequestID(context.Background(), "call")
+
+		WithContext(bound).InfoContext(call, "precedence")
+
+		assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`"request_id"`)))
+		entry := decodeEntry(t, buf)
+		assert.Equal(t, "call", entry["request_id"])
+	})
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	assert.Equal(t, "", RequestIDFromContext(context.Background()))
+	assert.Equal(t, "abc", RequestIDFromContext(ContextWithRequestID(context.Background(), "abc")))
+}
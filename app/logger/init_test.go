@@ -0,0 +1,110 @@
+//go:build test
+
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit_OnlyFirstCallApplies(t *testing.T) {
+	previousLevel := Level()
+	Reset()
+	t.Cleanup(func() {
+		Reset()
+		SetLevel(previousLevel)
+	})
+
+	Init("production")
+	first := Get()
+	assert.IsType(t, &slog.JSONHandler{}, first.Handler().(*ContextHandler).Handler)
+	assert.Equal(t, slog.LevelInfo, Level())
+
+	Init("development")
+	assert.Same(t, first, Get(), "second Init must not replace the logger")
+	assert.Equal(t, slog.LevelInfo, Level(), "second Init must not change the level")
+}
+
+func TestReset_AllowsReinitialization(t *testing.T) {
+	previousLevel := Level()
+	Reset()
+	t.Cleanup(func() {
+		Reset()
+		SetLevel(previousLevel)
+	})
+
+	Init("production")
+	first := Get()
+
+	Reset()
+	Init("development")
+
+	assert.NotSame(t, first, Get())
+	assert.IsType(t, &slog.TextHandler{}, Get().Handler().(*ContextHandler).Handler)
+	assert.Equal(t, slog.LevelDebug, Level())
+}
+
+func TestInit_MultipleWriters(t *testing.T) {
+	previousLevel := Level()
+	Reset()
+	t.Cleanup(func() {
+		Reset()
+		SetLevel(previousLevel)
+	})
+
+	var stdout bytes.Buffer
+	path := filepath.Join(t.TempDir(), "app.log")
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+
+	Init("production", &stdout, file)
+	Info("written twice", slog.String("key", "value"))
+	require.NoError(t, file.Close())
+
+	fromFile, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	for name, output := range map[string]string{"stdout": stdout.String(), "file": string(fromFile)} {
+		assert.Contains(t, output, `"msg":"written twice"`, name)
+		assert.Contains(t, output, `"key":"value"`, name)
+	}
+}
+
+func TestGet_ConcurrentUseBeforeInit(t *testing.T) {
+	previousLevel, previousStdout := Level(), os.Stdout
+	Reset()
+	t.Cleanup(func() {
+		os.Stdout = previousStdout
+		Reset()
+		SetLevel(previousLevel)
+	})
+
+	// The first Get initializes the logger on os.Stdout; keep it out of the test output.
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { devNull.Close() })
+	os.Stdout = devNull
+
+	var wg sync.WaitGroup
+	loggers := make([]*slog.Logger, 100)
+	for i := range loggers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Info("concurrent log", "goroutine", i)
+			loggers[i] = Get()
+		}()
+	}
+	wg.Wait()
+
+	for _, l := range loggers {
+		assert.Same(t, loggers[0], l, "all goroutines must share one logger")
+	}
+}
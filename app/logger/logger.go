@@ -2,37 +2,119 @@
 package logger
 
 import (
+	"io"
 	"log/slog"
 	"os"
+	"sync"
+	"sync/atomic"
 )
 
-var defaultLogger *slog.Logger
+// loggerMu guards defaultLogger, which Init writes while other goroutines may
+// already be logging through Get.
+var (
+	loggerMu      sync.RWMutex
+	defaultLogger *slog.Logger
+)
+
+// initMu guards initOnce so that Reset can replace it safely.
+var (
+	initMu   sync.Mutex
+	initOnce sync.Once
+)
+
+// level is the minimum level of the default logger. It is shared by the
+// handler so that it can be changed at runtime via SetLevel.
+var level atomicLevel
+
+// atomicLevel is a slog.Leveler whose level can be changed concurrently.
+type atomicLevel struct {
+	v atomic.Int32
+}
+
+// Level implements slog.Leveler.
+func (l *atomicLevel) Level() slog.Level {
+	return slog.Level(l.v.Load())
+}
+
+func (l *atomicLevel) set(lvl slog.Level) {
+	l.v.Store(int32(lvl))
+}
 
 // Init initializes the default structured logger.
-func Init(env string) {
+// Logs are written to os.Stdout, or to all of the given writers if any are passed.
+//
+// Only the first call has an effect; later calls are no-ops, so the logger
+// configured at startup can't be replaced by accident, e.g. from a test
+// helper. Tests built with the "test" tag can call Reset to start over.
+func Init(env string, writers ...io.Writer) {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	initOnce.Do(func() { initLogger(env, writers) })
+}
+
+func initLogger(env string, writers []io.Writer) {
+	var out io.Writer
+	switch len(writers) {
+	case 0:
+		out = os.Stdout
+	case 1:
+		out = writers[0]
+	default:
+		out = io.MultiWriter(writers...)
+	}
+
 	var handler slog.Handler
 
 	if env == "production" {
 		// JSON format for production
-		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
+		level.set(slog.LevelInfo)
+		handler = slog.NewJSONHandler(out, &slog.HandlerOptions{
+			Level: &level,
 		})
 	} else {
 		// Text format for development
-		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
+		level.set(slog.LevelDebug)
+		handler = slog.NewTextHandler(out, &slog.HandlerOptions{
+			Level: &level,
 		})
 	}
 
-	defaultLogger = slog.New(handler)
-	slog.SetDefault(defaultLogger)
+	l := slog.New(NewContextHandler(handler))
+
+	loggerMu.Lock()
+	defaultLogger = l
+	loggerMu.Unlock()
+
+	slog.SetDefault(l)
+}
+
+// SetLevel changes the minimum level of the default logger at runtime.
+func SetLevel(lvl slog.Level) {
+	level.set(lvl)
 }
 
-// Get returns the default logger.
+// Level returns the current minimum level of the default logger.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// Get returns the default logger, initializing it for development if Init
+// has not been called yet.
 func Get() *slog.Logger {
-	if defaultLogger == nil {
-		Init("development")
+	if l := current(); l != nil {
+		return l
 	}
+
+	Init("development")
+	return current()
+}
+
+// current returns the default logger, or nil if it is not initialized.
+func current() *slog.Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+
 	return defaultLogger
 }
 
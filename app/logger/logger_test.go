@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLevel(t *testing.T) {
+	previousLogger, previousLevel := defaultLogger, Level()
+	t.Cleanup(func() {
+		defaultLogger = previousLogger
+		SetLevel(previousLevel)
+	})
+
+	var buf bytes.Buffer
+	SetLevel(slog.LevelInfo)
+	defaultLogger = slog.New(NewContextHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: &level})))
+
+	Debug("hidden")
+	assert.Empty(t, buf.String(), "debug entries must be dropped at info level")
+
+	SetLevel(slog.LevelDebug)
+	assert.Equal(t, slog.LevelDebug, Level())
+
+	Debug("visible")
+	assert.Contains(t, buf.String(), "visible")
+
+	buf.Reset()
+	SetLevel(slog.LevelError)
+	Warn("dropped")
+	Error("kept")
+	assert.NotContains(t, buf.String(), "dropped")
+	assert.Contains(t, buf.String(), "kept")
+}
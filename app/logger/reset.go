@@ -0,0 +1,18 @@
+//go:build test
+
+package logger
+
+import "sync"
+
+// Reset discards the default logger so that the next call to Init
+// configures it again. It is only available in builds with the "test" tag.
+func Reset() {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	initOnce = sync.Once{}
+
+	loggerMu.Lock()
+	defaultLogger = nil
+	loggerMu.Unlock()
+}
@@ -0,0 +1,14 @@
+// Package mathutil provides small integer helpers shared across packages.
+package mathutil
+
+// Clamp constrains value to the range [min, max].
+// If min is greater than max, min is returned.
+func Clamp(value, min, max int) int {
+	if value < min || min > max {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,37 @@
+package mathutil
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    int
+		min      int
+		max      int
+		expected int
+	}{
+		{name: "within range", value: 5, min: 1, max: 10, expected: 5},
+		{name: "below min", value: -3, min: 1, max: 10, expected: 1},
+		{name: "above max", value: 42, min: 1, max: 10, expected: 10},
+		{name: "equal to min", value: 1, min: 1, max: 10, expected: 1},
+		{name: "equal to max", value: 10, min: 1, max: 10, expected: 10},
+		{name: "min equals max", value: 7, min: 3, max: 3, expected: 3},
+		{name: "min greater than max", value: 5, min: 10, max: 1, expected: 10},
+		{name: "min greater than max with value above both", value: 50, min: 10, max: 1, expected: 10},
+		{name: "min greater than max with value below both", value: -50, min: 10, max: 1, expected: 10},
+		{name: "math.MinInt", value: math.MinInt, min: 1, max: 100, expected: 1},
+		{name: "math.MaxInt", value: math.MaxInt, min: 1, max: 100, expected: 100},
+		{name: "full int range", value: 0, min: math.MinInt, max: math.MaxInt, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Clamp(tt.value, tt.min, tt.max); got != tt.expected {
+				t.Errorf("Clamp(%d, %d, %d) = %d, expected %d", tt.value, tt.min, tt.max, got, tt.expected)
+			}
+		})
+	}
+}
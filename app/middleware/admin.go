@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// AdminTokenHeader is the header carrying the admin token.
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminToken returns a middleware that requires the AdminTokenHeader to match token.
+// It is independent of JWTAuth and guards operational endpoints.
+// Requests with a missing or wrong token are rejected with 401 Unauthorized.
+func AdminToken(token string) func(http.Handler) http.Handler {
+	expected := []byte(token)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := []byte(r.Header.Get(AdminTokenHeader))
+			if len(expected) == 0 || subtle.ConstantTimeCompare(provided, expected) != 1 {
+				writeUnauthorized(w, r, "")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
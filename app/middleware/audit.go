@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+// maxAuditBodyBytes is the maximum number of request body bytes stored per audit entry.
+const maxAuditBodyBytes = 64 << 10
+
+const auditActorKey contextKey = "audit_actor"
+
+// AuditStore persists audit log entries.
+type AuditStore interface {
+	Append(ctx context.Context, entry *models.AuditLog) error
+}
+
+// AuditLogger returns a middleware that records every non-read request
+// (anything other than GET, HEAD and OPTIONS) in the given store.
+// It must wrap JWTAuth so that the authenticated subject can be recorded;
+// requests without a subject are recorded as "anonymous".
+func AuditLogger(store AuditStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Capture the start of the body and hand the full stream back to the handler.
+			var body []byte
+			if r.Body != nil {
+				var err error
+				body, err = io.ReadAll(io.LimitReader(r.Body, maxAuditBodyBytes))
+				if err != nil {
+					logger.Warn("Failed to read request body for audit",
+						slog.String("request_id", GetRequestID(r.Context())),
+						slog.String("error", err.Error()),
+					)
+				}
+				r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+			}
+
+			// JWTAuth fills this slot once the token is validated further down the chain.
+			var actor string
+			r = r.WithContext(context.WithValue(r.Context(), auditActorKey, &actor))
+
+			rw := newResponseWriter(w)
+			next.ServeHTTP(rw, r)
+
+			if actor == "" {
+				actor = "anonymous"
+			}
+
+			entry := &models.AuditLog{
+				RequestID:   GetRequestID(r.Context()),
+				Method:      r.Method,
+				Path:        r.URL.Path,
+				StatusCode:  rw.statusCode,
+				ActorID:     actor,
+				Timestamp:   time.Now().UTC(),
+				RequestBody: body,
+			}
+
+			// The entry must be stored even if the client has already gone away.
+			if err := store.Append(context.WithoutCancel(r.Context()), entry); err != nil {
+				logger.Error("Failed to persist audit log",
+					slog.String("request_id", entry.RequestID),
+					slog.String("error", err.Error()),
+				)
+			}
+		})
+	}
+}
+
+// setAuditActor records the authenticated actor for an enclosing AuditLogger, if any.
+func setAuditActor(ctx context.Context, actor string) {
+	if slot, ok := ctx.Value(auditActorKey).(*string); ok {
+		*slot = actor
+	}
+}
+
+// readCloser combines a replacement reader with the original body's Close.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
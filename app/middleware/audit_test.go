@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuditStore records appended entries in memory.
+type fakeAuditStore struct {
+	entries []*models.AuditLog
+}
+
+func (f *fakeAuditStore) Append(ctx context.Context, entry *models.AuditLog) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func TestAuditLogger(t *testing.T) {
+	t.Run("skips read requests", func(t *testing.T) {
+		store := &fakeAuditStore{}
+		handler := AuditLogger(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/categories", nil))
+
+		assert.Empty(t, store.entries)
+	})
+
+	t.Run("records mutation with body and status", func(t *testing.T) {
+		store := &fakeAuditStore{}
+		var handlerBody string
+		handler := AuditLogger(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			handlerBody = string(b)
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", strings.NewReader(`{"code":"SHOES"}`))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.Len(t, store.entries, 1)
+		entry := store.entries[0]
+		assert.Equal(t, http.MethodPost, entry.Method)
+		assert.Equal(t, "/v1/categories", entry.Path)
+		assert.Equal(t, http.StatusCreated, entry.StatusCode)
+		assert.Equal(t, "anonymous", entry.ActorID)
+		assert.Equal(t, `{"code":"SHOES"}`, string(entry.RequestBody))
+		assert.Equal(t, `{"code":"SHOES"}`, handlerBody, "handler must still see the full body")
+	})
+
+	t.Run("truncates stored body but not handler body", func(t *testing.T) {
+		store := &fakeAuditStore{}
+		var handlerBytes int
+		handler := AuditLogger(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			handlerBytes = len(b)
+		}))
+
+		large := strings.Repeat("a", maxAuditBodyBytes+100)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/categories", strings.NewReader(large)))
+
+		require.Len(t, store.entries, 1)
+		assert.Len(t, store.entries[0].RequestBody, maxAuditBodyBytes)
+		assert.Equal(t, len(large), handlerBytes)
+	})
+
+	t.Run("records JWT subject as actor", func(t *testing.T) {
+		store := &fakeAuditStore{}
+		handler := AuditLogger(store)(JWTAuth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})))
+
+		token := signToken(t, testSecret, jwt.MapClaims{
+			"sub": "user-42",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		req := httptest.NewRequest(http.MethodDelete, "/v1/categories/SHOES", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.Len(t, store.entries, 1)
+		assert.Equal(t, "user-42", store.entries[0].ActorID)
+		assert.Equal(t, http.StatusNoContent, store.entries[0].StatusCode)
+	})
+
+	t.Run("records rejected requests as anonymous", func(t *testing.T) {
+		store := &fakeAuditStore{}
+		handler := AuditLogger(store)(JWTAuth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/v1/categories/SHOES", nil))
+
+		require.Len(t, store.entries, 1)
+		assert.Equal(t, "anonymous", store.entries[0].ActorID)
+		assert.Equal(t, http.StatusUnauthorized, store.entries[0].StatusCode)
+	})
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+)
+
+const subjectKey contextKey = "subject"
+
+// JWTAuth returns a middleware that requires a valid HMAC-signed JWT in the
+// Authorization header using the Bearer scheme.
+// Tokens must carry an exp claim, so a leaked token cannot be used forever.
+// Requests without a valid token are rejected with 401 Unauthorized.
+func JWTAuth(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString, ok := bearerToken(r.Header.Get("Authorization"))
+			if !ok {
+				writeUnauthorized(w, r, "Bearer")
+				return
+			}
+
+			token, err := jwt.Parse(tokenString, func(t *jwt.Token) (any, error) {
+				return secret, nil
+			}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}), jwt.WithExpirationRequired())
+			if err != nil || !token.Valid {
+				logger.Debug("Rejected JWT",
+					slog.String("request_id", GetRequestID(r.Context())),
+					slog.Any("error", err),
+				)
+				writeUnauthorized(w, r, "Bearer")
+				return
+			}
+
+			// Expose the token subject to downstream handlers.
+			if subject, err := token.Claims.GetSubject(); err == nil && subject != "" {
+				setAuditActor(r.Context(), subject)
+				r = r.WithContext(context.WithValue(r.Context(), subjectKey, subject))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GetSubject retrieves the authenticated JWT subject from context.
+func GetSubject(ctx context.Context) string {
+	if subject, ok := ctx.Value(subjectKey).(string); ok {
+		return subject
+	}
+	return ""
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header value.
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// writeUnauthorized writes a 401 response. The WWW-Authenticate header is
+// set only when scheme is not empty.
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, scheme string) {
+	w.Header().Set("Content-Type", "application/json")
+	if scheme != "" {
+		w.Header().Set("WWW-Authenticate", scheme)
+	}
+	w.WriteHeader(http.StatusUnauthorized)
+	if _, err := w.Write([]byte(`{"code":"unauthorized","message":"authentication required"}`)); err != nil {
+		logger.Error("Failed to write unauthorized response",
+			slog.String("request_id", GetRequestID(r.Context())),
+			slog.String("error", err.Error()),
+		)
+	}
+}
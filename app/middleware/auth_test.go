@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecret = []byte("test-secret")
+
+func signToken(t *testing.T, secret []byte, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestJWTAuth(t *testing.T) {
+	var gotSubject string
+	handler := JWTAuth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSubject = GetSubject(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	unauthorizedBody := `{"code":"unauthorized","message":"authentication required"}`
+
+	t.Run("missing token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, unauthorizedBody, recorder.Body.String())
+	})
+
+	t.Run("non-bearer scheme", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, unauthorizedBody, recorder.Body.String())
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+		req.Header.Set("Authorization", "Bearer not-a-jwt")
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, unauthorizedBody, recorder.Body.String())
+	})
+
+	t.Run("wrong signing secret", func(t *testing.T) {
+		token := signToken(t, []byte("other-secret"), jwt.MapClaims{
+			"sub": "user-1",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		token := signToken(t, testSecret, jwt.MapClaims{
+			"sub": "user-1",
+			"exp": time.Now().Add(-time.Hour).Unix(),
+		})
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, unauthorizedBody, recorder.Body.String())
+	})
+
+	t.Run("token without expiration", func(t *testing.T) {
+		token := signToken(t, testSecret, jwt.MapClaims{"sub": "user-1"})
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.JSONEq(t, unauthorizedBody, recorder.Body.String())
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		gotSubject = ""
+		token := signToken(t, testSecret, jwt.MapClaims{
+			"sub": "user-1",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "user-1", gotSubject)
+	})
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import "net/http"
+
+// Chain composes middlewares into a single middleware.
+// Middlewares run in the order given: the first argument is the outermost
+// handler and sees the request first.
+func Chain(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(final http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			final = middlewares[i](final)
+		}
+		return final
+	}
+}
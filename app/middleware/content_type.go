@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+)
+
+// RequireContentType returns a middleware that rejects requests whose Content-Type
+// media type is not ct with 415 Unsupported Media Type. Parameters such as
+// charset are ignored, and media types are compared case-insensitively.
+func RequireContentType(ct string) func(http.Handler) http.Handler {
+	message := "Content-Type must be " + ct
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || !strings.EqualFold(mediaType, ct) {
+				writeUnsupportedMediaType(w, r, message)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeUnsupportedMediaType(w http.ResponseWriter, r *http.Request, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnsupportedMediaType)
+	body := map[string]string{"code": "unsupported_media_type", "message": message}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("Failed to write unsupported media type response",
+			slog.String("request_id", GetRequestID(r.Context())),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireContentType(t *testing.T) {
+	handler := RequireContentType("application/json")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	tests := []struct {
+		name           string
+		contentType    string
+		expectedStatus int
+	}{
+		{name: "exact match", contentType: "application/json", expectedStatus: http.StatusCreated},
+		{name: "with charset", contentType: "application/json; charset=utf-8", expectedStatus: http.StatusCreated},
+		{name: "different case", contentType: "Application/JSON", expectedStatus: http.StatusCreated},
+		{name: "plain text", contentType: "text/plain", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "form encoded", contentType: "application/x-www-form-urlencoded", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "similar prefix", contentType: "application/jsonp", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "missing", contentType: "", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed", contentType: ";;", expectedStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/categories", nil)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnsupportedMediaType {
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+				assert.JSONEq(t, `{"code":"unsupported_media_type","message":"Content-Type must be application/json"}`, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import "io"
+
+// countingReader wraps a request body and counts the bytes read from it.
+// Only what the handler actually reads is counted, not the declared Content-Length.
+type countingReader struct {
+	io.ReadCloser
+	read int64
+}
+
+func newCountingReader(body io.ReadCloser) *countingReader {
+	return &countingReader{ReadCloser: body}
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.ReadCloser.Read(p)
+	cr.read += int64(n)
+	return n, err
+}
+
+// BytesRead returns the number of bytes read so far.
+func (cr *countingReader) BytesRead() int64 {
+	return cr.read
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountingReader(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "empty", body: ""},
+		{name: "small", body: `{"code":"BAGS","name":"Bags"}`},
+		{name: "large", body: strings.Repeat("x", 1<<20+3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := newCountingReader(io.NopCloser(strings.NewReader(tt.body)))
+
+			data, err := io.ReadAll(reader)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(data))
+			assert.Equal(t, int64(len(tt.body)), reader.BytesRead())
+		})
+	}
+}
+
+func TestCountingReader_CountsOnlyWhatIsRead(t *testing.T) {
+	reader := newCountingReader(io.NopCloser(bytes.NewReader(make([]byte, 100))))
+
+	_, err := io.ReadFull(reader, make([]byte, 10))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), reader.BytesRead())
+}
@@ -6,16 +6,26 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mytheresa/go-hiring-challenge/app/logger"
 )
 
+// ResponseTimeHeader is the response header in which Logger reports how long
+// the server took to produce the response, e.g. "12.345ms".
+const ResponseTimeHeader = "X-Response-Time"
+
 // responseWriter wraps http.ResponseWriter to capture status code.
+// If start is set, the time elapsed since start is sent in ResponseTimeHeader.
 type responseWriter struct {
 	http.ResponseWriter
-	statusCode int
-	written    int64
+	statusCode  int
+	written     int64
+	start       time.Time
+	wroteHeader bool
 }
 
 func newResponseWriter(w http.ResponseWriter) *responseWriter {
@@ -27,10 +37,27 @@ func newResponseWriter(w http.ResponseWriter) *responseWriter {
 
 func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
+	if !rw.wroteHeader {
+		rw.wroteHeader = true
+		rw.setResponseTime()
+	}
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// setResponseTime sets ResponseTimeHeader if timing is enabled. Headers cannot
+// change once written, so it runs at the moment the header is written.
+func (rw *responseWriter) setResponseTime() {
+	if rw.start.IsZero() {
+		return
+	}
+	elapsed := float64(time.Since(rw.start)) / float64(time.Millisecond)
+	rw.Header().Set(ResponseTimeHeader, strconv.FormatFloat(elapsed, 'f', 3, 64)+"ms")
+}
+
 func (rw *responseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
 	n, err := rw.ResponseWriter.Write(b)
 	rw.written += int64(n)
 	return n, err
@@ -45,8 +72,12 @@ func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return hijacker.Hijack()
 }
 
-// Flush implements http.Flusher interface.
+// Flush implements http.Flusher interface. Flushing commits the header, so it
+// is written first as for Write.
 func (rw *responseWriter) Flush() {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
 	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
 		flusher.Flush()
 	}
@@ -62,6 +93,9 @@ func (rw *responseWriter) Push(target string, opts *http.PushOptions) error {
 
 // ReadFrom implements io.ReaderFrom interface for efficient copying.
 func (rw *responseWriter) ReadFrom(r io.Reader) (n int64, err error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
 	if rf, ok := rw.ResponseWriter.(io.ReaderFrom); ok {
 		n, err = rf.ReadFrom(r)
 		rw.written += n
@@ -72,31 +106,117 @@ func (rw *responseWriter) ReadFrom(r io.Reader) (n int64, err error) {
 	return n, err
 }
 
+// DefaultRedactedParams are the query parameters whose values Logger hides.
+var DefaultRedactedParams = []string{"api_key", "token", "password"}
+
+// redactedValue replaces the value of a redacted query parameter in logs.
+const redactedValue = "[REDACTED]"
+
 // Logger is a middleware that logs HTTP requests with structured logging.
+// Each entry carries both the matched route pattern and the actual path.
+// Values of DefaultRedactedParams are redacted from the logged query string.
+// The server-side duration of each request is also sent in ResponseTimeHeader.
+// bytes is the size of the response body and request_bytes the number of
+// request body bytes the handler read.
+// Requests are logged at INFO, or at WARN for 4xx and ERROR for 5xx responses.
 func Logger(next http.Handler) http.Handler {
+	return Redact(DefaultRedactedParams...)(next)
+}
+
+// Redact returns a Logger middleware that replaces the values of the given
+// query parameters (matched case-insensitively) with [REDACTED] in the logged
+// query string. The request itself is left untouched.
+func Redact(params ...string) func(http.Handler) http.Handler {
+	redacted := make(map[string]struct{}, len(params))
+	for _, p := range params {
+		redacted[strings.ToLower(p)] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return logRequests(next, redacted)
+	}
+}
+
+func logRequests(next http.Handler, redacted map[string]struct{}) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
-		// Wrap response writer to capture status code
+		// Wrap response writer to capture status code and report the response time
 		rw := newResponseWriter(w)
+		rw.start = start
+
+		// Count the request body as the handler reads it. The body is replaced
+		// on r itself, since the mux records the matched pattern on r.
+		var body *countingReader
+		if r.Body != nil {
+			body = newCountingReader(r.Body)
+			r.Body = body
+		}
 
 		// Process request
 		next.ServeHTTP(rw, r)
 
+		// A handler that writes nothing gets an implicit 200 after returning,
+		// so the header can still be set now.
+		if !rw.wroteHeader {
+			rw.setResponseTime()
+		}
+
 		// Log request details
 		duration := time.Since(start)
 		requestID := GetRequestID(r.Context())
+		correlationID := GetCorrelationID(r.Context())
+		var requestBytes int64
+		if body != nil {
+			requestBytes = body.BytesRead()
+		}
 
-		logger.Info("HTTP request",
+		attrs := []any{
 			slog.String("request_id", requestID),
+			slog.String("correlation_id", correlationID),
 			slog.String("method", r.Method),
+			// The mux records the matched pattern on the request it is given, so
+			// middlewares between Logger and the mux must pass r on unchanged.
+			// It is empty when no pattern matched.
+			slog.String("route", r.Pattern),
 			slog.String("path", r.URL.Path),
-			slog.String("query", r.URL.RawQuery),
+			slog.String("query", redactQuery(r.URL.RawQuery, redacted)),
 			slog.Int("status", rw.statusCode),
 			slog.Duration("duration", duration),
 			slog.Int64("bytes", rw.written),
+			slog.Int64("request_bytes", requestBytes),
 			slog.String("remote_addr", r.RemoteAddr),
 			slog.String("user_agent", r.UserAgent()),
-		)
+		}
+
+		switch {
+		case rw.statusCode >= http.StatusInternalServerError:
+			logger.Error("HTTP request", attrs...)
+		case rw.statusCode >= http.StatusBadRequest:
+			logger.Warn("HTTP request", attrs...)
+		default:
+			logger.Info("HTTP request", attrs...)
+		}
 	})
 }
+
+// redactQuery returns rawQuery with the values of the redacted parameters
+// replaced. Parameter order and all other pairs are kept as sent.
+func redactQuery(rawQuery string, redacted map[string]struct{}) string {
+	if rawQuery == "" || len(redacted) == 0 {
+		return rawQuery
+	}
+
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		rawKey, _, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		if _, ok := redacted[strings.ToLower(key)]; ok {
+			pairs[i] = rawKey + "=" + redactedValue
+		}
+	}
+	return strings.Join(pairs, "&")
+}
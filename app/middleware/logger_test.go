@@ -0,0 +1,263 @@
+//go:build test
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureRequestLog serves a request through mw and returns the decoded log entry.
+func captureRequestLog(t *testing.T, mw func(http.Handler) http.Handler, req *http.Request) map[string]any {
+	t.Helper()
+
+	previousLevel := logger.Level()
+	logger.Reset()
+	t.Cleanup(func() {
+		logger.Reset()
+		logger.SetLevel(previousLevel)
+	})
+
+	var buf bytes.Buffer
+	logger.Init("production", &buf)
+
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry
+}
+
+func TestLogger_LevelByStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		level  string
+	}{
+		{status: http.StatusOK, level: "INFO"},
+		{status: http.StatusNoContent, level: "INFO"},
+		{status: http.StatusMovedPermanently, level: "INFO"},
+		{status: http.StatusNotModified, level: "INFO"},
+		{status: http.StatusBadRequest, level: "WARN"},
+		{status: http.StatusNotFound, level: "WARN"},
+		{status: 499, level: "WARN"},
+		{status: http.StatusInternalServerError, level: "ERROR"},
+		{status: http.StatusServiceUnavailable, level: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			previousLevel := logger.Level()
+			logger.Reset()
+			t.Cleanup(func() {
+				logger.Reset()
+				logger.SetLevel(previousLevel)
+			})
+
+			var buf bytes.Buffer
+			logger.Init("production", &buf)
+
+			handler := Logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/catalog", nil))
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.level, entry["level"])
+			assert.Equal(t, "HTTP request", entry["msg"])
+			assert.EqualValues(t, tt.status, entry["status"])
+		})
+	}
+}
+
+func TestLogger_LogsRoutePattern(t *testing.T) {
+	previousLevel := logger.Level()
+	logger.Reset()
+	t.Cleanup(func() {
+		logger.Reset()
+		logger.SetLevel(previousLevel)
+	})
+
+	var buf bytes.Buffer
+	logger.Init("production", &buf)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/catalog/{code}", func(w http.ResponseWriter, r *http.Request) {})
+	handler := Logger(mux)
+
+	for _, path := range []string{"/v1/catalog/PROD001", "/v1/catalog/PROD002", "/unknown"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	expected := []struct{ route, path string }{
+		{route: "GET /v1/catalog/{code}", path: "/v1/catalog/PROD001"},
+		{route: "GET /v1/catalog/{code}", path: "/v1/catalog/PROD002"},
+		{route: "", path: "/unknown"},
+	}
+	for i, line := range lines {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, expected[i].route, entry["route"])
+		assert.Equal(t, expected[i].path, entry["path"])
+	}
+}
+
+func TestLogger_RedactsDefaultParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog?api_key=secret&limit=5&token=abc&password=hunter2", nil)
+
+	entry := captureRequestLog(t, Logger, req)
+
+	assert.Equal(t, "api_key=[REDACTED]&limit=5&token=[REDACTED]&password=[REDACTED]", entry["query"])
+	assert.Equal(t, "api_key=secret&limit=5&token=abc&password=hunter2", req.URL.RawQuery, "request URL must not be mutated")
+}
+
+func TestRedact_CustomParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/catalog?Session=xyz&token=abc&category=SHOES", nil)
+
+	entry := captureRequestLog(t, Redact("session"), req)
+
+	assert.Equal(t, "Session=[REDACTED]&token=abc&category=SHOES", entry["query"])
+	assert.Equal(t, "Session=xyz&token=abc&category=SHOES", req.URL.RawQuery)
+}
+
+func TestRedactQuery(t *testing.T) {
+	redacted := map[string]struct{}{"api_key": {}, "token": {}}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"empty query", "", ""},
+		{"no sensitive params", "limit=5&offset=10", "limit=5&offset=10"},
+		{"key without value", "token&limit=5", "token=[REDACTED]&limit=5"},
+		{"repeated param", "token=a&token=b", "token=[REDACTED]&token=[REDACTED]"},
+		{"escaped key", "api%5Fkey=secret", "api%5Fkey=[REDACTED]"},
+		{"case-insensitive", "API_KEY=secret", "API_KEY=[REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactQuery(tt.query, redacted))
+		})
+	}
+}
+
+func TestLogger_LogsRequestBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		readAll  bool
+		expected float64
+	}{
+		{name: "no body", body: nil, readAll: true, expected: 0},
+		{name: "small body", body: strings.NewReader(`{"code":"BAGS","name":"Bags"}`), readAll: true, expected: 29},
+		{name: "large body", body: bytes.NewReader(make([]byte, 1<<20)), readAll: true, expected: 1 << 20},
+		{name: "body not read", body: strings.NewReader(`{"code":"BAGS"}`), readAll: false, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previousLevel := logger.Level()
+			logger.Reset()
+			t.Cleanup(func() {
+				logger.Reset()
+				logger.SetLevel(previousLevel)
+			})
+
+			var buf bytes.Buffer
+			logger.Init("production", &buf)
+
+			handler := Logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.readAll {
+					_, _ = io.Copy(io.Discard, r.Body)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/categories", tt.body))
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.expected, entry["request_bytes"])
+		})
+	}
+}
+
+func TestLogger_SetsResponseTimeHeader(t *testing.T) {
+	previousLevel := logger.Level()
+	logger.Reset()
+	t.Cleanup(func() {
+		logger.Reset()
+		logger.SetLevel(previousLevel)
+	})
+	logger.Init("production", io.Discard)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		status  int
+	}{
+		{
+			name: "explicit status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "implicit status on write",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(2 * time.Millisecond)
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "implicit status on flush",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.(http.Flusher).Flush()
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:    "nothing written",
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+			status:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/catalog", nil)
+
+			Logger(tt.handler).ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.status, recorder.Code)
+
+			// Result holds the header as it was when written, unlike the live Header map.
+			value := recorder.Result().Header.Get(ResponseTimeHeader)
+			require.True(t, strings.HasSuffix(value, "ms"), "expected a millisecond duration, got %q", value)
+			ms, err := strconv.ParseFloat(strings.TrimSuffix(value, "ms"), 64)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, ms, 0.0)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
@@ -8,9 +9,21 @@ import (
 	"github.com/mytheresa/go-hiring-challenge/app/logger"
 )
 
+// panicResponse is the body returned when a panic is recovered.
+// It mirrors api.ErrorResponseBody and adds the request ID for correlation with logs.
+type panicResponse struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
 // Recovery is a middleware that recovers from panics and logs the error.
+// A 500 response is sent unless the handler had already started its
+// response, in which case the panic is only logged: the status can no
+// longer change, and the truncated body tells the client something went wrong.
 func Recovery(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := newResponseWriter(w)
 		defer func() {
 			if err := recover(); err != nil {
 				// Log panic with stack trace
@@ -18,19 +31,28 @@ func Recovery(next http.Handler) http.Handler {
 					slog.String("request_id", GetRequestID(r.Context())),
 					slog.String("method", r.Method),
 					slog.String("path", r.URL.Path),
+					slog.Bool("response_started", rw.wroteHeader),
 					slog.Any("panic", err),
 					slog.String("stack", string(debug.Stack())),
 				)
 
+				if rw.wroteHeader {
+					return
+				}
+
 				// Return 500 Internal Server Error
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusInternalServerError)
-				if _, writeErr := w.Write([]byte(`{"code":"internal_error","message":"An internal error occurred"}`)); writeErr != nil {
+				if writeErr := json.NewEncoder(w).Encode(panicResponse{
+					Code:      "internal_error",
+					Message:   "An internal error occurred",
+					RequestID: GetRequestID(r.Context()),
+				}); writeErr != nil {
 					logger.Error("Failed to write error response after panic", slog.String("error", writeErr.Error()))
 				}
 			}
 		}()
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(rw, r)
 	})
 }
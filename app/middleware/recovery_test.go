@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecovery(t *testing.T) {
+	t.Run("includes request ID in panic response", func(t *testing.T) {
+		var contextRequestID string
+		handler := RequestID(Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contextRequestID = GetRequestID(r.Context())
+			panic("boom")
+		})))
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(RequestIDHeader, "req-panic")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "internal_error", body["code"])
+		assert.Equal(t, "An internal error occurred", body["message"])
+		assert.Equal(t, "req-panic", body["request_id"])
+		assert.Equal(t, contextRequestID, body["request_id"])
+	})
+
+	t.Run("escapes request ID in response body", func(t *testing.T) {
+		handler := RequestID(Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})))
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(RequestIDHeader, `id"with"quotes`)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, `id"with"quotes`, body["request_id"])
+	})
+
+	t.Run("writes 500 when the panic precedes WriteHeader", func(t *testing.T) {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+		w := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, 1, w.writeHeaderCalls, "expected a single WriteHeader call")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("does not write again after an explicit WriteHeader(200)", func(t *testing.T) {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			panic("boom")
+		}))
+
+		w := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, 1, w.writeHeaderCalls, "expected a single WriteHeader call")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("does not overwrite a response already started", func(t *testing.T) {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"items":[`))
+			panic("boom")
+		}))
+
+		w := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, 1, w.writeHeaderCalls, "expected a single WriteHeader call")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"items":[`, w.Body.String())
+	})
+
+	t.Run("treats a body write as a started response", func(t *testing.T) {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("partial"))
+			panic("boom")
+		}))
+
+		w := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, 1, w.writeHeaderCalls, "expected a single WriteHeader call")
+		assert.Equal(t, "partial", w.Body.String())
+	})
+
+	t.Run("passes through when no panic", func(t *testing.T) {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+}
+
+// headerCountingRecorder is an httptest.ResponseRecorder that counts WriteHeader calls.
+type headerCountingRecorder struct {
+	*httptest.ResponseRecorder
+	writeHeaderCalls int
+}
+
+func (r *headerCountingRecorder) WriteHeader(code int) {
+	r.writeHeaderCalls++
+	r.ResponseRecorder.WriteHeader(code)
+}
@@ -6,36 +6,85 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
 )
 
 type contextKey string
 
-const requestIDKey contextKey = "request_id"
+const correlationIDKey contextKey = "correlation_id"
 
-// RequestID is a middleware that adds a unique request ID to each request.
+// Header names used to propagate request identifiers.
+const (
+	RequestIDHeader     = "X-Request-ID"
+	CorrelationIDHeader = "X-Correlation-ID"
+)
+
+// maxRequestIDLength is the longest client supplied ID accepted, matching the
+// request_id column of audit_logs.
+const maxRequestIDLength = 64
+
+// RequestID is a middleware that adds a unique request ID to each request,
+// using the X-Request-ID header. See NewRequestID.
 func RequestID(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Check if request ID already exists in header
-		requestID := r.Header.Get("X-Request-ID")
-		if requestID == "" {
-			requestID = uuid.New().String()
-		}
-
-		// Add request ID to context
-		ctx := context.WithValue(r.Context(), requestIDKey, requestID)
-		r = r.WithContext(ctx)
-
-		// Add request ID to response header
-		w.Header().Set("X-Request-ID", requestID)
-
-		next.ServeHTTP(w, r)
-	})
+	return NewRequestID(RequestIDHeader)(next)
+}
+
+// NewRequestID returns a middleware that adds a unique request ID to each request,
+// read from and echoed in the given header, e.g. X-Trace-Id or CF-Ray.
+// An empty headerName falls back to X-Request-ID.
+//
+// The request ID is taken from headerName and generated when it is missing.
+// The X-Correlation-ID header, which gateways keep across the requests of one
+// operation, is stored separately, see GetCorrelationID. IDs longer than 64 bytes
+// are ignored.
+func NewRequestID(headerName string) func(http.Handler) http.Handler {
+	if headerName == "" {
+		headerName = RequestIDHeader
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := clientRequestID(r, headerName)
+			if requestID == "" {
+				requestID = uuid.New().String()
+			}
+			correlationID := clientRequestID(r, CorrelationIDHeader)
+
+			// Add request and correlation IDs to context
+			ctx := logger.ContextWithRequestID(r.Context(), requestID)
+			if correlationID != "" {
+				ctx = context.WithValue(ctx, correlationIDKey, correlationID)
+			}
+			r = r.WithContext(ctx)
+
+			// Add request ID to response header
+			w.Header().Set(headerName, requestID)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientRequestID returns the ID sent in the given header, or an empty string
+// if it is missing or longer than maxRequestIDLength.
+func clientRequestID(r *http.Request, header string) string {
+	id := r.Header.Get(header)
+	if len(id) > maxRequestIDLength {
+		return ""
+	}
+	return id
 }
 
 // GetRequestID retrieves the request ID from context.
 func GetRequestID(ctx context.Context) string {
-	if reqID, ok := ctx.Value(requestIDKey).(string); ok {
-		return reqID
+	return logger.RequestIDFromContext(ctx)
+}
+
+// GetCorrelationID retrieves the client supplied correlation ID from context.
+// Returns an empty string if the client sent no valid X-Correlation-ID header.
+func GetCorrelationID(ctx context.Context) string {
+	if correlationID, ok := ctx.Value(correlationIDKey).(string); ok {
+		return correlationID
 	}
 	return ""
 }
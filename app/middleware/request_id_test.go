@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name                  string
+		correlationHeader     string
+		requestHeader         string
+		expectedRequestID     string
+		expectedCorrelationID string
+		expectGenerated       bool
+	}{
+		{
+			name:            "no headers generates request ID",
+			expectGenerated: true,
+		},
+		{
+			name:              "request ID header only",
+			requestHeader:     "req-1",
+			expectedRequestID: "req-1",
+		},
+		{
+			name:                  "correlation ID header only generates request ID",
+			correlationHeader:     "corr-1",
+			expectedCorrelationID: "corr-1",
+			expectGenerated:       true,
+		},
+		{
+			name:                  "both headers keep both IDs",
+			correlationHeader:     "corr-2",
+			requestHeader:         "req-2",
+			expectedRequestID:     "req-2",
+			expectedCorrelationID: "corr-2",
+		},
+		{
+			name:              "64 character ID is kept",
+			requestHeader:     strings.Repeat("a", 64),
+			expectedRequestID: strings.Repeat("a", 64),
+		},
+		{
+			name:            "100 character ID is replaced",
+			requestHeader:   strings.Repeat("a", 100),
+			expectGenerated: true,
+		},
+		{
+			name:              "100 character correlation ID is ignored",
+			correlationHeader: strings.Repeat("c", 100),
+			requestHeader:     "req-3",
+			expectedRequestID: "req-3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRequestID, gotCorrelationID string
+			handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRequestID = GetRequestID(r.Context())
+				gotCorrelationID = GetCorrelationID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.correlationHeader != "" {
+				req.Header.Set(CorrelationIDHeader, tt.correlationHeader)
+			}
+			if tt.requestHeader != "" {
+				req.Header.Set(RequestIDHeader, tt.requestHeader)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if tt.expectGenerated {
+				_, err := uuid.Parse(gotRequestID)
+				assert.NoError(t, err, "expected a generated UUID request ID")
+			} else {
+				assert.Equal(t, tt.expectedRequestID, gotRequestID)
+			}
+			assert.Equal(t, tt.expectedCorrelationID, gotCorrelationID)
+			assert.Equal(t, gotRequestID, w.Header().Get(RequestIDHeader))
+		})
+	}
+}
+
+func TestNewRequestID_CustomHeader(t *testing.T) {
+	t.Run("reads and echoes the custom header", func(t *testing.T) {
+		var gotRequestID string
+		handler := NewRequestID("X-Trace-Id")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRequestID = GetRequestID(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("X-Trace-Id", "trace-1")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, "trace-1", gotRequestID)
+		assert.Equal(t, "trace-1", w.Header().Get("X-Trace-Id"))
+		assert.Empty(t, w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("ignores the default header", func(t *testing.T) {
+		var gotRequestID string
+		handler := NewRequestID("CF-Ray")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRequestID = GetRequestID(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(RequestIDHeader, "req-1")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		_, err := uuid.Parse(gotRequestID)
+		assert.NoError(t, err, "expected a generated UUID request ID")
+		assert.Equal(t, gotRequestID, w.Header().Get("CF-Ray"))
+	})
+
+	t.Run("correlation ID is kept apart from the request ID", func(t *testing.T) {
+		var gotRequestID, gotCorrelationID string
+		handler := NewRequestID("X-Trace-Id")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRequestID = GetRequestID(r.Context())
+			gotCorrelationID = GetCorrelationID(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(CorrelationIDHeader, "corr-1")
+		req.Header.Set("X-Trace-Id", "trace-1")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, "trace-1", gotRequestID)
+		assert.Equal(t, "corr-1", gotCorrelationID)
+		assert.Equal(t, "trace-1", w.Header().Get("X-Trace-Id"))
+	})
+
+	t.Run("empty header name falls back to X-Request-ID", func(t *testing.T) {
+		handler := NewRequestID("")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(RequestIDHeader, "req-2")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, "req-2", w.Header().Get(RequestIDHeader))
+	})
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/mathutil"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+// AuditLogDTO represents an audit log entry for API responses.
+type AuditLogDTO struct {
+	RequestID   string
+	Method      string
+	Path        string
+	StatusCode  int
+	ActorID     string
+	Timestamp   time.Time
+	RequestBody string
+}
+
+// AuditLogRepository defines the interface for audit log data access.
+type AuditLogRepository interface {
+	List(ctx context.Context, path string, limit int) ([]models.AuditLog, error)
+}
+
+// AuditService handles audit log business logic.
+type AuditService struct {
+	repo AuditLogRepository
+}
+
+// NewAuditService creates a new AuditService instance.
+func NewAuditService(repo AuditLogRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// ListAuditLogs retrieves the most recent audit entries, optionally restricted to a path.
+// The limit defaults to 50 when not provided and is clamped between 1 and 500.
+func (s *AuditService) ListAuditLogs(ctx context.Context, path string, limit int, limitProvided bool) ([]AuditLogDTO, error) {
+	if !limitProvided {
+		limit = 50
+	}
+	limit = mathutil.Clamp(limit, 1, 500)
+
+	entries, err := s.repo.List(ctx, path, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AuditLogDTO, len(entries))
+	for i, e := range entries {
+		result[i] = AuditLogDTO{
+			RequestID:   e.RequestID,
+			Method:      e.Method,
+			Path:        e.Path,
+			StatusCode:  e.StatusCode,
+			ActorID:     e.ActorID,
+			Timestamp:   e.Timestamp,
+			RequestBody: string(e.RequestBody),
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+// mockAuditLogRepository is a mock implementation of AuditLogRepository for testing.
+type mockAuditLogRepository struct {
+	listFunc func(ctx context.Context, path string, limit int) ([]models.AuditLog, error)
+}
+
+func (m *mockAuditLogRepository) List(ctx context.Context, path string, limit int) ([]models.AuditLog, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, path, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestListAuditLogs_Success(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRepo := &mockAuditLogRepository{
+		listFunc: func(ctx context.Context, path string, limit int) ([]models.AuditLog, error) {
+			if path != "/v1/categories" {
+				t.Errorf("expected path /v1/categories, got %s", path)
+			}
+			return []models.AuditLog{
+				{ID: 1, RequestID: "req-1", Method: "POST", Path: path, StatusCode: 201, ActorID: "user-1", Timestamp: ts, RequestBody: []byte(`{"code":"X"}`)},
+			}, nil
+		},
+	}
+
+	svc := NewAuditService(mockRepo)
+
+	result, err := svc.ListAuditLogs(context.Background(), "/v1/categories", 0, false)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(result))
+	}
+
+	if result[0].ActorID != "user-1" {
+		t.Errorf("expected actor user-1, got %s", result[0].ActorID)
+	}
+	if result[0].RequestBody != `{"code":"X"}` {
+		t.Errorf("unexpected request body %q", result[0].RequestBody)
+	}
+}
+
+func TestListAuditLogs_Limit(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		limitProvided bool
+		expectedLimit int
+	}{
+		{"limit not provided uses default", 0, false, 50},
+		{"limit below minimum clamped to 1", 0, true, 1},
+		{"limit above maximum clamped to 500", 1000, true, 500},
+		{"valid limit", 20, true, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedLimit int
+			mockRepo := &mockAuditLogRepository{
+				listFunc: func(ctx context.Context, path string, limit int) ([]models.AuditLog, error) {
+					capturedLimit = limit
+					return nil, nil
+				},
+			}
+
+			svc := NewAuditService(mockRepo)
+
+			if _, err := svc.ListAuditLogs(context.Background(), "", tt.limit, tt.limitProvided); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if capturedLimit != tt.expectedLimit {
+				t.Errorf("expected limit %d, got %d", tt.expectedLimit, capturedLimit)
+			}
+		})
+	}
+}
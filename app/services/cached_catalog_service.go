@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/cache"
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/shopspring/decimal"
+)
+
+// listCacheKeyPrefix prefixes the cache keys of product listings.
+const listCacheKeyPrefix = "catalog:list:"
+
+// Cache defines the interface for the key/value cache used by CachedCatalogService.
+// Get must return cache.ErrMiss when the key is not present.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	DeleteByPrefix(ctx context.Context, prefix string) error
+}
+
+// CachedCatalogService wraps CatalogService and caches product listings and details.
+// Cache entries are invalidated on the product and category events of the bus.
+// Cache failures are logged and never fail a request; the underlying
+// service is used instead.
+type CachedCatalogService struct {
+	*CatalogService
+	cache      Cache
+	listTTL    time.Duration
+	productTTL time.Duration
+}
+
+// NewCachedCatalogService creates a new CachedCatalogService instance.
+// Listings are cached for listTTL and product details for productTTL. bus
+// must be the bus next and the categories service publish their events on.
+func NewCachedCatalogService(next *CatalogService, c Cache, bus events.Bus, listTTL, productTTL time.Duration) *CachedCatalogService {
+	s := &CachedCatalogService{
+		CatalogService: next,
+		cache:          c,
+		listTTL:        listTTL,
+		productTTL:     productTTL,
+	}
+	bus.Subscribe(events.ProductCreated, s.onProductChanged)
+	bus.Subscribe(events.ProductUpdated, s.onProductChanged)
+	bus.Subscribe(events.CategoryUpdated, s.onCategoryChanged)
+	bus.Subscribe(events.CategoryDeleted, s.onCategoryChanged)
+	return s
+}
+
+// listCacheKey holds the parameters identifying a cached product listing.
+type listCacheKey struct {
+	Offset        int              `json:"offset"`
+	Limit         int              `json:"limit"`
+	Category      string           `json:"category"`
+	Brand         string           `json:"brand"`
+	PriceLessThan *decimal.Decimal `json:"price_less_than"`
+	InStock       bool             `json:"in_stock"`
+	UpdatedAfter  *time.Time       `json:"updated_after"`
+
+	MinOrderQtyLessThan *int   `json:"min_order_qty_less_than"`
+	Label               string `json:"label"`
+	OnSale              bool   `json:"on_sale"`
+}
+
+// ListProducts retrieves paginated and filtered products, serving them from
+// the cache when possible.
+func (s *CachedCatalogService) ListProducts(ctx context.Context, params PaginationParams, filter FilterParams) (*ProductListResult, error) {
+	key, err := listKey(params, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, err := s.cache.Get(ctx, key); err == nil {
+		var result ProductListResult
+		if err := json.Unmarshal(cached, &result); err == nil {
+			return &result, nil
+		}
+		logger.Warn("Discarding malformed cached product listing", "key", key)
+	} else if !errors.Is(err, cache.ErrMiss) {
+		logger.Warn("Failed to read product listing from cache", "key", key, "error", err)
+	}
+
+	result, err := s.CatalogService.ListProducts(ctx, params, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(ctx, key, result, s.listTTL)
+	return result, nil
+}
+
+// GetProductByCode retrieves a product by its code, serving it from the
+// cache when possible.
+func (s *CachedCatalogService) GetProductByCode(ctx context.Context, code string) (*ProductDetailDTO, error) {
+	if code == "" {
+		return nil, ErrInvalidInput
+	}
+
+	key := cache.ProductKey(code)
+	if cached, err := s.cache.Get(ctx, key); err == nil {
+		var detail ProductDetailDTO
+		if err := json.Unmarshal(cached, &detail); err == nil {
+			return &detail, nil
+		}
+		logger.Warn("Discarding malformed cached product", "key", key)
+	} else if !errors.Is(err, cache.ErrMiss) {
+		logger.Warn("Failed to read product from cache", "key", key, "error", err)
+	}
+
+	detail, err := s.CatalogService.GetProductByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(ctx, key, detail, s.productTTL)
+	return detail, nil
+}
+
+// onProductChanged invalidates the cached details of the product, if any,
+// and all cached listings, which may include it.
+func (s *CachedCatalogService) onProductChanged(event events.Event) {
+	ctx := context.Background()
+	if p, ok := event.Payload.(ProductEvent); ok {
+		s.invalidateProduct(ctx, p.Code)
+	}
+	s.invalidateListings(ctx)
+}
+
+// onCategoryChanged invalidates all cached product details and listings, as
+// they embed the category of each product.
+func (s *CachedCatalogService) onCategoryChanged(event events.Event) {
+	ctx := context.Background()
+	if err := s.cache.DeleteByPrefix(ctx, cache.ProductKeyPrefix); err != nil {
+		logger.Warn("Failed to invalidate cached products", "error", err)
+	}
+	s.invalidateListings(ctx)
+}
+
+func (s *CachedCatalogService) store(ctx context.Context, key string, value any, ttl time.Duration) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		logger.Warn("Failed to encode cache entry", "key", key, "error", err)
+		return
+	}
+	if err := s.cache.Set(ctx, key, encoded, ttl); err != nil {
+		logger.Warn("Failed to store cache entry", "key", key, "error", err)
+	}
+}
+
+func (s *CachedCatalogService) invalidateProduct(ctx context.Context, code string) {
+	if err := s.cache.Delete(ctx, cache.ProductKey(code)); err != nil {
+		logger.Warn("Failed to invalidate cached product", "code", code, "error", err)
+	}
+}
+
+func (s *CachedCatalogService) invalidateListings(ctx context.Context) {
+	if err := s.cache.DeleteByPrefix(ctx, listCacheKeyPrefix); err != nil {
+		logger.Warn("Failed to invalidate cached product listings", "error", err)
+	}
+}
+
+func listKey(params PaginationParams, filter FilterParams) (string, error) {
+	encoded, err := json.Marshal(listCacheKey{
+		Offset:        params.Offset,
+		Limit:         params.Limit,
+		Category:      filter.Category,
+		Brand:         filter.Brand,
+		PriceLessThan: filter.PriceLessThan,
+		InStock:       filter.InStock,
+		UpdatedAfter:  filter.UpdatedAfter,
+
+		MinOrderQtyLessThan: filter.MinOrderQtyLessThan,
+		Label:               filter.Label,
+		OnSale:              filter.OnSale,
+	})
+	if err != nil {
+		return "", err
+	}
+	return listCacheKeyPrefix + string(encoded), nil
+}
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/cache"
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// mockCache is an in-memory implementation of Cache for testing.
+type mockCache struct {
+	entries map[string][]byte
+	getErr  error
+	setTTLs map[string]time.Duration
+}
+
+func newMockCache() *mockCache {
+	return &mockCache{
+		entries: make(map[string][]byte),
+		setTTLs: make(map[string]time.Duration),
+	}
+}
+
+func (m *mockCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	value, ok := m.entries[key]
+	if !ok {
+		return nil, cache.ErrMiss
+	}
+	return value, nil
+}
+
+func (m *mockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.entries[key] = value
+	m.setTTLs[key] = ttl
+	return nil
+}
+
+func (m *mockCache) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
+
+func (m *mockCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	for key := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
+
+// newCachedCatalogService returns a CachedCatalogService over repo whose
+// underlying service publishes its events on the same bus.
+func newCachedCatalogService(repo ProductRepository, c Cache, listTTL, productTTL time.Duration) *CachedCatalogService {
+	bus := events.NewInMemoryBus()
+	return NewCachedCatalogService(NewCatalogService(repo, nil, CatalogServiceConfig{}, bus), c, bus, listTTL, productTTL)
+}
+
+func newCountingProductRepository(calls *int) *mockProductRepository {
+	return &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			*calls++
+			return []models.Product{
+				{Code: "PROD001", Price: decimal.NewFromFloat(10.99)},
+			}, 1, nil
+		},
+	}
+}
+
+func TestCachedListProducts_CachesResult(t *testing.T) {
+	calls := 0
+	c := newMockCache()
+	svc := newCachedCatalogService(newCountingProductRepository(&calls), c, 30*time.Second, time.Minute)
+
+	params := PaginationParams{Offset: 0, Limit: 10}
+	for i := 0; i < 2; i++ {
+		result, err := svc.ListProducts(context.Background(), params, FilterParams{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Products) != 1 || result.Products[0].Code != "PROD001" {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if !result.Products[0].Price.Equal(decimal.RequireFromString("10.99")) {
+			t.Errorf("expected price 10.99, got %s", result.Products[0].Price)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected repository to be called once, got %d", calls)
+	}
+
+	for key, ttl := range c.setTTLs {
+		if ttl != 30*time.Second {
+			t.Errorf("expected ttl 30s for %s, got %s", key, ttl)
+		}
+	}
+}
+
+func TestCachedListProducts_KeyedByParams(t *testing.T) {
+	calls := 0
+	svc := newCachedCatalogService(newCountingProductRepository(&calls), newMockCache(), time.Minute, time.Minute)
+
+	price := decimal.NewFromInt(50)
+	requests := []struct {
+		params PaginationParams
+		filter FilterParams
+	}{
+		{PaginationParams{Offset: 0, Limit: 10}, FilterParams{}},
+		{PaginationParams{Offset: 10, Limit: 10}, FilterParams{}},
+		{PaginationParams{Offset: 0, Limit: 10}, FilterParams{Category: "SHOES"}},
+		{PaginationParams{Offset: 0, Limit: 10}, FilterParams{PriceLessThan: &price}},
+		{PaginationParams{Offset: 0, Limit: 10}, FilterParams{InStock: true}},
+	}
+
+	for _, req := range requests {
+		if _, err := svc.ListProducts(context.Background(), req.params, req.filter); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls != len(requests) {
+		t.Errorf("expected %d repository calls, got %d", len(requests), calls)
+	}
+}
+
+func TestCachedListProducts_CacheErrorFallsBack(t *testing.T) {
+	calls := 0
+	c := newMockCache()
+	c.getErr = errors.New("connection refused")
+	svc := newCachedCatalogService(newCountingProductRepository(&calls), c, time.Minute, time.Minute)
+
+	if _, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{}); err != nil {
+		t.Fatalf("expected fallback to repository, got error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected repository to be called once, got %d", calls)
+	}
+}
+
+func TestCachedUpdateProduct_InvalidatesListings(t *testing.T) {
+	calls := 0
+	repo := newCountingProductRepository(&calls)
+	repo.updateProductFunc = func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+		return &models.Product{Code: code, Price: *update.Price}, nil
+	}
+
+	c := newMockCache()
+	c.entries["unrelated"] = []byte("keep")
+	svc := newCachedCatalogService(repo, c, time.Minute, time.Minute)
+
+	params := PaginationParams{Limit: 10}
+	if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	price := decimal.NewFromInt(20)
+	if _, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{Price: &price}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected listing to be refetched after update, got %d repository calls", calls)
+	}
+
+	if _, ok := c.entries["unrelated"]; !ok {
+		t.Error("expected unrelated cache entries to be kept")
+	}
+}
+
+func TestCachedUpdateProduct_ErrorKeepsListings(t *testing.T) {
+	calls := 0
+	repo := newCountingProductRepository(&calls)
+	svc := newCachedCatalogService(repo, newMockCache(), time.Minute, time.Minute)
+
+	params := PaginationParams{Limit: 10}
+	if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+
+	if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected cached listing to be kept after failed update, got %d repository calls", calls)
+	}
+}
+
+func TestCachedAdjustVariantStock_InvalidatesListings(t *testing.T) {
+	calls := 0
+	repo := newCountingProductRepository(&calls)
+	repo.adjustStockFunc = func(ctx context.Context, code, sku string, delta int) error {
+		return nil
+	}
+	repo.getProductByCodeFunc = func(ctx context.Context, code string) (*models.Product, error) {
+		return &models.Product{
+			Code:     code,
+			Price:    decimal.NewFromInt(10),
+			Variants: []models.Variant{{SKU: "SKU001A", StockQuantity: 3}},
+		}, nil
+	}
+
+	svc := newCachedCatalogService(repo, newMockCache(), time.Minute, time.Minute)
+
+	params := PaginationParams{Limit: 10}
+	filter := FilterParams{InStock: true}
+	if _, err := svc.ListProducts(context.Background(), params, filter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.AdjustVariantStock(context.Background(), "PROD001", "SKU001A", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.ListProducts(context.Background(), params, filter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected listing to be refetched after stock change, got %d repository calls", calls)
+	}
+}
+
+func newCountingDetailRepository(calls *int) *mockProductRepository {
+	return &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			*calls++
+			return &models.Product{
+				Code:     code,
+				Price:    decimal.NewFromFloat(10.99),
+				Category: &models.Category{Code: "CLOTHING", Name: "Clothing"},
+				Variants: []models.Variant{{Name: "Small", SKU: "SKU001A", StockQuantity: 5}},
+			}, nil
+		},
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			return &models.Product{Code: code, Price: *update.Price}, nil
+		},
+	}
+}
+
+func TestCachedGetProductByCode_CachesResult(t *testing.T) {
+	calls := 0
+	c := newMockCache()
+	svc := newCachedCatalogService(newCountingDetailRepository(&calls), c, time.Minute, 60*time.Second)
+
+	for i := 0; i < 2; i++ {
+		detail, err := svc.GetProductByCode(context.Background(), "PROD001")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if detail.Code != "PROD001" || detail.Category == nil || detail.Category.Code != "CLOTHING" {
+			t.Errorf("unexpected detail: %+v", detail)
+		}
+		if len(detail.Variants) != 1 || detail.Variants[0].StockQuantity != 5 {
+			t.Errorf("unexpected variants: %+v", detail.Variants)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected repository to be called once, got %d", calls)
+	}
+
+	if ttl := c.setTTLs["product:PROD001"]; ttl != 60*time.Second {
+		t.Errorf("expected product cached under product:PROD001 for 60s, got %s", ttl)
+	}
+}
+
+func TestCachedGetProductByCode_NotFoundNotCached(t *testing.T) {
+	calls := 0
+	repo := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			calls++
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	svc := newCachedCatalogService(repo, newMockCache(), time.Minute, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.GetProductByCode(context.Background(), "MISSING"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("expected repository to be called twice, got %d", calls)
+	}
+}
+
+func TestCachedUpdateProduct_EvictsProduct(t *testing.T) {
+	calls := 0
+	svc := newCachedCatalogService(newCountingDetailRepository(&calls), newMockCache(), time.Minute, time.Minute)
+
+	if _, err := svc.GetProductByCode(context.Background(), "PROD001"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	price := decimal.NewFromInt(20)
+	if _, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{Price: &price}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.GetProductByCode(context.Background(), "PROD001"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected product to be refetched after update, got %d repository calls", calls)
+	}
+}
+
+func TestCachedCatalog_CategoryEventsEvictProductsAndListings(t *testing.T) {
+	for _, name := range []string{events.CategoryUpdated, events.CategoryDeleted} {
+		t.Run(name, func(t *testing.T) {
+			detailCalls, listCalls := 0, 0
+			repo := newCountingDetailRepository(&detailCalls)
+			repo.getAllProductsFunc = newCountingProductRepository(&listCalls).getAllProductsFunc
+
+			bus := events.NewInMemoryBus()
+			c := newMockCache()
+			c.entries["unrelated"] = []byte("keep")
+			svc := NewCachedCatalogService(NewCatalogService(repo, nil, CatalogServiceConfig{}, bus), c, bus, time.Minute, time.Minute)
+			categories := NewCategoriesService(&mockCategoryRepository{
+				updateCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+					return &models.Category{Code: code, Name: name}, nil
+				},
+				deleteCategoryFunc: func(ctx context.Context, code string) error {
+					return nil
+				},
+			}, bus)
+
+			params := PaginationParams{Limit: 10}
+			if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := svc.GetProductByCode(context.Background(), "PROD001"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var err error
+			if name == events.CategoryUpdated {
+				_, err = categories.UpdateCategory(context.Background(), "CLOTHING", UpdateCategoryInput{Name: "Apparel"})
+			} else {
+				err = categories.DeleteCategory(context.Background(), "CLOTHING")
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := svc.GetProductByCode(context.Background(), "PROD001"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if listCalls != 2 || detailCalls != 2 {
+				t.Errorf("expected listing and product to be refetched, got %d listing and %d product calls", listCalls, detailCalls)
+			}
+			if _, ok := c.entries["unrelated"]; !ok {
+				t.Error("expected unrelated cache entries to be kept")
+			}
+		})
+	}
+}
+
+func TestCachedBulkCreateProducts_InvalidatesProductsAndListings(t *testing.T) {
+	calls := 0
+	repo := newCountingProductRepository(&calls)
+	repo.bulkCreateFunc = func(ctx context.Context, products []models.Product) error {
+		return nil
+	}
+
+	c := newMockCache()
+	c.entries[cache.ProductKey("PROD010")] = []byte(`{"code":"PROD010"}`)
+	c.entries["unrelated"] = []byte("keep")
+	svc := newCachedCatalogService(repo, c, time.Minute, time.Minute)
+
+	params := PaginationParams{Limit: 10}
+	if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	price := decimal.NewFromInt(20)
+	if _, _, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD010", Price: price},
+		{Code: "PROD011", Price: price},
+		{Code: "PROD012", Price: price},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := c.entries[cache.ProductKey("PROD010")]; ok {
+		t.Error("expected the cached PROD010 details to be evicted")
+	}
+	if _, ok := c.entries["unrelated"]; !ok {
+		t.Error("expected unrelated cache entries to be kept")
+	}
+
+	if _, err := svc.ListProducts(context.Background(), params, FilterParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected listing to be refetched after bulk create, got %d repository calls", calls)
+	}
+}
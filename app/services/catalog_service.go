@@ -4,12 +4,51 @@ package services
 import (
 	"context"
 	"errors"
-
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/mytheresa/go-hiring-challenge/app/mathutil"
 	"github.com/mytheresa/go-hiring-challenge/models"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
+// identifierPattern is the format of product codes and SKUs. Restricting them keeps
+// identifiers safe to use as URL path segments and filter values as is.
+var identifierPattern = regexp.MustCompile(`^[A-Z0-9_-]+$`)
+
+// ValidateProductCode checks that code consists only of uppercase letters, digits,
+// underscores and hyphens. Returns ErrInvalidProductCode otherwise.
+func ValidateProductCode(code string) error {
+	if !identifierPattern.MatchString(code) {
+		return ErrInvalidProductCode
+	}
+	return nil
+}
+
+// ValidateSKU checks that sku consists only of uppercase letters, digits,
+// underscores and hyphens. Returns ErrInvalidSKU otherwise.
+func ValidateSKU(sku string) error {
+	if !identifierPattern.MatchString(sku) {
+		return ErrInvalidSKU
+	}
+	return nil
+}
+
+// ValidateLabel checks that label is one of the product labels: new, sale, featured or none.
+// Returns ErrInvalidLabel otherwise.
+func ValidateLabel(label string) error {
+	switch label {
+	case models.LabelNew, models.LabelSale, models.LabelFeatured, models.LabelNone:
+		return nil
+	}
+	return ErrInvalidLabel
+}
+
 // PaginationParams holds validated pagination parameters.
 type PaginationParams struct {
 	Offset int
@@ -19,35 +58,64 @@ type PaginationParams struct {
 // FilterParams holds filter criteria for product queries.
 type FilterParams struct {
 	Category      string
+	Brand         string
 	PriceLessThan *decimal.Decimal
+	InStock       bool
+	UpdatedAfter  *time.Time
+	// MinOrderQtyLessThan keeps products whose minimum order quantity is below the value.
+	MinOrderQtyLessThan *int
+	Label               string
+	// OnSale keeps products whose sale price is below their price.
+	OnSale bool
 }
 
 // ProductDTO represents a product for API responses.
 type ProductDTO struct {
 	Code     string
-	Price    float64
+	Slug     string
+	Brand    string
+	ImageURL string
+	Price    decimal.Decimal
 	Category *CategoryDTO
+	Label    string
+	// SalePrice is nil when the product is not on sale.
+	SalePrice *decimal.Decimal
 }
 
 // CategoryDTO represents a category for API responses.
+// ProductCount is only populated when listing or restoring categories.
 type CategoryDTO struct {
-	Code string
-	Name string
+	Code         string
+	Name         string
+	ProductCount int64
 }
 
 // VariantDTO represents a variant for API responses.
 type VariantDTO struct {
-	Name  string
-	SKU   string
-	Price float64
+	Name          string
+	SKU           string
+	Barcode       string
+	Price         decimal.Decimal
+	StockQuantity int
 }
 
 // ProductDetailDTO represents detailed product information.
 type ProductDetailDTO struct {
-	Code     string
-	Price    float64
-	Category *CategoryDTO
-	Variants []VariantDTO
+	Code      string
+	Slug      string
+	Brand     string
+	ImageURL  string
+	Price     decimal.Decimal
+	Category  *CategoryDTO
+	Variants  []VariantDTO
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// MaxOrderQty is nil when there is no upper limit.
+	MinOrderQty int
+	MaxOrderQty *int
+	Label       string
+	// SalePrice is nil when the product is not on sale.
+	SalePrice *decimal.Decimal
 }
 
 // ProductListResult holds the result of listing products.
@@ -56,36 +124,131 @@ type ProductListResult struct {
 	Total    int64
 }
 
+// UpdateProductInput represents the input for updating a product.
+// Nil fields are left unchanged; an empty Brand clears the brand and an empty ImageURL the image.
+// A zero MaxOrderQty removes the upper limit and a zero SalePrice ends the product's sale.
+type UpdateProductInput struct {
+	Price       *decimal.Decimal
+	Brand       *string
+	ImageURL    *string
+	MinOrderQty *int
+	MaxOrderQty *int
+	SalePrice   *decimal.Decimal
+}
+
+// CreateProductInput represents the input for creating a product.
+// An empty Label defaults to "none". A nil SalePrice means the product is not on sale.
+// A nil MinOrderQty defaults to 1 and a nil MaxOrderQty means there is no upper limit.
+type CreateProductInput struct {
+	Code        string
+	Price       decimal.Decimal
+	Brand       *string
+	Label       string
+	SalePrice   *decimal.Decimal
+	MinOrderQty *int
+	MaxOrderQty *int
+}
+
+// BulkError describes why one input of a bulk operation was rejected.
+// Index is the position of the input in the request.
+type BulkError struct {
+	Index int
+	Code  string
+	Err   error
+}
+
 // ProductRepository defines the interface for product data access.
 type ProductRepository interface {
 	GetAllProducts(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error)
 	GetProductByCode(ctx context.Context, code string) (*models.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*models.Product, error)
+	GetProductSlugs(ctx context.Context, offset, limit int) ([]string, int64, error)
+	GetProductByBarcode(ctx context.Context, barcode string) (*models.Product, error)
+	AdjustVariantStock(ctx context.Context, code, sku string, delta int) error
+	UpdateProduct(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error)
+	BulkCreate(ctx context.Context, products []models.Product) error
+}
+
+// CategoryLookup resolves category codes to IDs.
+type CategoryLookup interface {
+	// GetCategoryIDByCode returns gorm.ErrRecordNotFound if no category has the code.
+	GetCategoryIDByCode(ctx context.Context, code string) (uint, error)
+}
+
+// Default pagination limits used when CatalogServiceConfig leaves them unset.
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+// eachProductBatchSize is the number of products EachProduct loads per query.
+const eachProductBatchSize = 500
+
+// maxImageURLLength is the longest image URL a product can store, matching the image_url column.
+const maxImageURLLength = 2048
+
+// New arrivals windows, in days.
+const (
+	DefaultNewArrivalsDays = 7
+	MaxNewArrivalsDays     = 90
+)
+
+// CatalogServiceConfig holds the settings of a CatalogService.
+// Zero values fall back to a default limit of 10 and a maximum limit of 100.
+type CatalogServiceConfig struct {
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// ProductEvent is the payload of product events.
+type ProductEvent struct {
+	Code string `json:"code"`
 }
 
 // CatalogService handles catalog business logic.
 type CatalogService struct {
-	repo ProductRepository
+	repo         ProductRepository
+	categories   CategoryLookup
+	bus          events.Bus
+	defaultLimit int
+	maxLimit     int
 }
 
 // NewCatalogService creates a new CatalogService instance.
-func NewCatalogService(repo ProductRepository) *CatalogService {
-	return &CatalogService{repo: repo}
+// The category filter is resolved to an ID through categories, so listings
+// need not join the categories table; a nil categories filters by code instead.
+// Product events are published on bus, which may be nil to publish none.
+func NewCatalogService(repo ProductRepository, categories CategoryLookup, cfg CatalogServiceConfig, bus events.Bus) *CatalogService {
+	s := &CatalogService{
+		repo:         repo,
+		categories:   categories,
+		bus:          bus,
+		defaultLimit: cfg.DefaultLimit,
+		maxLimit:     cfg.MaxLimit,
+	}
+	if s.defaultLimit <= 0 {
+		s.defaultLimit = defaultPageLimit
+	}
+	if s.maxLimit <= 0 {
+		s.maxLimit = maxPageLimit
+	}
+	return s
 }
 
 // ValidatePagination validates and normalizes pagination parameters.
-// Returns validated params with defaults: offset=0, limit=10.
-// Limit is constrained between 1 and 100.
+// Returns validated params with defaults: offset=0, limit=the configured default limit.
+// Limit is constrained between 1 and the configured maximum limit.
 // Note: Negative offset validation is handled at the handler layer.
 // The limitProvided flag indicates whether limit was explicitly set by the caller.
 func (s *CatalogService) ValidatePagination(offset, limit int, limitProvided bool) PaginationParams {
 	params := PaginationParams{
 		Offset: offset,
-		Limit:  10,
+		Limit:  s.defaultLimit,
 	}
 
 	if limitProvided {
 		// Limit was explicitly provided, clamp to valid range
-		params.Limit = clamp(limit, 1, 100)
+		params.Limit = mathutil.Clamp(limit, 1, s.maxLimit)
 	}
 
 	return params
@@ -94,14 +257,46 @@ func (s *CatalogService) ValidatePagination(offset, limit int, limitProvided boo
 // ListProducts retrieves paginated and filtered products.
 func (s *CatalogService) ListProducts(ctx context.Context, params PaginationParams, filter FilterParams) (*ProductListResult, error) {
 	repoFilter := models.ProductFilter{
-		Category: filter.Category,
+		Category:     filter.Category,
+		Brand:        filter.Brand,
+		InStock:      filter.InStock,
+		UpdatedAfter: filter.UpdatedAfter,
+
+		MinOrderQtyLessThan: filter.MinOrderQtyLessThan,
+		Label:               filter.Label,
+		OnSale:              filter.OnSale,
 	}
 
 	if filter.PriceLessThan != nil {
 		repoFilter.PriceLessThan = filter.PriceLessThan
 	}
 
-	products, total, err := s.repo.GetAllProducts(ctx, params.Offset, params.Limit, repoFilter)
+	if filter.Category != "" && s.categories != nil {
+		id, err := s.categories.GetCategoryIDByCode(ctx, filter.Category)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// An unknown category matches no product.
+			return &ProductListResult{Products: []ProductDTO{}}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		repoFilter.Category = ""
+		repoFilter.CategoryIDs = []uint{id}
+	}
+
+	return s.listProducts(ctx, params, repoFilter)
+}
+
+// ListNewArrivals retrieves paginated products created in the last days days.
+// days is constrained between 1 and MaxNewArrivalsDays.
+func (s *CatalogService) ListNewArrivals(ctx context.Context, days int, params PaginationParams) (*ProductListResult, error) {
+	return s.listProducts(ctx, params, models.ProductFilter{
+		CreatedWithinDays: mathutil.Clamp(days, 1, MaxNewArrivalsDays),
+	})
+}
+
+func (s *CatalogService) listProducts(ctx context.Context, params PaginationParams, filter models.ProductFilter) (*ProductListResult, error) {
+	products, total, err := s.repo.GetAllProducts(ctx, params.Offset, params.Limit, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -118,14 +313,78 @@ func (s *CatalogService) ListProducts(ctx context.Context, params PaginationPara
 	return result, nil
 }
 
+// EachProduct calls fn with every product, including its variants, in ID order.
+// Products are loaded in batches, so the catalog is never held in memory at once.
+// It stops at the first error returned by the repository or by fn.
+func (s *CatalogService) EachProduct(ctx context.Context, fn func(*ProductDetailDTO) error) error {
+	for offset := 0; ; offset += eachProductBatchSize {
+		products, _, err := s.repo.GetAllProducts(ctx, offset, eachProductBatchSize, models.ProductFilter{})
+		if err != nil {
+			return err
+		}
+
+		for i := range products {
+			if err := fn(mapProductToDetailDTO(&products[i])); err != nil {
+				return err
+			}
+		}
+
+		if len(products) < eachProductBatchSize {
+			return nil
+		}
+	}
+}
+
+// ListProductSlugs retrieves a page of product slugs ordered by ID, along
+// with the total number of products. A limit of 0 only counts the products.
+func (s *CatalogService) ListProductSlugs(ctx context.Context, offset, limit int) ([]string, int64, error) {
+	return s.repo.GetProductSlugs(ctx, offset, limit)
+}
+
 // GetProductByCode retrieves a product by its code.
-// Returns ErrNotFound if the product doesn't exist.
+// Returns ErrNotFound, wrapping the repository error, if the product doesn't exist.
 func (s *CatalogService) GetProductByCode(ctx context.Context, code string) (*ProductDetailDTO, error) {
 	if code == "" {
 		return nil, ErrInvalidInput
 	}
 
 	product, err := s.repo.GetProductByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+		return nil, err
+	}
+
+	return mapProductToDetailDTO(product), nil
+}
+
+// GetProductBySlug retrieves a product by its slug.
+// Returns ErrNotFound if the product doesn't exist.
+func (s *CatalogService) GetProductBySlug(ctx context.Context, slug string) (*ProductDetailDTO, error) {
+	if slug == "" {
+		return nil, ErrInvalidInput
+	}
+
+	product, err := s.repo.GetProductBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return mapProductToDetailDTO(product), nil
+}
+
+// GetProductByBarcode retrieves the product owning the variant with the given barcode.
+// Returns ErrNotFound if no variant has the barcode.
+func (s *CatalogService) GetProductByBarcode(ctx context.Context, barcode string) (*ProductDetailDTO, error) {
+	if barcode == "" {
+		return nil, ErrInvalidInput
+	}
+
+	product, err := s.repo.GetProductByBarcode(ctx, barcode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrNotFound
@@ -136,10 +395,216 @@ func (s *CatalogService) GetProductByCode(ctx context.Context, code string) (*Pr
 	return mapProductToDetailDTO(product), nil
 }
 
+// UpdateProduct updates a product by its code and returns the updated details.
+// Price changes are recorded in the product's price history.
+// Returns ErrInvalidInput if the order quantity bounds are invalid, either as
+// given or combined with the stored ones, ErrInvalidProductImage if the image URL
+// is not an absolute http(s) URL of at most maxImageURLLength characters, and
+// ErrNotFound if the product doesn't exist.
+func (s *CatalogService) UpdateProduct(ctx context.Context, code string, input UpdateProductInput) (*ProductDetailDTO, error) {
+	if code == "" || (input.Price == nil && input.Brand == nil && input.ImageURL == nil && input.MinOrderQty == nil && input.MaxOrderQty == nil && input.SalePrice == nil) {
+		return nil, ErrInvalidInput
+	}
+	if input.Price != nil && input.Price.IsNegative() {
+		return nil, ErrInvalidProductPrice
+	}
+	if input.SalePrice != nil && !validSalePrice(*input.SalePrice, input.Price) {
+		return nil, ErrInvalidSalePrice
+	}
+	// A zero maximum removes the limit, so there is no bound to check.
+	maxOrderQty := input.MaxOrderQty
+	if maxOrderQty != nil && *maxOrderQty == 0 {
+		maxOrderQty = nil
+	}
+	if err := validateOrderQuantities(input.MinOrderQty, maxOrderQty); err != nil {
+		return nil, err
+	}
+
+	var brand *string
+	if input.Brand != nil {
+		trimmed := strings.TrimSpace(*input.Brand)
+		brand = &trimmed
+	}
+
+	var imageURL *string
+	if input.ImageURL != nil {
+		trimmed := strings.TrimSpace(*input.ImageURL)
+		if trimmed != "" && (len(trimmed) > maxImageURLLength || !isHTTPURL(trimmed)) {
+			return nil, ErrInvalidProductImage
+		}
+		imageURL = &trimmed
+	}
+
+	product, err := s.repo.UpdateProduct(ctx, code, models.ProductUpdate{
+		Price:       input.Price,
+		Brand:       brand,
+		ImageURL:    imageURL,
+		MinOrderQty: input.MinOrderQty,
+		MaxOrderQty: input.MaxOrderQty,
+		SalePrice:   input.SalePrice,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, ErrNotFound
+		case errors.Is(err, models.ErrInvalidOrderQuantity):
+			return nil, ErrInvalidInput
+		case errors.Is(err, models.ErrInvalidSalePrice):
+			return nil, ErrInvalidSalePrice
+		}
+		return nil, err
+	}
+
+	publish(ctx, s.bus, events.ProductUpdated, ProductEvent{Code: product.Code})
+	return mapProductToDetailDTO(product), nil
+}
+
+// BulkCreateProducts creates all the given products atomically and returns their details.
+// Every input is validated first: if any is invalid, nothing is created, ErrInvalidInput
+// is returned and the BulkErrors list each rejected input with the reason, one of
+// ErrInvalidProductCode (see ValidateProductCode), ErrInvalidProductPrice, ErrInvalidLabel,
+// ErrInvalidSalePrice, ErrInvalidInput for bad order quantities, or ErrDuplicateCode for a
+// code repeated in the batch. If the insert of an input fails, nothing is created either
+// and its BulkError, also returned as the error, holds ErrDuplicateCode for a taken code,
+// ErrInvalidProductPrice for a price the database rejects, or the repository error otherwise.
+// An events.ProductCreated event is published for each product once all are created.
+func (s *CatalogService) BulkCreateProducts(ctx context.Context, inputs []CreateProductInput) ([]ProductDetailDTO, []BulkError, error) {
+	if len(inputs) == 0 {
+		return nil, nil, ErrInvalidInput
+	}
+
+	var bulkErrs []BulkError
+	products := make([]models.Product, len(inputs))
+	seen := make(map[string]int, len(inputs))
+	for i, input := range inputs {
+		code := strings.TrimSpace(input.Code)
+
+		label := input.Label
+		if label == "" {
+			label = models.LabelNone
+		}
+
+		var err error
+		switch {
+		case ValidateProductCode(code) != nil:
+			err = ErrInvalidProductCode
+		case input.Price.IsNegative():
+			err = ErrInvalidProductPrice
+		case ValidateLabel(label) != nil:
+			err = ErrInvalidLabel
+		case input.SalePrice != nil && (input.SalePrice.IsZero() || !validSalePrice(*input.SalePrice, &input.Price)):
+			err = ErrInvalidSalePrice
+		case validateOrderQuantities(input.MinOrderQty, input.MaxOrderQty) != nil:
+			err = ErrInvalidInput
+		default:
+			if _, ok := seen[code]; ok {
+				err = ErrDuplicateCode
+			}
+		}
+		if err != nil {
+			bulkErrs = append(bulkErrs, BulkError{Index: i, Code: code, Err: err})
+			continue
+		}
+		seen[code] = i
+
+		var brand *string
+		if input.Brand != nil {
+			if trimmed := strings.TrimSpace(*input.Brand); trimmed != "" {
+				brand = &trimmed
+			}
+		}
+		minOrderQty := 1
+		if input.MinOrderQty != nil {
+			minOrderQty = *input.MinOrderQty
+		}
+		products[i] = models.Product{
+			Code:        code,
+			Price:       input.Price,
+			Brand:       brand,
+			MinOrderQty: minOrderQty,
+			MaxOrderQty: input.MaxOrderQty,
+			Label:       label,
+			SalePrice:   input.SalePrice,
+		}
+	}
+	if len(bulkErrs) > 0 {
+		return nil, bulkErrs, ErrInvalidInput
+	}
+
+	if err := s.repo.BulkCreate(ctx, products); err != nil {
+		var rowErr *models.RowError
+		if !errors.As(err, &rowErr) {
+			return nil, nil, err
+		}
+
+		cause := rowErr.Err
+		switch {
+		case errors.Is(cause, models.ErrDuplicateCode):
+			cause = ErrDuplicateCode
+		case errors.Is(cause, models.ErrNegativePrice):
+			cause = ErrInvalidProductPrice
+		}
+		return nil, []BulkError{{Index: rowErr.Index, Code: products[rowErr.Index].Code, Err: cause}}, cause
+	}
+
+	details := make([]ProductDetailDTO, len(products))
+	for i := range products {
+		publish(ctx, s.bus, events.ProductCreated, ProductEvent{Code: products[i].Code})
+		details[i] = *mapProductToDetailDTO(&products[i])
+	}
+	return details, nil, nil
+}
+
+// AdjustVariantStock changes the stock quantity of a product variant by delta
+// and returns the updated variant.
+// Returns ErrInvalidSKU if sku is malformed and ErrNotFound if the product or variant doesn't exist.
+func (s *CatalogService) AdjustVariantStock(ctx context.Context, code, sku string, delta int) (*VariantDTO, error) {
+	if code == "" || sku == "" {
+		return nil, ErrInvalidInput
+	}
+	if err := ValidateSKU(sku); err != nil {
+		return nil, err
+	}
+	if delta == 0 {
+		return nil, ErrInvalidStockDelta
+	}
+
+	if err := s.repo.AdjustVariantStock(ctx, code, sku, delta); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, ErrNotFound
+		case errors.Is(err, models.ErrInsufficientStock):
+			return nil, ErrInsufficientStock
+		}
+		return nil, err
+	}
+
+	// Stock is part of the product and drives the inStock filter.
+	publish(ctx, s.bus, events.ProductUpdated, ProductEvent{Code: code})
+
+	detail, err := s.GetProductByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range detail.Variants {
+		if v.SKU == sku {
+			return &v, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
 func mapProductToDTO(p models.Product) ProductDTO {
 	dto := ProductDTO{
-		Code:  p.Code,
-		Price: p.Price.InexactFloat64(),
+		Code:      p.Code,
+		Slug:      p.Slug,
+		Brand:     derefString(p.Brand),
+		ImageURL:  derefString(p.ImageURL),
+		Price:     p.Price,
+		Label:     p.Label,
+		SalePrice: p.SalePrice,
 	}
 
 	if p.Category != nil {
@@ -154,9 +619,19 @@ func mapProductToDTO(p models.Product) ProductDTO {
 
 func mapProductToDetailDTO(p *models.Product) *ProductDetailDTO {
 	detail := &ProductDetailDTO{
-		Code:     p.Code,
-		Price:    p.Price.InexactFloat64(),
-		Variants: make([]VariantDTO, len(p.Variants)),
+		Code:      p.Code,
+		Slug:      p.Slug,
+		Brand:     derefString(p.Brand),
+		ImageURL:  derefString(p.ImageURL),
+		Price:     p.Price,
+		Variants:  make([]VariantDTO, len(p.Variants)),
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+
+		MinOrderQty: p.MinOrderQty,
+		MaxOrderQty: p.MaxOrderQty,
+		Label:       p.Label,
+		SalePrice:   p.SalePrice,
 	}
 
 	if p.Category != nil {
@@ -166,29 +641,65 @@ func mapProductToDetailDTO(p *models.Product) *ProductDetailDTO {
 		}
 	}
 
-	productPrice := p.Price.InexactFloat64()
 	for i, v := range p.Variants {
-		variantPrice := productPrice
+		variantPrice := p.Price
 		if v.Price != nil {
-			variantPrice = v.Price.InexactFloat64()
+			variantPrice = *v.Price
 		}
 
 		detail.Variants[i] = VariantDTO{
-			Name:  v.Name,
-			SKU:   v.SKU,
-			Price: variantPrice,
+			Name:          v.Name,
+			SKU:           v.SKU,
+			Barcode:       derefString(v.Barcode),
+			Price:         variantPrice,
+			StockQuantity: v.StockQuantity,
 		}
 	}
 
 	return detail
 }
 
-func clamp(value, min, max int) int {
-	if value < min {
-		return min
+// validateOrderQuantities checks the given order quantity bounds: the minimum
+// must be at least 1 and the maximum, if set, must not be below the minimum.
+// Nil bounds are not checked. Returns ErrInvalidInput on violation.
+func validateOrderQuantities(min, max *int) error {
+	lower := 1
+	if min != nil {
+		if *min < 1 {
+			return ErrInvalidInput
+		}
+		lower = *min
+	}
+	if max != nil && *max < lower {
+		return ErrInvalidInput
 	}
-	if value > max {
-		return max
+	return nil
+}
+
+// validSalePrice checks a sale price against the price it discounts, if known.
+// A zero sale price ends a sale and is always valid; the repository checks the
+// sale price against the stored price when price is nil.
+func validSalePrice(salePrice decimal.Decimal, price *decimal.Decimal) bool {
+	if salePrice.IsZero() {
+		return true
+	}
+	return !salePrice.IsNegative() && (price == nil || salePrice.LessThan(*price))
+}
+
+// publish publishes an event on bus, if any. The change it announces is
+// already persisted, so a failure is logged rather than returned.
+func publish(ctx context.Context, bus events.Bus, name string, payload any) {
+	if bus == nil {
+		return
+	}
+	if err := bus.Publish(ctx, events.Event{Name: name, Payload: payload}); err != nil {
+		logger.Warn("Failed to publish event", "event", name, "error", err)
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
 	}
-	return value
+	return *s
 }
@@ -3,8 +3,16 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"testing/quick"
+	"time"
 
+	"github.com/mytheresa/go-hiring-challenge/app/events"
 	"github.com/mytheresa/go-hiring-challenge/models"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
@@ -12,8 +20,14 @@ import (
 
 // mockProductRepository is a mock implementation of ProductRepository for testing.
 type mockProductRepository struct {
-	getAllProductsFunc   func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error)
-	getProductByCodeFunc func(ctx context.Context, code string) (*models.Product, error)
+	getAllProductsFunc      func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error)
+	getProductByCodeFunc    func(ctx context.Context, code string) (*models.Product, error)
+	getProductBySlugFunc    func(ctx context.Context, slug string) (*models.Product, error)
+	getProductSlugsFunc     func(ctx context.Context, offset, limit int) ([]string, int64, error)
+	getProductByBarcodeFunc func(ctx context.Context, barcode string) (*models.Product, error)
+	adjustStockFunc         func(ctx context.Context, code, sku string, delta int) error
+	updateProductFunc       func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error)
+	bulkCreateFunc          func(ctx context.Context, products []models.Product) error
 }
 
 func (m *mockProductRepository) GetAllProducts(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
@@ -30,8 +44,62 @@ func (m *mockProductRepository) GetProductByCode(ctx context.Context, code strin
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockProductRepository) GetProductBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	if m.getProductBySlugFunc != nil {
+		return m.getProductBySlugFunc(ctx, slug)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) GetProductSlugs(ctx context.Context, offset, limit int) ([]string, int64, error) {
+	if m.getProductSlugsFunc != nil {
+		return m.getProductSlugsFunc(ctx, offset, limit)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) GetProductByBarcode(ctx context.Context, barcode string) (*models.Product, error) {
+	if m.getProductByBarcodeFunc != nil {
+		return m.getProductByBarcodeFunc(ctx, barcode)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) AdjustVariantStock(ctx context.Context, code, sku string, delta int) error {
+	if m.adjustStockFunc != nil {
+		return m.adjustStockFunc(ctx, code, sku, delta)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) UpdateProduct(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+	if m.updateProductFunc != nil {
+		return m.updateProductFunc(ctx, code, update)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) BulkCreate(ctx context.Context, products []models.Product) error {
+	if m.bulkCreateFunc != nil {
+		return m.bulkCreateFunc(ctx, products)
+	}
+	return errors.New("not implemented")
+}
+
+// mockCategoryLookup is a mock implementation of CategoryLookup for testing.
+type mockCategoryLookup struct {
+	getCategoryIDByCodeFunc func(ctx context.Context, code string) (uint, error)
+}
+
+func (m *mockCategoryLookup) GetCategoryIDByCode(ctx context.Context, code string) (uint, error) {
+	if m.getCategoryIDByCodeFunc != nil {
+		return m.getCategoryIDByCodeFunc(ctx, code)
+	}
+	return 0, errors.New("not implemented")
+}
+
 func TestValidatePagination_Defaults(t *testing.T) {
-	svc := NewCatalogService(&mockProductRepository{})
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
 
 	params := svc.ValidatePagination(0, 0, false)
 
@@ -44,7 +112,7 @@ func TestValidatePagination_Defaults(t *testing.T) {
 }
 
 func TestValidatePagination_ValidValues(t *testing.T) {
-	svc := NewCatalogService(&mockProductRepository{})
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
 
 	params := svc.ValidatePagination(5, 20, true)
 
@@ -56,6 +124,31 @@ func TestValidatePagination_ValidValues(t *testing.T) {
 	}
 }
 
+func TestValidatePagination_CustomLimits(t *testing.T) {
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{DefaultLimit: 25, MaxLimit: 500}, nil)
+
+	tests := []struct {
+		name          string
+		limit         int
+		limitProvided bool
+		expectedLimit int
+	}{
+		{name: "default when not provided", limit: 0, limitProvided: false, expectedLimit: 25},
+		{name: "within custom maximum", limit: 300, limitProvided: true, expectedLimit: 300},
+		{name: "above custom maximum", limit: 1000, limitProvided: true, expectedLimit: 500},
+		{name: "below minimum", limit: 0, limitProvided: true, expectedLimit: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := svc.ValidatePagination(0, tt.limit, tt.limitProvided)
+			if params.Limit != tt.expectedLimit {
+				t.Errorf("expected limit %d, got %d", tt.expectedLimit, params.Limit)
+			}
+		})
+	}
+}
+
 func TestValidatePagination_LimitValidation(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -70,9 +163,12 @@ func TestValidatePagination_LimitValidation(t *testing.T) {
 		{"limit above maximum clamped to 100", 200, true, 100},
 		{"limit at maximum", 100, true, 100},
 		{"valid limit", 50, true, 50},
+		{"limit one above maximum clamped to 100", 101, true, 100},
+		{"limit at max int clamped to 100", math.MaxInt, true, 100},
+		{"limit at min int clamped to 1", math.MinInt, true, 1},
 	}
 
-	svc := NewCatalogService(&mockProductRepository{})
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -85,8 +181,38 @@ func TestValidatePagination_LimitValidation(t *testing.T) {
 	}
 }
 
+func TestValidatePagination_Properties(t *testing.T) {
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
+
+	property := func(offset, limit int, limitProvided bool) bool {
+		params := svc.ValidatePagination(offset, limit, limitProvided)
+
+		if params.Limit < 1 || params.Limit > 100 {
+			return false
+		}
+		if params.Offset != offset {
+			return false
+		}
+		if !limitProvided && params.Limit != 10 {
+			return false
+		}
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+
+	// Extremes are unlikely to be generated at random, so check them explicitly.
+	for _, limit := range []int{math.MinInt, math.MinInt32, math.MaxInt32, math.MaxInt} {
+		if !property(0, limit, true) {
+			t.Errorf("expected limit %d to be clamped to [1, 100], got %d", limit, svc.ValidatePagination(0, limit, true).Limit)
+		}
+	}
+}
+
 func TestValidatePagination_OffsetPassthrough(t *testing.T) {
-	svc := NewCatalogService(&mockProductRepository{})
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
 
 	// Service passes through offset as-is; negative offset validation
 	// is handled at the handler layer (returns 400 Bad Request)
@@ -119,7 +245,7 @@ func TestListProducts_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 	params := PaginationParams{Offset: 0, Limit: 10}
 	filter := FilterParams{}
 
@@ -141,8 +267,8 @@ func TestListProducts_Success(t *testing.T) {
 	if result.Products[0].Code != "PROD001" {
 		t.Errorf("expected code PROD001, got %s", result.Products[0].Code)
 	}
-	if result.Products[0].Price != 10.99 {
-		t.Errorf("expected price 10.99, got %f", result.Products[0].Price)
+	if !result.Products[0].Price.Equal(decimal.RequireFromString("10.99")) {
+		t.Errorf("expected price 10.99, got %s", result.Products[0].Price)
 	}
 	if result.Products[0].Category == nil {
 		t.Fatal("expected category to be present")
@@ -164,7 +290,7 @@ func TestListProducts_RepositoryError(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 	params := PaginationParams{Offset: 0, Limit: 10}
 	filter := FilterParams{}
 
@@ -175,6 +301,45 @@ func TestListProducts_RepositoryError(t *testing.T) {
 	}
 }
 
+func TestCatalogService_KeepsExactPrices(t *testing.T) {
+	// 0.1 + 0.2 is not 0.3 in float64; mapping must keep the decimals as they are.
+	price := decimal.RequireFromString("0.1").Add(decimal.RequireFromString("0.2"))
+	variantPrice := decimal.RequireFromString("10.005")
+	product := models.Product{
+		Code:     "PROD001",
+		Price:    price,
+		Variants: []models.Variant{{Name: "Small", SKU: "SKU001-S", Price: &variantPrice}},
+	}
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			return []models.Product{product}, 1, nil
+		},
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &product, nil
+		},
+	}
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	list, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := list.Products[0].Price.String(); got != "0.3" {
+		t.Errorf("expected listed price 0.3, got %s", got)
+	}
+
+	detail, err := svc.GetProductByCode(context.Background(), "PROD001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := detail.Price.String(); got != "0.3" {
+		t.Errorf("expected detail price 0.3, got %s", got)
+	}
+	if got := detail.Variants[0].Price.String(); got != "10.005" {
+		t.Errorf("expected variant price 10.005, got %s", got)
+	}
+}
+
 func TestGetProductByCode_Success(t *testing.T) {
 	variantPrice := decimal.NewFromFloat(11.99)
 	mockRepo := &mockProductRepository{
@@ -195,7 +360,7 @@ func TestGetProductByCode_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 
 	result, err := svc.GetProductByCode(context.Background(), "PROD001")
 
@@ -206,8 +371,8 @@ func TestGetProductByCode_Success(t *testing.T) {
 	if result.Code != "PROD001" {
 		t.Errorf("expected code PROD001, got %s", result.Code)
 	}
-	if result.Price != 10.99 {
-		t.Errorf("expected price 10.99, got %f", result.Price)
+	if !result.Price.Equal(decimal.RequireFromString("10.99")) {
+		t.Errorf("expected price 10.99, got %s", result.Price)
 	}
 	if result.Category == nil {
 		t.Fatal("expected category to be present")
@@ -222,20 +387,20 @@ func TestGetProductByCode_Success(t *testing.T) {
 	}
 
 	// First variant has its own price
-	if result.Variants[0].Price != 11.99 {
-		t.Errorf("expected variant price 11.99, got %f", result.Variants[0].Price)
+	if !result.Variants[0].Price.Equal(decimal.RequireFromString("11.99")) {
+		t.Errorf("expected variant price 11.99, got %s", result.Variants[0].Price)
 	}
 
 	// Second variant should inherit product price
-	if result.Variants[1].Price != 10.99 {
-		t.Errorf("expected variant to inherit product price 10.99, got %f", result.Variants[1].Price)
+	if !result.Variants[1].Price.Equal(decimal.RequireFromString("10.99")) {
+		t.Errorf("expected variant to inherit product price 10.99, got %s", result.Variants[1].Price)
 	}
 }
 
 func TestGetProductByCode_EmptyCode(t *testing.T) {
 	mockRepo := &mockProductRepository{}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 
 	_, err := svc.GetProductByCode(context.Background(), "")
 
@@ -251,13 +416,87 @@ func TestGetProductByCode_NotFound(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 
 	_, err := svc.GetProductByCode(context.Background(), "INVALID")
 
 	if !errors.Is(err, ErrNotFound) {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected the repository error to be preserved, got %v", err)
+	}
+}
+
+// lookupError is a typed repository error wrapping gorm.ErrRecordNotFound.
+type lookupError struct {
+	code string
+}
+
+func (e *lookupError) Error() string {
+	return "product " + e.code + ": " + gorm.ErrRecordNotFound.Error()
+}
+
+func (e *lookupError) Unwrap() error {
+	return gorm.ErrRecordNotFound
+}
+
+func TestGetProductByCode_NotFoundPreservesErrorChain(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return nil, &lookupError{code: code}
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.GetProductByCode(context.Background(), "MISSING")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	var lookupErr *lookupError
+	if !errors.As(err, &lookupErr) {
+		t.Fatalf("expected errors.As to find *lookupError in %v", err)
+	}
+	if lookupErr.code != "MISSING" {
+		t.Errorf("expected code MISSING, got %s", lookupErr.code)
+	}
+
+	if want := "resource not found: product MISSING: record not found"; err.Error() != want {
+		t.Errorf("expected message %q, got %q", want, err.Error())
+	}
+}
+
+func TestCatalogService_PropagatesContextErrors(t *testing.T) {
+	for _, ctxErr := range []error{context.Canceled, context.DeadlineExceeded} {
+		t.Run(ctxErr.Error(), func(t *testing.T) {
+			mockRepo := &mockProductRepository{
+				getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+					return nil, 0, fmt.Errorf("count products: %w", ctxErr)
+				},
+				getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+					return nil, fmt.Errorf("find product: %w", ctxErr)
+				},
+			}
+
+			svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+			_, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{})
+			if !errors.Is(err, ctxErr) {
+				t.Errorf("ListProducts: expected %v, got %v", ctxErr, err)
+			}
+
+			_, err = svc.GetProductByCode(context.Background(), "PROD001")
+			if !errors.Is(err, ctxErr) {
+				t.Errorf("GetProductByCode: expected %v, got %v", ctxErr, err)
+			}
+			if errors.Is(err, ErrNotFound) {
+				t.Errorf("GetProductByCode: context error must not be reported as not found")
+			}
+		})
+	}
 }
 
 func TestGetProductByCode_RepositoryError(t *testing.T) {
@@ -267,7 +506,7 @@ func TestGetProductByCode_RepositoryError(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 
 	_, err := svc.GetProductByCode(context.Background(), "PROD001")
 
@@ -292,7 +531,7 @@ func TestGetProductByCode_NoCategory(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 
 	result, err := svc.GetProductByCode(context.Background(), "PROD001")
 
@@ -320,7 +559,7 @@ func TestGetProductByCode_AllVariantsInheritPrice(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 
 	result, err := svc.GetProductByCode(context.Background(), "PROD001")
 
@@ -329,8 +568,8 @@ func TestGetProductByCode_AllVariantsInheritPrice(t *testing.T) {
 	}
 
 	for i, v := range result.Variants {
-		if v.Price != 25.00 {
-			t.Errorf("variant %d: expected inherited price 25.00, got %f", i, v.Price)
+		if !v.Price.Equal(decimal.RequireFromString("25.00")) {
+			t.Errorf("variant %d: expected inherited price 25.00, got %s", i, v.Price)
 		}
 	}
 }
@@ -350,7 +589,7 @@ func TestGetProductByCode_VariantWithZeroPrice(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 
 	result, err := svc.GetProductByCode(context.Background(), "PROD001")
 
@@ -359,8 +598,8 @@ func TestGetProductByCode_VariantWithZeroPrice(t *testing.T) {
 	}
 
 	// Variant with explicit 0.00 price should NOT inherit product price
-	if result.Variants[0].Price != 0.00 {
-		t.Errorf("expected variant price 0.00, got %f", result.Variants[0].Price)
+	if !result.Variants[0].Price.Equal(decimal.RequireFromString("0.00")) {
+		t.Errorf("expected variant price 0.00, got %s", result.Variants[0].Price)
 	}
 }
 
@@ -385,7 +624,7 @@ func TestListProducts_WithCategoryFilter(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 	params := PaginationParams{Offset: 0, Limit: 10}
 	filter := FilterParams{Category: "CLOTHING"}
 
@@ -400,6 +639,82 @@ func TestListProducts_WithCategoryFilter(t *testing.T) {
 	}
 }
 
+func TestListProducts_CategoryResolvedToID(t *testing.T) {
+	lookup := &mockCategoryLookup{
+		getCategoryIDByCodeFunc: func(ctx context.Context, code string) (uint, error) {
+			if code != "CLOTHING" {
+				t.Errorf("expected lookup of CLOTHING, got %s", code)
+			}
+			return 4, nil
+		},
+	}
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			// The resolved ID replaces the code, so the repository does not join categories.
+			if filter.Category != "" {
+				t.Errorf("expected no category code, got %s", filter.Category)
+			}
+			if !slices.Equal(filter.CategoryIDs, []uint{4}) {
+				t.Errorf("expected category IDs [4], got %v", filter.CategoryIDs)
+			}
+			return []models.Product{{ID: 1, Code: "PROD001", Price: decimal.NewFromInt(10)}}, 1, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, lookup, CatalogServiceConfig{}, nil)
+
+	result, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{Category: "CLOTHING"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 1 {
+		t.Errorf("expected total 1, got %d", result.Total)
+	}
+}
+
+func TestListProducts_UnknownCategoryMatchesNothing(t *testing.T) {
+	lookup := &mockCategoryLookup{
+		getCategoryIDByCodeFunc: func(ctx context.Context, code string) (uint, error) {
+			return 0, gorm.ErrRecordNotFound
+		},
+	}
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			t.Error("expected no product query for an unknown category")
+			return nil, 0, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, lookup, CatalogServiceConfig{}, nil)
+
+	result, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{Category: "MISSING"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 0 || result.Products == nil || len(result.Products) != 0 {
+		t.Errorf("expected an empty, non-nil page, got %+v", result)
+	}
+}
+
+func TestListProducts_CategoryLookupError(t *testing.T) {
+	lookupErr := errors.New("connection refused")
+	lookup := &mockCategoryLookup{
+		getCategoryIDByCodeFunc: func(ctx context.Context, code string) (uint, error) {
+			return 0, lookupErr
+		},
+	}
+
+	svc := NewCatalogService(&mockProductRepository{}, lookup, CatalogServiceConfig{}, nil)
+
+	_, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{Category: "CLOTHING"})
+
+	if !errors.Is(err, lookupErr) {
+		t.Errorf("expected lookup error, got %v", err)
+	}
+}
+
 func TestListProducts_WithPriceFilter(t *testing.T) {
 	mockRepo := &mockProductRepository{
 		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
@@ -421,7 +736,7 @@ func TestListProducts_WithPriceFilter(t *testing.T) {
 		},
 	}
 
-	svc := NewCatalogService(mockRepo)
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
 	params := PaginationParams{Offset: 0, Limit: 10}
 	price := decimal.NewFromInt(50)
 	filter := FilterParams{PriceLessThan: &price}
@@ -436,3 +751,1415 @@ func TestListProducts_WithPriceFilter(t *testing.T) {
 		t.Errorf("expected total 1, got %d", result.Total)
 	}
 }
+
+func TestListProducts_PriceFilterBoundary(t *testing.T) {
+	priceLessThan := decimal.NewFromFloat(50)
+
+	tests := []struct {
+		name     string
+		price    decimal.Decimal
+		included bool
+	}{
+		{name: "price equal to the filter is excluded", price: decimal.NewFromFloat(50.00), included: false},
+		{name: "price one cent below the filter is included", price: decimal.NewFromFloat(49.99), included: true},
+		{name: "price one cent above the filter is excluded", price: decimal.NewFromFloat(50.01), included: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The repository filters with a strict price < priceLessThan; the mock
+			// applies the same comparison to the one stored product.
+			mockRepo := &mockProductRepository{
+				getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+					if !filter.PriceLessThan.Equal(priceLessThan) {
+						t.Errorf("expected price filter %s, got %s", priceLessThan, filter.PriceLessThan)
+					}
+					if !tt.price.LessThan(*filter.PriceLessThan) {
+						return []models.Product{}, 0, nil
+					}
+					return []models.Product{{ID: 1, Code: "PROD001", Price: tt.price}}, 1, nil
+				},
+			}
+			svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+			result, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{PriceLessThan: &priceLessThan})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if included := len(result.Products) == 1; included != tt.included {
+				t.Errorf("expected product at %s included=%v with priceLessThan=%s, got %v", tt.price, tt.included, priceLessThan, included)
+			}
+			if tt.included && !result.Products[0].Price.Equal(tt.price) {
+				t.Errorf("expected price %s, got %s", tt.price, result.Products[0].Price)
+			}
+		})
+	}
+}
+
+func TestEachProduct_LoadsInBatches(t *testing.T) {
+	var offsets []int
+	repo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			offsets = append(offsets, offset)
+			// Two full batches followed by a partial one.
+			n := limit
+			if offset >= 2*limit {
+				n = 1
+			}
+			products := make([]models.Product, n)
+			for i := range products {
+				products[i] = models.Product{Code: fmt.Sprintf("PROD%d", offset+i)}
+			}
+			return products, int64(2*limit + 1), nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	var codes []string
+	err := svc.EachProduct(context.Background(), func(p *ProductDetailDTO) error {
+		codes = append(codes, p.Code)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(offsets) != 3 || offsets[1] != eachProductBatchSize || offsets[2] != 2*eachProductBatchSize {
+		t.Errorf("expected batches at offsets 0, %d and %d, got %v", eachProductBatchSize, 2*eachProductBatchSize, offsets)
+	}
+	if len(codes) != 2*eachProductBatchSize+1 || codes[len(codes)-1] != fmt.Sprintf("PROD%d", 2*eachProductBatchSize) {
+		t.Errorf("expected %d products ending with PROD%d, got %d", 2*eachProductBatchSize+1, 2*eachProductBatchSize, len(codes))
+	}
+}
+
+func TestEachProduct_StopsAtCallbackError(t *testing.T) {
+	calls := 0
+	repo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			calls++
+			return make([]models.Product, limit), int64(10 * limit), nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	errStop := errors.New("client gone")
+	seen := 0
+	err := svc.EachProduct(context.Background(), func(p *ProductDetailDTO) error {
+		seen++
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected the callback error, got %v", err)
+	}
+	if calls != 1 || seen != 1 {
+		t.Errorf("expected to stop after the first product, got %d queries and %d products", calls, seen)
+	}
+}
+
+func TestListProductSlugs(t *testing.T) {
+	repo := &mockProductRepository{
+		getProductSlugsFunc: func(ctx context.Context, offset, limit int) ([]string, int64, error) {
+			if offset != 100 || limit != 50 {
+				t.Errorf("expected offset 100 and limit 50, got %d and %d", offset, limit)
+			}
+			return []string{"prod101"}, 101, nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	slugs, total, err := svc.ListProductSlugs(context.Background(), 100, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 101 || len(slugs) != 1 || slugs[0] != "prod101" {
+		t.Errorf("expected [prod101] of 101, got %v of %d", slugs, total)
+	}
+}
+
+func TestGetProductBySlug_Success(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getProductBySlugFunc: func(ctx context.Context, slug string) (*models.Product, error) {
+			if slug != "prod001" {
+				t.Errorf("expected slug prod001, got %s", slug)
+			}
+			return &models.Product{ID: 1, Code: "PROD001", Slug: slug, Price: decimal.NewFromInt(10)}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.GetProductBySlug(context.Background(), "prod001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Code != "PROD001" || result.Slug != "prod001" {
+		t.Errorf("unexpected product: code %s, slug %s", result.Code, result.Slug)
+	}
+}
+
+func TestGetProductBySlug_NotFound(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getProductBySlugFunc: func(ctx context.Context, slug string) (*models.Product, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.GetProductBySlug(context.Background(), "missing")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetProductByBarcode_Success(t *testing.T) {
+	barcode := "4006381333931"
+
+	mockRepo := &mockProductRepository{
+		getProductByBarcodeFunc: func(ctx context.Context, got string) (*models.Product, error) {
+			if got != barcode {
+				t.Errorf("expected barcode %s, got %s", barcode, got)
+			}
+			return &models.Product{
+				ID:    1,
+				Code:  "PROD001",
+				Price: decimal.NewFromInt(10),
+				Variants: []models.Variant{
+					{Name: "Variant A", SKU: "SKU001A", Barcode: &barcode},
+					{Name: "Variant B", SKU: "SKU001B"},
+				},
+			}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.GetProductByBarcode(context.Background(), barcode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Code != "PROD001" {
+		t.Errorf("expected code PROD001, got %s", result.Code)
+	}
+	if result.Variants[0].Barcode != barcode {
+		t.Errorf("expected barcode %s, got %q", barcode, result.Variants[0].Barcode)
+	}
+	if result.Variants[1].Barcode != "" {
+		t.Errorf("expected empty barcode, got %q", result.Variants[1].Barcode)
+	}
+}
+
+func TestGetProductByBarcode_NotFound(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getProductByBarcodeFunc: func(ctx context.Context, barcode string) (*models.Product, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.GetProductByBarcode(context.Background(), "0000000000000")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetProductByBarcode_Empty(t *testing.T) {
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.GetProductByBarcode(context.Background(), "")
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestListProducts_WithBrandFilter(t *testing.T) {
+	brand := "Acme"
+
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			if filter.Brand != "acme" {
+				t.Errorf("expected brand filter acme, got %q", filter.Brand)
+			}
+			return []models.Product{
+				{Code: "PROD001", Price: decimal.NewFromInt(10), Brand: &brand},
+				{Code: "PROD002", Price: decimal.NewFromInt(20)},
+			}, 2, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{Brand: "acme"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Products[0].Brand != "Acme" {
+		t.Errorf("expected brand Acme, got %q", result.Products[0].Brand)
+	}
+	if result.Products[1].Brand != "" {
+		t.Errorf("expected empty brand for product without one, got %q", result.Products[1].Brand)
+	}
+}
+
+func TestListProducts_WithUpdatedAfterFilter(t *testing.T) {
+	updatedAfter := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			if filter.UpdatedAfter == nil || !filter.UpdatedAfter.Equal(updatedAfter) {
+				t.Errorf("expected updatedAfter filter %s, got %v", updatedAfter, filter.UpdatedAfter)
+			}
+			return []models.Product{}, 0, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	if _, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{UpdatedAfter: &updatedAfter}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestListProducts_WithMinOrderQtyLessThanFilter(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			if filter.MinOrderQtyLessThan == nil || *filter.MinOrderQtyLessThan != 5 {
+				t.Errorf("expected minOrderQtyLessThan filter 5, got %v", filter.MinOrderQtyLessThan)
+			}
+			return []models.Product{}, 0, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	lessThan := 5
+
+	if _, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{MinOrderQtyLessThan: &lessThan}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestListProducts_WithLabelFilter(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			if filter.Label != models.LabelFeatured {
+				t.Errorf("expected label filter featured, got %q", filter.Label)
+			}
+			return []models.Product{{Code: "PROD001", Label: models.LabelFeatured}}, 1, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{Label: models.LabelFeatured})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Products) != 1 || result.Products[0].Label != models.LabelFeatured {
+		t.Errorf("expected a featured product, got %+v", result.Products)
+	}
+}
+
+func TestListProducts_WithOnSaleFilter(t *testing.T) {
+	salePrice := decimal.NewFromInt(8)
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			if !filter.OnSale {
+				t.Error("expected onSale filter to be set")
+			}
+			return []models.Product{{Code: "PROD001", Price: decimal.NewFromInt(10), SalePrice: &salePrice}}, 1, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.ListProducts(context.Background(), PaginationParams{Limit: 10}, FilterParams{OnSale: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Products) != 1 || result.Products[0].SalePrice == nil || !result.Products[0].SalePrice.Equal(salePrice) {
+		t.Errorf("expected a product on sale at 8, got %+v", result.Products)
+	}
+}
+
+func TestListNewArrivals(t *testing.T) {
+	tests := []struct {
+		name     string
+		days     int
+		expected int
+	}{
+		{"within range", 7, 7},
+		{"below minimum", 0, 1},
+		{"above maximum", 365, MaxNewArrivalsDays},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockProductRepository{
+				getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+					if filter.CreatedWithinDays != tt.expected {
+						t.Errorf("expected a %d day window, got %d", tt.expected, filter.CreatedWithinDays)
+					}
+					if offset != 5 || limit != 10 {
+						t.Errorf("expected offset 5 and limit 10, got %d and %d", offset, limit)
+					}
+					return []models.Product{{Code: "PROD001"}}, 1, nil
+				},
+			}
+
+			svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+			result, err := svc.ListNewArrivals(context.Background(), tt.days, PaginationParams{Offset: 5, Limit: 10})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Total != 1 || len(result.Products) != 1 {
+				t.Errorf("expected one product, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestGetProductByCode_Label(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &models.Product{Code: code, Price: decimal.NewFromInt(10), Label: models.LabelSale}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.GetProductByCode(context.Background(), "PROD001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Label != models.LabelSale {
+		t.Errorf("expected label sale, got %q", result.Label)
+	}
+}
+
+func TestGetProductByCode_Timestamps(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := time.Date(2024, 6, 7, 8, 9, 10, 0, time.UTC)
+
+	mockRepo := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &models.Product{Code: code, Price: decimal.NewFromInt(10), CreatedAt: createdAt, UpdatedAt: updatedAt}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.GetProductByCode(context.Background(), "PROD001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.CreatedAt.Equal(createdAt) {
+		t.Errorf("expected created_at %s, got %s", createdAt, result.CreatedAt)
+	}
+	if !result.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("expected updated_at %s, got %s", updatedAt, result.UpdatedAt)
+	}
+}
+
+func TestListProducts_WithInStockFilter(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			// Verify in-stock filter is passed correctly
+			if !filter.InStock {
+				t.Error("expected in-stock filter to be set")
+			}
+			return []models.Product{}, 0, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	params := PaginationParams{Offset: 0, Limit: 10}
+	filter := FilterParams{InStock: true}
+
+	if _, err := svc.ListProducts(context.Background(), params, filter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetProductByCode_IncludesStockQuantity(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &models.Product{
+				ID:    1,
+				Code:  "PROD001",
+				Price: decimal.NewFromFloat(10.00),
+				Variants: []models.Variant{
+					{Name: "Variant A", SKU: "SKU001A", StockQuantity: 7},
+				},
+			}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.GetProductByCode(context.Background(), "PROD001")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Variants[0].StockQuantity != 7 {
+		t.Errorf("expected stock quantity 7, got %d", result.Variants[0].StockQuantity)
+	}
+}
+
+func TestAdjustVariantStock_Success(t *testing.T) {
+	stock := 5
+	mockRepo := &mockProductRepository{
+		adjustStockFunc: func(ctx context.Context, code, sku string, delta int) error {
+			if code != "PROD001" || sku != "SKU001A" {
+				t.Errorf("unexpected code/sku passed to repo: %s/%s", code, sku)
+			}
+			stock += delta
+			return nil
+		},
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &models.Product{
+				ID:    1,
+				Code:  "PROD001",
+				Price: decimal.NewFromFloat(10.00),
+				Variants: []models.Variant{
+					{Name: "Variant A", SKU: "SKU001A", StockQuantity: stock},
+					{Name: "Variant B", SKU: "SKU001B"},
+				},
+			}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.AdjustVariantStock(context.Background(), "PROD001", "SKU001A", 3)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.SKU != "SKU001A" {
+		t.Errorf("expected SKU001A, got %s", result.SKU)
+	}
+	if result.StockQuantity != 8 {
+		t.Errorf("expected stock quantity 8, got %d", result.StockQuantity)
+	}
+}
+
+func TestAdjustVariantStock_ZeroDelta(t *testing.T) {
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.AdjustVariantStock(context.Background(), "PROD001", "SKU001A", 0)
+
+	if !errors.Is(err, ErrInvalidStockDelta) {
+		t.Errorf("expected ErrInvalidStockDelta, got %v", err)
+	}
+}
+
+func TestAdjustVariantStock_InvalidSKU(t *testing.T) {
+	repo := &mockProductRepository{
+		adjustStockFunc: func(ctx context.Context, code, sku string, delta int) error {
+			t.Error("expected the repository not to be called")
+			return nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.AdjustVariantStock(context.Background(), "PROD001", "sku 001a", 1)
+
+	if !errors.Is(err, ErrInvalidSKU) {
+		t.Errorf("expected ErrInvalidSKU, got %v", err)
+	}
+}
+
+func TestAdjustVariantStock_NotFound(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		adjustStockFunc: func(ctx context.Context, code, sku string, delta int) error {
+			return gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.AdjustVariantStock(context.Background(), "PROD001", "MISSING", 1)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAdjustVariantStock_InsufficientStock(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		adjustStockFunc: func(ctx context.Context, code, sku string, delta int) error {
+			return models.ErrInsufficientStock
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.AdjustVariantStock(context.Background(), "PROD001", "SKU001A", -100)
+
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("expected ErrInsufficientStock, got %v", err)
+	}
+}
+
+func TestUpdateProduct_Success(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			if update.Price == nil {
+				t.Fatal("expected price to be passed to repo")
+			}
+			return &models.Product{ID: 1, Code: code, Price: *update.Price}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	price := decimal.NewFromFloat(12.99)
+
+	result, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{Price: &price})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Price.Equal(decimal.RequireFromString("12.99")) {
+		t.Errorf("expected price 12.99, got %s", result.Price)
+	}
+}
+
+func TestUpdateProduct_Brand(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			if update.Price != nil {
+				t.Errorf("expected price to be left unchanged, got %s", update.Price)
+			}
+			if update.Brand == nil || *update.Brand != "Acme" {
+				t.Fatalf("expected trimmed brand Acme, got %v", update.Brand)
+			}
+			return &models.Product{ID: 1, Code: code, Price: decimal.NewFromInt(10), Brand: update.Brand}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	brand := "  Acme "
+
+	result, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{Brand: &brand})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Brand != "Acme" {
+		t.Errorf("expected brand Acme, got %q", result.Brand)
+	}
+}
+
+func TestUpdateProduct_ImageURL(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			if update.ImageURL == nil || *update.ImageURL != "https://cdn.example.com/prod001.jpg" {
+				t.Fatalf("expected trimmed image URL, got %v", update.ImageURL)
+			}
+			return &models.Product{ID: 1, Code: code, Price: decimal.NewFromInt(10), ImageURL: update.ImageURL}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	imageURL := " https://cdn.example.com/prod001.jpg "
+
+	result, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{ImageURL: &imageURL})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ImageURL != "https://cdn.example.com/prod001.jpg" {
+		t.Errorf("expected image URL https://cdn.example.com/prod001.jpg, got %q", result.ImageURL)
+	}
+}
+
+func TestUpdateProduct_EmptyImageURLClearsImage(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			if update.ImageURL == nil || *update.ImageURL != "" {
+				t.Fatalf("expected an empty image URL, got %v", update.ImageURL)
+			}
+			return &models.Product{ID: 1, Code: code, Price: decimal.NewFromInt(10)}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	imageURL := "  "
+
+	result, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{ImageURL: &imageURL})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ImageURL != "" {
+		t.Errorf("expected no image URL, got %q", result.ImageURL)
+	}
+}
+
+func TestUpdateProduct_InvalidImageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"relative", "/images/prod001.jpg"},
+		{"unsupported scheme", "ftp://cdn.example.com/prod001.jpg"},
+		{"missing host", "https://"},
+		{"too long", "https://cdn.example.com/" + strings.Repeat("a", maxImageURLLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockProductRepository{
+				updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+					t.Fatal("repository should not be called for invalid image URLs")
+					return nil, nil
+				},
+			}
+
+			svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+			_, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{ImageURL: &tt.url})
+
+			if !errors.Is(err, ErrInvalidProductImage) {
+				t.Errorf("expected ErrInvalidProductImage, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateProduct_OrderQuantity(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			if update.MinOrderQty == nil || *update.MinOrderQty != 2 {
+				t.Fatalf("expected min order quantity 2, got %v", update.MinOrderQty)
+			}
+			if update.MaxOrderQty == nil || *update.MaxOrderQty != 10 {
+				t.Fatalf("expected max order quantity 10, got %v", update.MaxOrderQty)
+			}
+			return &models.Product{ID: 1, Code: code, Price: decimal.NewFromInt(10), MinOrderQty: *update.MinOrderQty, MaxOrderQty: update.MaxOrderQty}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	min, max := 2, 10
+
+	result, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{MinOrderQty: &min, MaxOrderQty: &max})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.MinOrderQty != 2 {
+		t.Errorf("expected min order quantity 2, got %d", result.MinOrderQty)
+	}
+	if result.MaxOrderQty == nil || *result.MaxOrderQty != 10 {
+		t.Errorf("expected max order quantity 10, got %v", result.MaxOrderQty)
+	}
+}
+
+func TestUpdateProduct_ZeroMaxOrderQtyRemovesLimit(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			if update.MaxOrderQty == nil || *update.MaxOrderQty != 0 {
+				t.Fatalf("expected max order quantity 0 to be passed on, got %v", update.MaxOrderQty)
+			}
+			return &models.Product{ID: 1, Code: code, Price: decimal.NewFromInt(10), MinOrderQty: 3}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	max := 0
+
+	result, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{MaxOrderQty: &max})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MaxOrderQty != nil {
+		t.Errorf("expected no max order quantity, got %v", *result.MaxOrderQty)
+	}
+}
+
+func TestUpdateProduct_InvalidOrderQuantity(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name  string
+		input UpdateProductInput
+	}{
+		{"zero min", UpdateProductInput{MinOrderQty: intPtr(0)}},
+		{"negative min", UpdateProductInput{MinOrderQty: intPtr(-3)}},
+		{"negative max", UpdateProductInput{MaxOrderQty: intPtr(-1)}},
+		{"max below min", UpdateProductInput{MinOrderQty: intPtr(5), MaxOrderQty: intPtr(4)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockProductRepository{
+				updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+					t.Fatal("repository should not be called for invalid order quantities")
+					return nil, nil
+				},
+			}
+
+			svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+			_, err := svc.UpdateProduct(context.Background(), "PROD001", tt.input)
+
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateProduct_OrderQuantityConflictsWithStored(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			return nil, models.ErrInvalidOrderQuantity
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	max := 2
+
+	_, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{MaxOrderQty: &max})
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestUpdateProduct_SalePrice(t *testing.T) {
+	salePrice := decimal.RequireFromString("7.50")
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			if update.SalePrice == nil || !update.SalePrice.Equal(salePrice) {
+				t.Fatalf("expected sale price 7.50, got %v", update.SalePrice)
+			}
+			return &models.Product{Code: code, Price: decimal.NewFromInt(10), SalePrice: update.SalePrice}, nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+	result, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{SalePrice: &salePrice})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.SalePrice == nil || !result.SalePrice.Equal(salePrice) {
+		t.Errorf("expected sale price 7.50, got %v", result.SalePrice)
+	}
+}
+
+func TestUpdateProduct_InvalidSalePrice(t *testing.T) {
+	decimalPtr := func(v string) *decimal.Decimal {
+		d := decimal.RequireFromString(v)
+		return &d
+	}
+
+	tests := []struct {
+		name  string
+		input UpdateProductInput
+	}{
+		{"negative", UpdateProductInput{SalePrice: decimalPtr("-1")}},
+		{"equal to price", UpdateProductInput{Price: decimalPtr("10"), SalePrice: decimalPtr("10")}},
+		{"above price", UpdateProductInput{Price: decimalPtr("10"), SalePrice: decimalPtr("12")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockProductRepository{
+				updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+					t.Fatal("repository should not be called for invalid sale prices")
+					return nil, nil
+				},
+			}
+
+			svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+
+			_, err := svc.UpdateProduct(context.Background(), "PROD001", tt.input)
+
+			if !errors.Is(err, ErrInvalidSalePrice) {
+				t.Errorf("expected ErrInvalidSalePrice, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateProduct_SalePriceConflictsWithStored(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			return nil, models.ErrInvalidSalePrice
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	salePrice := decimal.NewFromInt(50)
+
+	_, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{SalePrice: &salePrice})
+
+	if !errors.Is(err, ErrInvalidSalePrice) {
+		t.Errorf("expected ErrInvalidSalePrice, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected the error to wrap ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestUpdateProduct_MissingPrice(t *testing.T) {
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
+
+	_, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{})
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestUpdateProduct_NegativePrice(t *testing.T) {
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
+	price := decimal.NewFromInt(-1)
+
+	_, err := svc.UpdateProduct(context.Background(), "PROD001", UpdateProductInput{Price: &price})
+
+	if !errors.Is(err, ErrInvalidProductPrice) {
+		t.Errorf("expected ErrInvalidProductPrice, got %v", err)
+	}
+}
+
+func TestUpdateProduct_NotFound(t *testing.T) {
+	mockRepo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	price := decimal.NewFromFloat(12.99)
+
+	_, err := svc.UpdateProduct(context.Background(), "MISSING", UpdateProductInput{Price: &price})
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// benchmarkProducts returns n products with a category and two variants each.
+func benchmarkProducts(n int) []models.Product {
+	category := &models.Category{Code: "CLOTHING", Name: "Clothing"}
+	variantPrice := decimal.NewFromFloat(11.99)
+
+	products := make([]models.Product, n)
+	for i := range products {
+		products[i] = models.Product{
+			ID:       uint(i + 1),
+			Code:     fmt.Sprintf("PROD%03d", i+1),
+			Price:    decimal.NewFromFloat(10.99),
+			Category: category,
+			Variants: []models.Variant{
+				{Name: "Small", SKU: fmt.Sprintf("SKU%03d-S", i+1), Price: &variantPrice},
+				{Name: "Large", SKU: fmt.Sprintf("SKU%03d-L", i+1)},
+			},
+		}
+	}
+	return products
+}
+
+func BenchmarkListProducts(b *testing.B) {
+	products := benchmarkProducts(100)
+	mockRepo := &mockProductRepository{
+		getAllProductsFunc: func(ctx context.Context, offset, limit int, filter models.ProductFilter) ([]models.Product, int64, error) {
+			return products, int64(len(products)), nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	ctx := context.Background()
+	params := PaginationParams{Offset: 0, Limit: 100}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := svc.ListProducts(ctx, params, FilterParams{}); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkGetProductByCode(b *testing.B) {
+	products := benchmarkProducts(100)
+	mockRepo := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &products[0], nil
+		},
+	}
+
+	svc := NewCatalogService(mockRepo, nil, CatalogServiceConfig{}, nil)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := svc.GetProductByCode(ctx, "PROD001"); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestBulkCreateProducts_Success(t *testing.T) {
+	var created []models.Product
+	repo := &mockProductRepository{
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			for i := range products {
+				products[i].ID = uint(i + 1)
+			}
+			created = products
+			return nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	brand := "  Acme  "
+	details, bulkErrs, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: " PROD001 ", Price: decimal.NewFromFloat(10.5), Brand: &brand},
+		{Code: "PROD002", Price: decimal.NewFromInt(20)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bulkErrs != nil {
+		t.Errorf("expected no bulk errors, got %v", bulkErrs)
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("expected 2 products passed to repository, got %d", len(created))
+	}
+	if created[0].Code != "PROD001" {
+		t.Errorf("expected trimmed code PROD001, got %q", created[0].Code)
+	}
+	if created[0].Brand == nil || *created[0].Brand != "Acme" {
+		t.Errorf("expected trimmed brand Acme, got %v", created[0].Brand)
+	}
+	if created[1].Brand != nil {
+		t.Errorf("expected nil brand, got %v", *created[1].Brand)
+	}
+
+	if len(details) != 2 {
+		t.Fatalf("expected 2 details, got %d", len(details))
+	}
+	if details[1].Code != "PROD002" || !details[1].Price.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("unexpected second detail: %+v", details[1])
+	}
+	if details[0].MinOrderQty != 1 {
+		t.Errorf("expected default min order quantity 1, got %d", details[0].MinOrderQty)
+	}
+	if created[0].Label != models.LabelNone || details[0].Label != models.LabelNone {
+		t.Errorf("expected default label none, got %q", created[0].Label)
+	}
+}
+
+func TestBulkCreateProducts_OrderQuantity(t *testing.T) {
+	var created []models.Product
+	repo := &mockProductRepository{
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			created = products
+			return nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+	min, max := 2, 10
+
+	details, _, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD001", Price: decimal.NewFromInt(10), MinOrderQty: &min, MaxOrderQty: &max},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(created) != 1 || created[0].MinOrderQty != 2 || created[0].MaxOrderQty == nil || *created[0].MaxOrderQty != 10 {
+		t.Fatalf("expected min 2 and max 10 passed to repository, got %+v", created)
+	}
+	if details[0].MinOrderQty != 2 || details[0].MaxOrderQty == nil || *details[0].MaxOrderQty != 10 {
+		t.Errorf("expected min 2 and max 10 in details, got %d and %v", details[0].MinOrderQty, details[0].MaxOrderQty)
+	}
+}
+
+func TestBulkCreateProducts_InvalidOrderQuantity(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name  string
+		input CreateProductInput
+	}{
+		{"zero min", CreateProductInput{MinOrderQty: intPtr(0)}},
+		{"negative min", CreateProductInput{MinOrderQty: intPtr(-3)}},
+		{"max below default min", CreateProductInput{MaxOrderQty: intPtr(0)}},
+		{"max below min", CreateProductInput{MinOrderQty: intPtr(5), MaxOrderQty: intPtr(4)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockProductRepository{
+				bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+					t.Fatal("repository should not be called for invalid order quantities")
+					return nil
+				},
+			}
+			svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+			input := tt.input
+			input.Code, input.Price = "PROD002", decimal.NewFromInt(10)
+			_, bulkErrs, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+				{Code: "PROD001", Price: decimal.NewFromInt(10)},
+				input,
+			})
+
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+			if len(bulkErrs) != 1 || bulkErrs[0].Index != 1 || bulkErrs[0].Code != "PROD002" || !errors.Is(bulkErrs[0].Err, ErrInvalidInput) {
+				t.Errorf("expected a single ErrInvalidInput bulk error for PROD002, got %+v", bulkErrs)
+			}
+		})
+	}
+}
+
+func TestBulkCreateProducts_Label(t *testing.T) {
+	var created []models.Product
+	repo := &mockProductRepository{
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			created = products
+			return nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	_, _, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD001", Price: decimal.NewFromInt(10), Label: models.LabelFeatured},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created) != 1 || created[0].Label != models.LabelFeatured {
+		t.Errorf("expected a featured product, got %+v", created)
+	}
+
+	_, bulkErrs, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD002", Price: decimal.NewFromInt(10), Label: "bestseller"},
+	})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+	if len(bulkErrs) != 1 || !errors.Is(bulkErrs[0].Err, ErrInvalidLabel) {
+		t.Errorf("expected ErrInvalidLabel for the input, got %+v", bulkErrs)
+	}
+}
+
+func TestBulkCreateProducts_Empty(t *testing.T) {
+	svc := NewCatalogService(&mockProductRepository{}, nil, CatalogServiceConfig{}, nil)
+
+	_, _, err := svc.BulkCreateProducts(context.Background(), nil)
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestBulkCreateProducts_InvalidInputs(t *testing.T) {
+	salePrice := decimal.NewFromInt(10)
+	called := false
+	repo := &mockProductRepository{
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			called = true
+			return nil
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	details, bulkErrs, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD001", Price: decimal.NewFromInt(10)},
+		{Code: "  ", Price: decimal.NewFromInt(10)},
+		{Code: "PROD003", Price: decimal.NewFromInt(-1)},
+		{Code: "PROD001", Price: decimal.NewFromInt(10)},
+		{Code: "prod@005", Price: decimal.NewFromInt(10)},
+		{Code: "PROD006", Price: decimal.NewFromInt(10), SalePrice: &salePrice},
+	})
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+	if details != nil {
+		t.Errorf("expected no details, got %v", details)
+	}
+	if called {
+		t.Error("expected repository not to be called")
+	}
+
+	expected := []BulkError{
+		{Index: 1, Code: "", Err: ErrInvalidProductCode},
+		{Index: 2, Code: "PROD003", Err: ErrInvalidProductPrice},
+		{Index: 3, Code: "PROD001", Err: ErrDuplicateCode},
+		{Index: 4, Code: "prod@005", Err: ErrInvalidProductCode},
+		{Index: 5, Code: "PROD006", Err: ErrInvalidSalePrice},
+	}
+	if len(bulkErrs) != len(expected) {
+		t.Fatalf("expected %d bulk errors, got %v", len(expected), bulkErrs)
+	}
+	for i, want := range expected {
+		got := bulkErrs[i]
+		if got.Index != want.Index || got.Code != want.Code || !errors.Is(got.Err, want.Err) {
+			t.Errorf("bulk error %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestBulkCreateProducts_DuplicateCodeInRepository(t *testing.T) {
+	repo := &mockProductRepository{
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			return &models.RowError{Index: 1, Err: fmt.Errorf("%w: %s", models.ErrDuplicateCode, products[1].Code)}
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	details, bulkErrs, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD001", Price: decimal.NewFromInt(10)},
+		{Code: "PROD002", Price: decimal.NewFromInt(10)},
+	})
+
+	if !errors.Is(err, ErrDuplicateCode) {
+		t.Errorf("expected ErrDuplicateCode, got %v", err)
+	}
+	if details != nil {
+		t.Errorf("expected no details, got %v", details)
+	}
+	if len(bulkErrs) != 1 || bulkErrs[0].Index != 1 || bulkErrs[0].Code != "PROD002" {
+		t.Errorf("expected bulk error for row 1 PROD002, got %+v", bulkErrs)
+	}
+}
+
+func TestBulkCreateProducts_NegativePriceInRepository(t *testing.T) {
+	repo := &mockProductRepository{
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			return &models.RowError{Index: 0, Err: models.ErrNegativePrice}
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	_, bulkErrs, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD001", Price: decimal.NewFromInt(10)},
+	})
+
+	if !errors.Is(err, ErrInvalidProductPrice) {
+		t.Errorf("expected ErrInvalidProductPrice, got %v", err)
+	}
+	if len(bulkErrs) != 1 || bulkErrs[0].Index != 0 || !errors.Is(bulkErrs[0].Err, ErrInvalidProductPrice) {
+		t.Errorf("expected bulk error for row 0 with ErrInvalidProductPrice, got %+v", bulkErrs)
+	}
+}
+
+func TestBulkCreateProducts_RepositoryError(t *testing.T) {
+	repoErr := errors.New("connection refused")
+	repo := &mockProductRepository{
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			return repoErr
+		},
+	}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, nil)
+
+	_, bulkErrs, err := svc.BulkCreateProducts(context.Background(), []CreateProductInput{
+		{Code: "PROD001", Price: decimal.NewFromInt(10)},
+	})
+
+	if !errors.Is(err, repoErr) {
+		t.Errorf("expected repository error, got %v", err)
+	}
+	if bulkErrs != nil {
+		t.Errorf("expected no bulk errors, got %v", bulkErrs)
+	}
+}
+
+func TestValidateProductCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{name: "uppercase alphanumeric", code: "PROD001", wantErr: false},
+		{name: "underscore and hyphen", code: "PROD_001-XL", wantErr: false},
+		{name: "digits only", code: "12345", wantErr: false},
+		{name: "empty", code: "", wantErr: true},
+		{name: "lowercase", code: "prod001", wantErr: true},
+		{name: "space", code: "PRO D 001", wantErr: true},
+		{name: "special character", code: "PROD@001", wantErr: true},
+		{name: "slash", code: "PROD/001", wantErr: true},
+		{name: "trailing newline", code: "PROD001\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProductCode(tt.code)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidProductCode) {
+				t.Errorf("expected ErrInvalidProductCode, got %v", err)
+			}
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected error to wrap ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateSKU(t *testing.T) {
+	tests := []struct {
+		name    string
+		sku     string
+		wantErr bool
+	}{
+		{name: "uppercase alphanumeric", sku: "SKU001", wantErr: false},
+		{name: "size suffix", sku: "SKU001-42", wantErr: false},
+		{name: "underscore", sku: "SKU_001_XL", wantErr: false},
+		{name: "empty", sku: "", wantErr: true},
+		{name: "lowercase", sku: "sku001-42", wantErr: true},
+		{name: "space", sku: "SKU 001", wantErr: true},
+		{name: "special character", sku: "SKU#001", wantErr: true},
+		{name: "dot", sku: "SKU.001", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSKU(tt.sku)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidSKU) {
+				t.Errorf("expected ErrInvalidSKU, got %v", err)
+			}
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected error to wrap ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateLabel(t *testing.T) {
+	tests := []struct {
+		label   string
+		wantErr bool
+	}{
+		{label: "new", wantErr: false},
+		{label: "sale", wantErr: false},
+		{label: "featured", wantErr: false},
+		{label: "none", wantErr: false},
+		{label: "", wantErr: true},
+		{label: "Featured", wantErr: true},
+		{label: "clearance", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			err := ValidateLabel(tt.label)
+			if tt.wantErr && !errors.Is(err, ErrInvalidLabel) {
+				t.Errorf("expected ErrInvalidLabel, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCatalogService_PublishesProductEvents(t *testing.T) {
+	price := decimal.NewFromInt(20)
+	repo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			return &models.Product{Code: code, Price: *update.Price}, nil
+		},
+		bulkCreateFunc: func(ctx context.Context, products []models.Product) error {
+			return nil
+		},
+		adjustStockFunc: func(ctx context.Context, code, sku string, delta int) error {
+			return nil
+		},
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &models.Product{Code: code, Variants: []models.Variant{{SKU: "SKU001A", StockQuantity: 3}}}, nil
+		},
+	}
+	bus := &recordingBus{}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, bus)
+	ctx := context.Background()
+
+	if _, err := svc.UpdateProduct(ctx, "PROD001", UpdateProductInput{Price: &price}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := svc.BulkCreateProducts(ctx, []CreateProductInput{
+		{Code: "PROD010", Price: price},
+		{Code: "PROD011", Price: price},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AdjustVariantStock(ctx, "PROD002", "SKU001A", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []events.Event{
+		{Name: events.ProductUpdated, Payload: ProductEvent{Code: "PROD001"}},
+		{Name: events.ProductCreated, Payload: ProductEvent{Code: "PROD010"}},
+		{Name: events.ProductCreated, Payload: ProductEvent{Code: "PROD011"}},
+		{Name: events.ProductUpdated, Payload: ProductEvent{Code: "PROD002"}},
+	}
+	if !reflect.DeepEqual(bus.published, expected) {
+		t.Errorf("expected events %+v, got %+v", expected, bus.published)
+	}
+}
+
+func TestCatalogService_FailedChangePublishesNothing(t *testing.T) {
+	repo := &mockProductRepository{
+		updateProductFunc: func(ctx context.Context, code string, update models.ProductUpdate) (*models.Product, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		adjustStockFunc: func(ctx context.Context, code, sku string, delta int) error {
+			return models.ErrInsufficientStock
+		},
+	}
+	bus := &recordingBus{}
+	svc := NewCatalogService(repo, nil, CatalogServiceConfig{}, bus)
+	price := decimal.NewFromInt(20)
+
+	if _, err := svc.UpdateProduct(context.Background(), "MISSING", UpdateProductInput{Price: &price}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if _, err := svc.AdjustVariantStock(context.Background(), "PROD001", "SKU001A", -100); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(bus.published) != 0 {
+		t.Errorf("expected no events, got %+v", bus.published)
+	}
+}
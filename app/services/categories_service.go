@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"github.com/mytheresa/go-hiring-challenge/app/events"
 	"github.com/mytheresa/go-hiring-challenge/models"
+	"gorm.io/gorm"
 )
 
 // CreateCategoryInput represents the input for creating a category.
@@ -12,53 +16,187 @@ type CreateCategoryInput struct {
 	Name string
 }
 
+// UpdateCategoryInput represents the input for updating a category.
+// Code is the code sent in the request body, if any; it must match the category being updated.
+type UpdateCategoryInput struct {
+	Code *string
+	Name string
+}
+
+// DefaultCategorySort is the category sort order used when none is requested.
+const DefaultCategorySort = "code_asc"
+
+// categorySortOrders maps the supported sort parameters to ORDER BY clauses.
+var categorySortOrders = map[string]string{
+	"code_asc":  "categories.code ASC",
+	"code_desc": "categories.code DESC",
+	"name_asc":  "categories.name ASC",
+	"name_desc": "categories.name DESC",
+}
+
 // CategoryRepository defines the interface for category data access.
 type CategoryRepository interface {
-	GetAllCategories(ctx context.Context) ([]models.Category, error)
+	GetAllCategories(ctx context.Context, order string) ([]models.Category, error)
 	CreateCategory(ctx context.Context, code, name string) (*models.Category, error)
+	UpdateCategory(ctx context.Context, code, name string) (*models.Category, error)
+	DeleteCategory(ctx context.Context, code string) error
+	RestoreCategory(ctx context.Context, code string) (*models.Category, error)
+}
+
+// CategoryEvent is the payload of category events.
+type CategoryEvent struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
 }
 
 // CategoriesService handles category business logic.
 type CategoriesService struct {
 	repo CategoryRepository
+	bus  events.Bus
 }
 
 // NewCategoriesService creates a new CategoriesService instance.
-func NewCategoriesService(repo CategoryRepository) *CategoriesService {
-	return &CategoriesService{repo: repo}
+// Category events are published on bus, which may be nil to publish none.
+func NewCategoriesService(repo CategoryRepository, bus events.Bus) *CategoriesService {
+	return &CategoriesService{repo: repo, bus: bus}
 }
 
-// ListCategories retrieves all categories.
-func (s *CategoriesService) ListCategories(ctx context.Context) ([]CategoryDTO, error) {
-	categories, err := s.repo.GetAllCategories(ctx)
+// ListCategories retrieves all categories in the given sort order.
+// An empty sort falls back to DefaultCategorySort; unsupported values return ErrInvalidSort.
+func (s *CategoriesService) ListCategories(ctx context.Context, sort string) ([]CategoryDTO, error) {
+	if sort == "" {
+		sort = DefaultCategorySort
+	}
+	order, ok := categorySortOrders[sort]
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+
+	categories, err := s.repo.GetAllCategories(ctx, order)
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]CategoryDTO, len(categories))
 	for i, c := range categories {
-		result[i] = CategoryDTO{
-			Code: c.Code,
-			Name: c.Name,
-		}
+		result[i] = mapCategoryToDTO(c)
 	}
 
 	return result, nil
 }
 
 // CreateCategory creates a new category after validating input.
+// The code is trimmed and uppercased and the name trimmed before validation, so
+// " clothing " and "CLOTHING" refer to the same category.
+// Returns ErrDuplicateCode if a category with the same code already exists.
+// An events.CategoryCreated event is published once the category is created.
 func (s *CategoriesService) CreateCategory(ctx context.Context, input CreateCategoryInput) (*CategoryDTO, error) {
-	if input.Code == "" || input.Name == "" {
+	code := normalizeCategoryCode(input.Code)
+	name := strings.TrimSpace(input.Name)
+	if code == "" || name == "" {
 		return nil, ErrInvalidCategoryInput
 	}
 
-	category, err := s.repo.CreateCategory(ctx, input.Code, input.Name)
+	category, err := s.repo.CreateCategory(ctx, code, name)
 	if err != nil {
+		if errors.Is(err, models.ErrDuplicateCode) {
+			return nil, ErrDuplicateCode
+		}
+		if errors.Is(err, models.ErrInvalidCategoryInput) {
+			return nil, ErrInvalidCategoryInput
+		}
 		return nil, err
 	}
 
-	return &CategoryDTO{
-		Code: category.Code,
-		Name: category.Name,
-	}, nil
+	publish(ctx, s.bus, events.CategoryCreated, CategoryEvent{Code: category.Code, Name: category.Name})
+
+	dto := mapCategoryToDTO(*category)
+	return &dto, nil
+}
+
+// UpdateCategory changes the name of a category. Category codes are immutable:
+// returns ErrCodeImmutable if input.Code is set and differs from code, compared
+// trimmed and uppercased like CreateCategory does, and ErrNotFound if no active
+// category has the given code.
+// An events.CategoryUpdated event is published once the category is renamed.
+func (s *CategoriesService) UpdateCategory(ctx context.Context, code string, input UpdateCategoryInput) (*CategoryDTO, error) {
+	if code == "" {
+		return nil, ErrInvalidInput
+	}
+	if input.Code != nil && normalizeCategoryCode(*input.Code) != normalizeCategoryCode(code) {
+		return nil, ErrCodeImmutable
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, ErrInvalidCategoryName
+	}
+
+	category, err := s.repo.UpdateCategory(ctx, code, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	publish(ctx, s.bus, events.CategoryUpdated, CategoryEvent{Code: category.Code, Name: category.Name})
+
+	dto := mapCategoryToDTO(*category)
+	return &dto, nil
+}
+
+// DeleteCategory soft-deletes a category by its code. Its products keep referencing it.
+// Returns ErrNotFound if no active category has the given code, or
+// ErrCategoryInUse if the database refuses the change with a foreign key violation.
+// An events.CategoryDeleted event is published once the category is deleted.
+func (s *CategoriesService) DeleteCategory(ctx context.Context, code string) error {
+	if code == "" {
+		return ErrInvalidInput
+	}
+
+	if err := s.repo.DeleteCategory(ctx, code); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ErrNotFound
+		case errors.Is(err, models.ErrCategoryInUse):
+			return ErrCategoryInUse
+		}
+		return err
+	}
+
+	publish(ctx, s.bus, events.CategoryDeleted, CategoryEvent{Code: code})
+
+	return nil
+}
+
+// RestoreCategory restores a soft-deleted category by its code.
+// Returns ErrNotFound if no deleted category has the given code.
+func (s *CategoriesService) RestoreCategory(ctx context.Context, code string) (*CategoryDTO, error) {
+	if code == "" {
+		return nil, ErrInvalidInput
+	}
+
+	category, err := s.repo.RestoreCategory(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	dto := mapCategoryToDTO(*category)
+	return &dto, nil
+}
+
+// normalizeCategoryCode trims and uppercases a category code.
+func normalizeCategoryCode(code string) string {
+	return strings.TrimSpace(strings.ToUpper(code))
+}
+
+func mapCategoryToDTO(c models.Category) CategoryDTO {
+	return CategoryDTO{
+		Code:         c.Code,
+		Name:         c.Name,
+		ProductCount: c.ProductCount,
+	}
 }
@@ -3,20 +3,26 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/mytheresa/go-hiring-challenge/app/events"
 	"github.com/mytheresa/go-hiring-challenge/models"
+	"gorm.io/gorm"
 )
 
 // mockCategoryRepository is a mock implementation of CategoryRepository for testing.
 type mockCategoryRepository struct {
-	getAllCategoriesFunc func(ctx context.Context) ([]models.Category, error)
+	getAllCategoriesFunc func(ctx context.Context, order string) ([]models.Category, error)
 	createCategoryFunc   func(ctx context.Context, code, name string) (*models.Category, error)
+	updateCategoryFunc   func(ctx context.Context, code, name string) (*models.Category, error)
+	deleteCategoryFunc   func(ctx context.Context, code string) error
+	restoreCategoryFunc  func(ctx context.Context, code string) (*models.Category, error)
 }
 
-func (m *mockCategoryRepository) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+func (m *mockCategoryRepository) GetAllCategories(ctx context.Context, order string) ([]models.Category, error) {
 	if m.getAllCategoriesFunc != nil {
-		return m.getAllCategoriesFunc(ctx)
+		return m.getAllCategoriesFunc(ctx, order)
 	}
 	return nil, errors.New("not implemented")
 }
@@ -28,20 +34,41 @@ func (m *mockCategoryRepository) CreateCategory(ctx context.Context, code, name
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockCategoryRepository) UpdateCategory(ctx context.Context, code, name string) (*models.Category, error) {
+	if m.updateCategoryFunc != nil {
+		return m.updateCategoryFunc(ctx, code, name)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCategoryRepository) DeleteCategory(ctx context.Context, code string) error {
+	if m.deleteCategoryFunc != nil {
+		return m.deleteCategoryFunc(ctx, code)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepository) RestoreCategory(ctx context.Context, code string) (*models.Category, error) {
+	if m.restoreCategoryFunc != nil {
+		return m.restoreCategoryFunc(ctx, code)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func TestListCategories_Success(t *testing.T) {
 	mockRepo := &mockCategoryRepository{
-		getAllCategoriesFunc: func(ctx context.Context) ([]models.Category, error) {
+		getAllCategoriesFunc: func(ctx context.Context, order string) ([]models.Category, error) {
 			return []models.Category{
-				{ID: 1, Code: "CLOTHING", Name: "Clothing"},
-				{ID: 2, Code: "SHOES", Name: "Shoes"},
-				{ID: 3, Code: "ACCESSORIES", Name: "Accessories"},
+				{ID: 1, Code: "CLOTHING", Name: "Clothing", ProductCount: 2},
+				{ID: 2, Code: "SHOES", Name: "Shoes", ProductCount: 1},
+				{ID: 3, Code: "ACCESSORIES", Name: "Accessories", ProductCount: 0},
 			}, nil
 		},
 	}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 
-	result, err := svc.ListCategories(context.Background())
+	result, err := svc.ListCategories(context.Background(), "")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -57,26 +84,35 @@ func TestListCategories_Success(t *testing.T) {
 	if result[0].Name != "Clothing" {
 		t.Errorf("expected first category name Clothing, got %s", result[0].Name)
 	}
+	if result[0].ProductCount != 2 {
+		t.Errorf("expected first category product count 2, got %d", result[0].ProductCount)
+	}
 
 	if result[1].Code != "SHOES" {
 		t.Errorf("expected second category code SHOES, got %s", result[1].Code)
 	}
+	if result[1].ProductCount != 1 {
+		t.Errorf("expected second category product count 1, got %d", result[1].ProductCount)
+	}
 
 	if result[2].Code != "ACCESSORIES" {
 		t.Errorf("expected third category code ACCESSORIES, got %s", result[2].Code)
 	}
+	if result[2].ProductCount != 0 {
+		t.Errorf("expected third category product count 0, got %d", result[2].ProductCount)
+	}
 }
 
 func TestListCategories_Empty(t *testing.T) {
 	mockRepo := &mockCategoryRepository{
-		getAllCategoriesFunc: func(ctx context.Context) ([]models.Category, error) {
+		getAllCategoriesFunc: func(ctx context.Context, order string) ([]models.Category, error) {
 			return []models.Category{}, nil
 		},
 	}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 
-	result, err := svc.ListCategories(context.Background())
+	result, err := svc.ListCategories(context.Background(), "")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -89,20 +125,63 @@ func TestListCategories_Empty(t *testing.T) {
 
 func TestListCategories_RepositoryError(t *testing.T) {
 	mockRepo := &mockCategoryRepository{
-		getAllCategoriesFunc: func(ctx context.Context) ([]models.Category, error) {
+		getAllCategoriesFunc: func(ctx context.Context, order string) ([]models.Category, error) {
 			return nil, errors.New("database error")
 		},
 	}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 
-	_, err := svc.ListCategories(context.Background())
+	_, err := svc.ListCategories(context.Background(), "")
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
 
+func TestListCategories_SortOrders(t *testing.T) {
+	tests := []struct {
+		sort     string
+		expected string
+	}{
+		{"", "categories.code ASC"},
+		{"code_asc", "categories.code ASC"},
+		{"code_desc", "categories.code DESC"},
+		{"name_asc", "categories.name ASC"},
+		{"name_desc", "categories.name DESC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			mockRepo := &mockCategoryRepository{
+				getAllCategoriesFunc: func(ctx context.Context, order string) ([]models.Category, error) {
+					if order != tt.expected {
+						t.Errorf("expected order %q, got %q", tt.expected, order)
+					}
+					return []models.Category{}, nil
+				},
+			}
+
+			svc := NewCategoriesService(mockRepo, nil)
+
+			if _, err := svc.ListCategories(context.Background(), tt.sort); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestListCategories_InvalidSort(t *testing.T) {
+	svc := NewCategoriesService(&mockCategoryRepository{}, nil)
+
+	for _, sort := range []string{"price_asc", "CODE_ASC", "code", "name_asc; DROP TABLE categories"} {
+		_, err := svc.ListCategories(context.Background(), sort)
+		if !errors.Is(err, ErrInvalidSort) {
+			t.Errorf("sort %q: expected ErrInvalidSort, got %v", sort, err)
+		}
+	}
+}
+
 func TestCreateCategory_Success(t *testing.T) {
 	mockRepo := &mockCategoryRepository{
 		createCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
@@ -114,7 +193,7 @@ func TestCreateCategory_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 	input := CreateCategoryInput{
 		Code: "ELECTRONICS",
 		Name: "Electronics",
@@ -137,7 +216,7 @@ func TestCreateCategory_Success(t *testing.T) {
 func TestCreateCategory_EmptyCode(t *testing.T) {
 	mockRepo := &mockCategoryRepository{}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 	input := CreateCategoryInput{
 		Code: "",
 		Name: "Electronics",
@@ -153,7 +232,7 @@ func TestCreateCategory_EmptyCode(t *testing.T) {
 func TestCreateCategory_EmptyName(t *testing.T) {
 	mockRepo := &mockCategoryRepository{}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 	input := CreateCategoryInput{
 		Code: "ELECTRONICS",
 		Name: "",
@@ -169,7 +248,7 @@ func TestCreateCategory_EmptyName(t *testing.T) {
 func TestCreateCategory_BothEmpty(t *testing.T) {
 	mockRepo := &mockCategoryRepository{}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 	input := CreateCategoryInput{
 		Code: "",
 		Name: "",
@@ -189,7 +268,7 @@ func TestCreateCategory_RepositoryError(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 	input := CreateCategoryInput{
 		Code: "ELECTRONICS",
 		Name: "Electronics",
@@ -202,6 +281,46 @@ func TestCreateCategory_RepositoryError(t *testing.T) {
 	}
 }
 
+func TestCreateCategory_DuplicateCode(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		createCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			return nil, fmt.Errorf("%w: %s", models.ErrDuplicateCode, code)
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+	input := CreateCategoryInput{
+		Code: "CLOTHING",
+		Name: "Clothing",
+	}
+
+	_, err := svc.CreateCategory(context.Background(), input)
+
+	if !errors.Is(err, ErrDuplicateCode) {
+		t.Errorf("expected ErrDuplicateCode, got %v", err)
+	}
+}
+
+func TestCreateCategory_RejectedByDatabase(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		createCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			return nil, fmt.Errorf("%w: check violation", models.ErrInvalidCategoryInput)
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+	input := CreateCategoryInput{
+		Code: "SHOES",
+		Name: "Shoes",
+	}
+
+	_, err := svc.CreateCategory(context.Background(), input)
+
+	if !errors.Is(err, ErrInvalidCategoryInput) {
+		t.Errorf("expected ErrInvalidCategoryInput, got %v", err)
+	}
+}
+
 func TestCreateCategory_VerifiesInputPassedToRepo(t *testing.T) {
 	var capturedCode, capturedName string
 
@@ -217,9 +336,9 @@ func TestCreateCategory_VerifiesInputPassedToRepo(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoriesService(mockRepo)
+	svc := NewCategoriesService(mockRepo, nil)
 	input := CreateCategoryInput{
-		Code: "TEST_CODE",
+		Code: "test_code",
 		Name: "Test Name",
 	}
 
@@ -236,3 +355,372 @@ func TestCreateCategory_VerifiesInputPassedToRepo(t *testing.T) {
 		t.Errorf("expected name 'Test Name' to be passed to repo, got %s", capturedName)
 	}
 }
+
+func TestCreateCategory_NormalizesInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        CreateCategoryInput
+		expectedCode string
+		expectedName string
+	}{
+		{name: "leading and trailing spaces", input: CreateCategoryInput{Code: "  CLOTHING  ", Name: "  Clothing "}, expectedCode: "CLOTHING", expectedName: "Clothing"},
+		{name: "lowercase code", input: CreateCategoryInput{Code: "clothing", Name: "Clothing"}, expectedCode: "CLOTHING", expectedName: "Clothing"},
+		{name: "mixed case code", input: CreateCategoryInput{Code: "ClOtHiNg", Name: "Clothing"}, expectedCode: "CLOTHING", expectedName: "Clothing"},
+		{name: "spaces and mixed case", input: CreateCategoryInput{Code: "\t home_Decor ", Name: "Home Decor\n"}, expectedCode: "HOME_DECOR", expectedName: "Home Decor"},
+		{name: "name case is kept", input: CreateCategoryInput{Code: "SHOES", Name: "sHoes"}, expectedCode: "SHOES", expectedName: "sHoes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedCode, capturedName string
+			mockRepo := &mockCategoryRepository{
+				createCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+					capturedCode = code
+					capturedName = name
+					return &models.Category{ID: 1, Code: code, Name: name}, nil
+				},
+			}
+
+			svc := NewCategoriesService(mockRepo, nil)
+			result, err := svc.CreateCategory(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if capturedCode != tt.expectedCode {
+				t.Errorf("expected code %q to be passed to repo, got %q", tt.expectedCode, capturedCode)
+			}
+			if capturedName != tt.expectedName {
+				t.Errorf("expected name %q to be passed to repo, got %q", tt.expectedName, capturedName)
+			}
+			if result.Code != tt.expectedCode {
+				t.Errorf("expected returned code %q, got %q", tt.expectedCode, result.Code)
+			}
+		})
+	}
+}
+
+func TestCreateCategory_BlankAfterTrimming(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CreateCategoryInput
+	}{
+		{name: "whitespace code", input: CreateCategoryInput{Code: "   ", Name: "Clothing"}},
+		{name: "whitespace name", input: CreateCategoryInput{Code: "CLOTHING", Name: " \t "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockCategoryRepository{
+				createCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+					t.Error("expected repository not to be called")
+					return nil, nil
+				},
+			}
+
+			svc := NewCategoriesService(mockRepo, nil)
+			_, err := svc.CreateCategory(context.Background(), tt.input)
+
+			if !errors.Is(err, ErrInvalidCategoryInput) {
+				t.Errorf("expected ErrInvalidCategoryInput, got %v", err)
+			}
+		})
+	}
+}
+
+// recordingBus is an events.Bus recording the events published on it.
+type recordingBus struct {
+	published []events.Event
+}
+
+func (b *recordingBus) Publish(ctx context.Context, event events.Event) error {
+	b.published = append(b.published, event)
+	return nil
+}
+
+func (b *recordingBus) Subscribe(name string, handler func(events.Event)) {}
+
+func TestCreateCategory_PublishesEvent(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		createCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			return &models.Category{Code: code, Name: name}, nil
+		},
+	}
+	bus := &recordingBus{}
+
+	svc := NewCategoriesService(mockRepo, bus)
+	if _, err := svc.CreateCategory(context.Background(), CreateCategoryInput{Code: "bags", Name: "Bags"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := events.Event{Name: events.CategoryCreated, Payload: CategoryEvent{Code: "BAGS", Name: "Bags"}}
+	if len(bus.published) != 1 || bus.published[0] != expected {
+		t.Errorf("expected %+v to be published, got %+v", expected, bus.published)
+	}
+}
+
+func TestCreateCategory_FailureDoesNotPublishEvent(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		createCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			return nil, models.ErrDuplicateCode
+		},
+	}
+	bus := &recordingBus{}
+
+	svc := NewCategoriesService(mockRepo, bus)
+	if _, err := svc.CreateCategory(context.Background(), CreateCategoryInput{Code: "BAGS", Name: "Bags"}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(bus.published) != 0 {
+		t.Errorf("expected no events, got %+v", bus.published)
+	}
+}
+
+func TestDeleteCategory_Success(t *testing.T) {
+	var capturedCode string
+
+	mockRepo := &mockCategoryRepository{
+		deleteCategoryFunc: func(ctx context.Context, code string) error {
+			capturedCode = code
+			return nil
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+
+	if err := svc.DeleteCategory(context.Background(), "SHOES"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if capturedCode != "SHOES" {
+		t.Errorf("expected code SHOES to be passed to repo, got %s", capturedCode)
+	}
+}
+
+func TestDeleteCategory_PublishesEvent(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		deleteCategoryFunc: func(ctx context.Context, code string) error {
+			return nil
+		},
+	}
+	bus := &recordingBus{}
+
+	svc := NewCategoriesService(mockRepo, bus)
+	if err := svc.DeleteCategory(context.Background(), "SHOES"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := events.Event{Name: events.CategoryDeleted, Payload: CategoryEvent{Code: "SHOES"}}
+	if len(bus.published) != 1 || bus.published[0] != expected {
+		t.Errorf("expected %+v to be published, got %+v", expected, bus.published)
+	}
+}
+
+func TestDeleteCategory_FailureDoesNotPublishEvent(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		deleteCategoryFunc: func(ctx context.Context, code string) error {
+			return fmt.Errorf("%w: %s", models.ErrCategoryInUse, code)
+		},
+	}
+	bus := &recordingBus{}
+
+	svc := NewCategoriesService(mockRepo, bus)
+	if err := svc.DeleteCategory(context.Background(), "CLOTHING"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(bus.published) != 0 {
+		t.Errorf("expected no events, got %+v", bus.published)
+	}
+}
+
+func TestDeleteCategory_NotFound(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		deleteCategoryFunc: func(ctx context.Context, code string) error {
+			return gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+
+	err := svc.DeleteCategory(context.Background(), "MISSING")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteCategory_InUse(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		deleteCategoryFunc: func(ctx context.Context, code string) error {
+			return fmt.Errorf("%w: %s", models.ErrCategoryInUse, code)
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+
+	err := svc.DeleteCategory(context.Background(), "CLOTHING")
+
+	if !errors.Is(err, ErrCategoryInUse) {
+		t.Errorf("expected ErrCategoryInUse, got %v", err)
+	}
+}
+
+func TestDeleteCategory_EmptyCode(t *testing.T) {
+	mockRepo := &mockCategoryRepository{}
+
+	svc := NewCategoriesService(mockRepo, nil)
+
+	err := svc.DeleteCategory(context.Background(), "")
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestUpdateCategory_Success(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		updateCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			if name != "Footwear" {
+				t.Errorf("expected trimmed name Footwear, got %q", name)
+			}
+			return &models.Category{ID: 2, Code: code, Name: name, ProductCount: 1}, nil
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+	sameCode := "SHOES"
+
+	result, err := svc.UpdateCategory(context.Background(), "SHOES", UpdateCategoryInput{Code: &sameCode, Name: " Footwear "})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Code != "SHOES" || result.Name != "Footwear" {
+		t.Errorf("unexpected category: %+v", result)
+	}
+}
+
+func TestUpdateCategory_PublishesEvent(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		updateCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			return &models.Category{Code: code, Name: name}, nil
+		},
+	}
+	bus := &recordingBus{}
+
+	svc := NewCategoriesService(mockRepo, bus)
+	if _, err := svc.UpdateCategory(context.Background(), "SHOES", UpdateCategoryInput{Name: "Footwear"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := events.Event{Name: events.CategoryUpdated, Payload: CategoryEvent{Code: "SHOES", Name: "Footwear"}}
+	if len(bus.published) != 1 || bus.published[0] != expected {
+		t.Errorf("expected %+v to be published, got %+v", expected, bus.published)
+	}
+}
+
+func TestUpdateCategory_CodeImmutable(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		updateCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			t.Fatal("repository must not be called when the code changes")
+			return nil, nil
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+	otherCode := "FOOTWEAR"
+
+	_, err := svc.UpdateCategory(context.Background(), "SHOES", UpdateCategoryInput{Code: &otherCode, Name: "Footwear"})
+
+	if !errors.Is(err, ErrCodeImmutable) {
+		t.Errorf("expected ErrCodeImmutable, got %v", err)
+	}
+}
+
+func TestUpdateCategory_SameCodeInAnotherCase(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		updateCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			return &models.Category{ID: 2, Code: code, Name: name}, nil
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+	sameCode := " shoes "
+
+	_, err := svc.UpdateCategory(context.Background(), "SHOES", UpdateCategoryInput{Code: &sameCode, Name: "Footwear"})
+
+	if err != nil {
+		t.Errorf("expected the code to be accepted, got %v", err)
+	}
+}
+
+func TestUpdateCategory_MissingName(t *testing.T) {
+	svc := NewCategoriesService(&mockCategoryRepository{}, nil)
+
+	_, err := svc.UpdateCategory(context.Background(), "SHOES", UpdateCategoryInput{Name: "  "})
+
+	if !errors.Is(err, ErrInvalidCategoryName) {
+		t.Errorf("expected ErrInvalidCategoryName, got %v", err)
+	}
+}
+
+func TestUpdateCategory_NotFound(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		updateCategoryFunc: func(ctx context.Context, code, name string) (*models.Category, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+
+	_, err := svc.UpdateCategory(context.Background(), "MISSING", UpdateCategoryInput{Name: "Missing"})
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRestoreCategory_Success(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		restoreCategoryFunc: func(ctx context.Context, code string) (*models.Category, error) {
+			return &models.Category{ID: 2, Code: code, Name: "Shoes", ProductCount: 4}, nil
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+
+	result, err := svc.RestoreCategory(context.Background(), "SHOES")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Code != "SHOES" {
+		t.Errorf("expected code SHOES, got %s", result.Code)
+	}
+	if result.Name != "Shoes" {
+		t.Errorf("expected name Shoes, got %s", result.Name)
+	}
+	if result.ProductCount != 4 {
+		t.Errorf("expected product count 4, got %d", result.ProductCount)
+	}
+}
+
+func TestRestoreCategory_NotFound(t *testing.T) {
+	mockRepo := &mockCategoryRepository{
+		restoreCategoryFunc: func(ctx context.Context, code string) (*models.Category, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewCategoriesService(mockRepo, nil)
+
+	_, err := svc.RestoreCategory(context.Background(), "MISSING")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
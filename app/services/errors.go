@@ -1,18 +1,68 @@
 package services
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrNotFound indicates that the requested resource was not found.
 var ErrNotFound = errors.New("resource not found")
 
+// ErrConflict indicates that the request conflicts with the current state of a resource.
+var ErrConflict = errors.New("resource conflict")
+
+// Conflict errors
+var (
+	ErrDuplicateCode = errors.New("code already exists")
+	ErrCategoryInUse = errors.New("category is referenced by existing products")
+)
+
+// ErrCodeImmutable indicates an attempt to change a resource's code.
+var ErrCodeImmutable = errors.New("code cannot be changed")
+
 // ErrInvalidInput indicates that the provided input is invalid.
 var ErrInvalidInput = errors.New("invalid input")
 
+// MaxOffset is the largest pagination offset accepted by the catalog listings.
+// Larger offsets fit in an int but not in the 32-bit OFFSET some Postgres
+// drivers bind, and would fail at query time instead of being rejected as input.
+const MaxOffset = 10_000_000
+
 // Specific validation errors
 var (
 	ErrInvalidOffset        = errors.New("offset must be a non-negative integer")
 	ErrInvalidLimit         = errors.New("limit must be a positive integer")
+	ErrOffsetExceedsTotal   = errors.New("offset exceeds total product count")
+	ErrOffsetTooLarge       = fmt.Errorf("offset must not exceed %d", MaxOffset)
 	ErrInvalidPrice         = errors.New("priceLessThan must be a valid decimal number")
 	ErrNegativePrice        = errors.New("priceLessThan must be a non-negative value")
 	ErrInvalidCategoryInput = errors.New("category code and name are required")
+	ErrInvalidCategoryName  = errors.New("category name is required")
+	ErrInvalidInStock       = errors.New("inStock must be a boolean")
+	ErrInvalidStockDelta    = errors.New("delta must be a non-zero integer")
+	ErrInsufficientStock    = errors.New("stock quantity cannot become negative")
+	ErrInvalidProductPrice  = errors.New("price must be a non-negative decimal number")
+	ErrInvalidLogLevel      = errors.New("level must be one of debug, info, warn, error")
+	ErrInvalidUpdatedAfter  = errors.New("updatedAfter must be an RFC3339 timestamp")
+	ErrInvalidMinOrderQty   = errors.New("minOrderQtyLessThan must be an integer")
+	ErrInvalidImageURL      = errors.New("url must be an absolute http or https URL")
+	ErrInvalidImagePosition = errors.New("position must be a non-negative integer")
+	ErrInvalidImageID       = errors.New("image id must be a positive integer")
+	ErrInvalidProductImage  = errors.New("image_url must be an absolute http or https URL of at most 2048 characters")
+	ErrInvalidSort          = errors.New("sort must be one of code_asc, code_desc, name_asc, name_desc")
+	ErrInvalidFields        = errors.New("fields must be a comma-separated list of code, slug, brand, image_url, price, sale_price, category, label")
+	ErrInvalidOnSale        = errors.New("onSale must be a boolean")
+	ErrInvalidDays          = errors.New("days must be a positive integer")
+	ErrInvalidWebhookURL    = errors.New("url must be an absolute http or https URL")
+	ErrInvalidWebhookEvent  = errors.New("event must be one of category.created, product.created")
+	ErrInvalidWebhookSecret = errors.New("secret is required")
+	ErrInvalidPage          = errors.New("page must be a positive integer")
+)
+
+// Product attribute format errors. They wrap ErrInvalidInput.
+var (
+	ErrInvalidProductCode = fmt.Errorf("%w: product code must contain only uppercase letters, digits, underscores and hyphens", ErrInvalidInput)
+	ErrInvalidSKU         = fmt.Errorf("%w: sku must contain only uppercase letters, digits, underscores and hyphens", ErrInvalidInput)
+	ErrInvalidLabel       = fmt.Errorf("%w: label must be one of new, sale, featured, none", ErrInvalidInput)
+	ErrInvalidSalePrice   = fmt.Errorf("%w: sale_price must be a non-negative decimal number below the price", ErrInvalidInput)
 )
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// sentinels lists every sentinel error of the package.
+var sentinels = map[string]error{
+	"ErrNotFound":             ErrNotFound,
+	"ErrConflict":             ErrConflict,
+	"ErrDuplicateCode":        ErrDuplicateCode,
+	"ErrCategoryInUse":        ErrCategoryInUse,
+	"ErrCodeImmutable":        ErrCodeImmutable,
+	"ErrInvalidInput":         ErrInvalidInput,
+	"ErrInvalidOffset":        ErrInvalidOffset,
+	"ErrInvalidLimit":         ErrInvalidLimit,
+	"ErrOffsetExceedsTotal":   ErrOffsetExceedsTotal,
+	"ErrOffsetTooLarge":       ErrOffsetTooLarge,
+	"ErrInvalidPrice":         ErrInvalidPrice,
+	"ErrNegativePrice":        ErrNegativePrice,
+	"ErrInvalidCategoryInput": ErrInvalidCategoryInput,
+	"ErrInvalidCategoryName":  ErrInvalidCategoryName,
+	"ErrInvalidInStock":       ErrInvalidInStock,
+	"ErrInvalidStockDelta":    ErrInvalidStockDelta,
+	"ErrInsufficientStock":    ErrInsufficientStock,
+	"ErrInvalidProductPrice":  ErrInvalidProductPrice,
+	"ErrInvalidLogLevel":      ErrInvalidLogLevel,
+	"ErrInvalidUpdatedAfter":  ErrInvalidUpdatedAfter,
+	"ErrInvalidMinOrderQty":   ErrInvalidMinOrderQty,
+	"ErrInvalidImageURL":      ErrInvalidImageURL,
+	"ErrInvalidImagePosition": ErrInvalidImagePosition,
+	"ErrInvalidImageID":       ErrInvalidImageID,
+	"ErrInvalidProductImage":  ErrInvalidProductImage,
+	"ErrInvalidSort":          ErrInvalidSort,
+	"ErrInvalidFields":        ErrInvalidFields,
+	"ErrInvalidOnSale":        ErrInvalidOnSale,
+	"ErrInvalidDays":          ErrInvalidDays,
+	"ErrInvalidWebhookURL":    ErrInvalidWebhookURL,
+	"ErrInvalidWebhookEvent":  ErrInvalidWebhookEvent,
+	"ErrInvalidWebhookSecret": ErrInvalidWebhookSecret,
+	"ErrInvalidPage":          ErrInvalidPage,
+	"ErrInvalidProductCode":   ErrInvalidProductCode,
+	"ErrInvalidSKU":           ErrInvalidSKU,
+	"ErrInvalidLabel":         ErrInvalidLabel,
+	"ErrInvalidSalePrice":     ErrInvalidSalePrice,
+}
+
+// wrapsInvalidInput lists the sentinels that deliberately wrap ErrInvalidInput.
+var wrapsInvalidInput = map[string]bool{
+	"ErrInvalidProductCode": true,
+	"ErrInvalidSKU":         true,
+	"ErrInvalidLabel":       true,
+	"ErrInvalidSalePrice":   true,
+}
+
+func TestSentinels_MatchWhenWrapped(t *testing.T) {
+	for name, sentinel := range sentinels {
+		t.Run(name, func(t *testing.T) {
+			err := fmt.Errorf("context: %w", sentinel)
+			if !errors.Is(err, sentinel) {
+				t.Errorf("expected wrapped error to match %s", name)
+			}
+		})
+	}
+}
+
+func TestSentinels_AreDistinct(t *testing.T) {
+	for name, sentinel := range sentinels {
+		for otherName, other := range sentinels {
+			if name == otherName {
+				continue
+			}
+			if otherName == "ErrInvalidInput" && wrapsInvalidInput[name] {
+				if !errors.Is(sentinel, other) {
+					t.Errorf("expected %s to wrap ErrInvalidInput", name)
+				}
+				continue
+			}
+			if errors.Is(sentinel, other) {
+				t.Errorf("expected %s not to match %s", name, otherName)
+			}
+		}
+	}
+}
+
+func TestSentinels_PaginationErrorsAreNotInvalidInput(t *testing.T) {
+	for _, err := range []error{ErrInvalidOffset, ErrInvalidLimit} {
+		if errors.Is(err, ErrInvalidInput) {
+			t.Errorf("expected %q to be distinct from ErrInvalidInput", err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// PriceChangeDTO represents a single product price change.
+type PriceChangeDTO struct {
+	OldPrice  decimal.Decimal
+	NewPrice  decimal.Decimal
+	ChangedAt time.Time
+}
+
+// PriceHistoryRepository defines the interface for price history data access.
+type PriceHistoryRepository interface {
+	GetHistory(ctx context.Context, productCode string) ([]models.PriceHistory, error)
+}
+
+// PriceHistoryService handles product price history business logic.
+type PriceHistoryService struct {
+	products ProductRepository
+	history  PriceHistoryRepository
+}
+
+// NewPriceHistoryService creates a new PriceHistoryService instance.
+func NewPriceHistoryService(products ProductRepository, history PriceHistoryRepository) *PriceHistoryService {
+	return &PriceHistoryService{products: products, history: history}
+}
+
+// GetPriceHistory retrieves the price changes of a product, oldest first.
+// Returns ErrNotFound if the product doesn't exist.
+func (s *PriceHistoryService) GetPriceHistory(ctx context.Context, code string) ([]PriceChangeDTO, error) {
+	if code == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if _, err := s.products.GetProductByCode(ctx, code); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	history, err := s.history.GetHistory(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PriceChangeDTO, len(history))
+	for i, h := range history {
+		result[i] = PriceChangeDTO{
+			OldPrice:  h.OldPrice,
+			NewPrice:  h.NewPrice,
+			ChangedAt: h.ChangedAt,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// mockPriceHistoryRepository is a mock implementation of PriceHistoryRepository for testing.
+type mockPriceHistoryRepository struct {
+	getHistoryFunc func(ctx context.Context, productCode string) ([]models.PriceHistory, error)
+}
+
+func (m *mockPriceHistoryRepository) GetHistory(ctx context.Context, productCode string) ([]models.PriceHistory, error) {
+	if m.getHistoryFunc != nil {
+		return m.getHistoryFunc(ctx, productCode)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestGetPriceHistory_Success(t *testing.T) {
+	changedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	products := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &models.Product{ID: 1, Code: code}, nil
+		},
+	}
+	history := &mockPriceHistoryRepository{
+		getHistoryFunc: func(ctx context.Context, productCode string) ([]models.PriceHistory, error) {
+			return []models.PriceHistory{
+				{ID: 1, ProductID: 1, OldPrice: decimal.RequireFromString("10.99"), NewPrice: decimal.RequireFromString("12.99"), ChangedAt: changedAt},
+			}, nil
+		},
+	}
+
+	svc := NewPriceHistoryService(products, history)
+
+	result, err := svc.GetPriceHistory(context.Background(), "PROD001")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 price change, got %d", len(result))
+	}
+
+	if !result[0].OldPrice.Equal(decimal.RequireFromString("10.99")) {
+		t.Errorf("expected old price 10.99, got %s", result[0].OldPrice)
+	}
+	if !result[0].NewPrice.Equal(decimal.RequireFromString("12.99")) {
+		t.Errorf("expected new price 12.99, got %s", result[0].NewPrice)
+	}
+	if !result[0].ChangedAt.Equal(changedAt) {
+		t.Errorf("expected changed at %s, got %s", changedAt, result[0].ChangedAt)
+	}
+}
+
+func TestGetPriceHistory_ProductNotFound(t *testing.T) {
+	products := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewPriceHistoryService(products, &mockPriceHistoryRepository{})
+
+	_, err := svc.GetPriceHistory(context.Background(), "MISSING")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetPriceHistory_EmptyCode(t *testing.T) {
+	svc := NewPriceHistoryService(&mockProductRepository{}, &mockPriceHistoryRepository{})
+
+	_, err := svc.GetPriceHistory(context.Background(), "")
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
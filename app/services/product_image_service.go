@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"gorm.io/gorm"
+)
+
+// ProductImageDTO represents a single image of a product's gallery.
+type ProductImageDTO struct {
+	ID       uint
+	URL      string
+	Position int
+}
+
+// AddProductImageInput represents the input for adding an image to a product's gallery.
+type AddProductImageInput struct {
+	URL      string
+	Position int
+}
+
+// ProductImageRepository defines the interface for product image data access.
+type ProductImageRepository interface {
+	GetImages(ctx context.Context, productCode string) ([]models.ProductImage, error)
+	CreateImage(ctx context.Context, image *models.ProductImage) error
+	DeleteImage(ctx context.Context, productCode string, id uint) error
+}
+
+// ProductImageService handles product image gallery business logic.
+type ProductImageService struct {
+	products ProductRepository
+	images   ProductImageRepository
+}
+
+// NewProductImageService creates a new ProductImageService instance.
+func NewProductImageService(products ProductRepository, images ProductImageRepository) *ProductImageService {
+	return &ProductImageService{products: products, images: images}
+}
+
+// ListImages retrieves the gallery of a product, ordered by position.
+// Returns ErrNotFound if the product doesn't exist.
+func (s *ProductImageService) ListImages(ctx context.Context, code string) ([]ProductImageDTO, error) {
+	if _, err := s.findProduct(ctx, code); err != nil {
+		return nil, err
+	}
+
+	images, err := s.images.GetImages(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ProductImageDTO, len(images))
+	for i, img := range images {
+		result[i] = mapImageToDTO(img)
+	}
+
+	return result, nil
+}
+
+// AddImage appends an image to the gallery of a product.
+// Returns ErrInvalidImageURL if the URL is not an absolute http(s) URL,
+// ErrInvalidImagePosition if the position is negative, and ErrNotFound if the product doesn't exist.
+func (s *ProductImageService) AddImage(ctx context.Context, code string, input AddProductImageInput) (*ProductImageDTO, error) {
+	imageURL := strings.TrimSpace(input.URL)
+	if !isHTTPURL(imageURL) {
+		return nil, ErrInvalidImageURL
+	}
+	if input.Position < 0 {
+		return nil, ErrInvalidImagePosition
+	}
+
+	product, err := s.findProduct(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	image := models.ProductImage{
+		ProductID: product.ID,
+		URL:       imageURL,
+		Position:  input.Position,
+	}
+	if err := s.images.CreateImage(ctx, &image); err != nil {
+		return nil, err
+	}
+
+	dto := mapImageToDTO(image)
+	return &dto, nil
+}
+
+// DeleteImage removes an image from the gallery of a product.
+// Returns ErrNotFound if the product or image doesn't exist.
+func (s *ProductImageService) DeleteImage(ctx context.Context, code string, id uint) error {
+	if code == "" || id == 0 {
+		return ErrInvalidInput
+	}
+
+	if err := s.images.DeleteImage(ctx, code, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *ProductImageService) findProduct(ctx context.Context, code string) (*models.Product, error) {
+	if code == "" {
+		return nil, ErrInvalidInput
+	}
+
+	product, err := s.products.GetProductByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func mapImageToDTO(img models.ProductImage) ProductImageDTO {
+	return ProductImageDTO{
+		ID:       img.ID,
+		URL:      img.URL,
+		Position: img.Position,
+	}
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL with a host.
+func isHTTPURL(s string) bool {
+	u, err := url.ParseRequestURI(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
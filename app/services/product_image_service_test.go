@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"gorm.io/gorm"
+)
+
+// mockProductImageRepository is a mock implementation of ProductImageRepository for testing.
+type mockProductImageRepository struct {
+	getImagesFunc   func(ctx context.Context, productCode string) ([]models.ProductImage, error)
+	createImageFunc func(ctx context.Context, image *models.ProductImage) error
+	deleteImageFunc func(ctx context.Context, productCode string, id uint) error
+}
+
+func (m *mockProductImageRepository) GetImages(ctx context.Context, productCode string) ([]models.ProductImage, error) {
+	if m.getImagesFunc != nil {
+		return m.getImagesFunc(ctx, productCode)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductImageRepository) CreateImage(ctx context.Context, image *models.ProductImage) error {
+	if m.createImageFunc != nil {
+		return m.createImageFunc(ctx, image)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductImageRepository) DeleteImage(ctx context.Context, productCode string, id uint) error {
+	if m.deleteImageFunc != nil {
+		return m.deleteImageFunc(ctx, productCode, id)
+	}
+	return errors.New("not implemented")
+}
+
+func existingProductRepo() *mockProductRepository {
+	return &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return &models.Product{ID: 7, Code: code}, nil
+		},
+	}
+}
+
+func TestListImages_Success(t *testing.T) {
+	images := &mockProductImageRepository{
+		getImagesFunc: func(ctx context.Context, productCode string) ([]models.ProductImage, error) {
+			return []models.ProductImage{
+				{ID: 1, ProductID: 7, URL: "https://cdn.example.com/front.jpg", Position: 0},
+				{ID: 2, ProductID: 7, URL: "https://cdn.example.com/back.jpg", Position: 1},
+			}, nil
+		},
+	}
+
+	svc := NewProductImageService(existingProductRepo(), images)
+
+	result, err := svc.ListImages(context.Background(), "PROD001")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 images, got %d", len(result))
+	}
+	if result[1].ID != 2 || result[1].URL != "https://cdn.example.com/back.jpg" || result[1].Position != 1 {
+		t.Errorf("unexpected second image: %+v", result[1])
+	}
+}
+
+func TestListImages_ProductNotFound(t *testing.T) {
+	products := &mockProductRepository{
+		getProductByCodeFunc: func(ctx context.Context, code string) (*models.Product, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewProductImageService(products, &mockProductImageRepository{})
+
+	_, err := svc.ListImages(context.Background(), "MISSING")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAddImage_Success(t *testing.T) {
+	images := &mockProductImageRepository{
+		createImageFunc: func(ctx context.Context, image *models.ProductImage) error {
+			if image.ProductID != 7 {
+				t.Errorf("expected product ID 7, got %d", image.ProductID)
+			}
+			image.ID = 42
+			return nil
+		},
+	}
+
+	svc := NewProductImageService(existingProductRepo(), images)
+
+	result, err := svc.AddImage(context.Background(), "PROD001", AddProductImageInput{URL: " https://cdn.example.com/side.jpg ", Position: 3})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != 42 {
+		t.Errorf("expected ID 42, got %d", result.ID)
+	}
+	if result.URL != "https://cdn.example.com/side.jpg" {
+		t.Errorf("expected trimmed URL, got %q", result.URL)
+	}
+	if result.Position != 3 {
+		t.Errorf("expected position 3, got %d", result.Position)
+	}
+}
+
+func TestAddImage_InvalidURL(t *testing.T) {
+	svc := NewProductImageService(existingProductRepo(), &mockProductImageRepository{})
+
+	for _, u := range []string{"", "not a url", "/relative/path.jpg", "ftp://cdn.example.com/a.jpg", "https://"} {
+		_, err := svc.AddImage(context.Background(), "PROD001", AddProductImageInput{URL: u})
+		if !errors.Is(err, ErrInvalidImageURL) {
+			t.Errorf("url %q: expected ErrInvalidImageURL, got %v", u, err)
+		}
+	}
+}
+
+func TestAddImage_NegativePosition(t *testing.T) {
+	svc := NewProductImageService(existingProductRepo(), &mockProductImageRepository{})
+
+	_, err := svc.AddImage(context.Background(), "PROD001", AddProductImageInput{URL: "https://cdn.example.com/a.jpg", Position: -1})
+
+	if !errors.Is(err, ErrInvalidImagePosition) {
+		t.Errorf("expected ErrInvalidImagePosition, got %v", err)
+	}
+}
+
+func TestDeleteImage_NotFound(t *testing.T) {
+	images := &mockProductImageRepository{
+		deleteImageFunc: func(ctx context.Context, productCode string, id uint) error {
+			return gorm.ErrRecordNotFound
+		},
+	}
+
+	svc := NewProductImageService(existingProductRepo(), images)
+
+	err := svc.DeleteImage(context.Background(), "PROD001", 99)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteImage_Success(t *testing.T) {
+	called := false
+	images := &mockProductImageRepository{
+		deleteImageFunc: func(ctx context.Context, productCode string, id uint) error {
+			called = true
+			if productCode != "PROD001" || id != 5 {
+				t.Errorf("unexpected delete arguments: %s %d", productCode, id)
+			}
+			return nil
+		},
+	}
+
+	svc := NewProductImageService(existingProductRepo(), images)
+
+	if err := svc.DeleteImage(context.Background(), "PROD001", 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected repository delete to be called")
+	}
+}
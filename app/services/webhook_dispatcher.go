@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/app/logger"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+// Webhook delivery headers.
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// webhookAttempts is the number of times a delivery is tried before giving up.
+const webhookAttempts = 3
+
+// WebhookPayload is the JSON body POSTed to webhooks.
+type WebhookPayload struct {
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature value for body: the hex
+// encoded HMAC-SHA256 of the body keyed with the webhook secret, prefixed with "sha256=".
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDispatcher delivers events to the webhooks registered for them.
+// Deliveries run in the background and never fail the request that raised
+// the event; failures are logged.
+type WebhookDispatcher struct {
+	repo    WebhookRepository
+	client  *http.Client
+	backoff time.Duration
+	wg      sync.WaitGroup
+	// stopped is canceled by Stop to abandon the pending deliveries.
+	stopped context.Context
+	stop    context.CancelFunc
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher instance.
+// A failed delivery is retried after backoff, doubling the delay on every
+// attempt, until webhookAttempts have been made.
+func NewWebhookDispatcher(repo WebhookRepository, client *http.Client, backoff time.Duration) *WebhookDispatcher {
+	stopped, stop := context.WithCancel(context.Background())
+	return &WebhookDispatcher{
+		repo:    repo,
+		client:  client,
+		backoff: backoff,
+		stopped: stopped,
+		stop:    stop,
+	}
+}
+
+// Dispatch delivers event with data to every webhook registered for it.
+// It returns immediately; use Wait to wait for the deliveries to finish.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, event string, data any) {
+	// Deliveries outlive the request that raised the event, until Stop is called.
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	unregister := context.AfterFunc(d.stopped, cancel)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer cancel()
+		defer unregister()
+
+		webhooks, err := d.repo.ListWebhooks(ctx, event)
+		if err != nil {
+			logger.Error("Failed to list webhooks", "event", event, "error", err)
+			return
+		}
+		if len(webhooks) == 0 {
+			return
+		}
+
+		body, err := json.Marshal(WebhookPayload{Event: event, Data: data})
+		if err != nil {
+			logger.Error("Failed to encode webhook payload", "event", event, "error", err)
+			return
+		}
+
+		var deliveries sync.WaitGroup
+		for _, webhook := range webhooks {
+			deliveries.Add(1)
+			go func() {
+				defer deliveries.Done()
+				d.deliver(ctx, webhook, body)
+			}()
+		}
+		deliveries.Wait()
+	}()
+}
+
+// Handle dispatches event. It is meant to be subscribed to an events.Bus.
+func (d *WebhookDispatcher) Handle(event events.Event) {
+	d.Dispatch(context.Background(), event.Name, event.Payload)
+}
+
+// Wait blocks until all pending deliveries have finished.
+func (d *WebhookDispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Stop abandons the pending deliveries: in-flight attempts are canceled and
+// no further retries are made. Use Wait to wait for them to return.
+func (d *WebhookDispatcher) Stop() {
+	d.stop()
+}
+
+// deliver POSTs body to the webhook, retrying with exponential backoff until ctx is done.
+func (d *WebhookDispatcher) deliver(ctx context.Context, webhook models.Webhook, body []byte) {
+	signature := SignWebhookPayload(webhook.Secret, body)
+	delay := d.backoff
+
+	for attempt := 1; ; attempt++ {
+		err := d.post(ctx, webhook, body, signature)
+		if err == nil {
+			return
+		}
+		if attempt == webhookAttempts {
+			logger.Error("Webhook delivery failed", "webhook_id", webhook.ID, "event", webhook.Event, "attempts", attempt, "error", err)
+			return
+		}
+
+		logger.Warn("Webhook delivery attempt failed, retrying", "webhook_id", webhook.ID, "event", webhook.Event, "attempt", attempt, "retry_in", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			logger.Warn("Webhook delivery abandoned", "webhook_id", webhook.ID, "event", webhook.Event, "attempts", attempt, "error", ctx.Err())
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post makes a single delivery attempt. Any non-2xx response is a failure.
+func (d *WebhookDispatcher) post(ctx context.Context, webhook models.Webhook, body []byte, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, webhook.Event)
+	req.Header.Set(WebhookSignatureHeader, signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+// webhookReceiver is a test server recording the deliveries it receives.
+// It answers the first failures requests with 500 and the rest with 204.
+type webhookReceiver struct {
+	mu         sync.Mutex
+	failures   int
+	bodies     [][]byte
+	signatures []string
+	events     []string
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	rcv.bodies = append(rcv.bodies, body)
+	rcv.signatures = append(rcv.signatures, r.Header.Get(WebhookSignatureHeader))
+	rcv.events = append(rcv.events, r.Header.Get(WebhookEventHeader))
+
+	if len(rcv.bodies) <= rcv.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newTestDispatcher(t *testing.T, webhooks ...models.Webhook) *WebhookDispatcher {
+	t.Helper()
+
+	repo := &mockWebhookRepository{
+		listWebhooksFunc: func(ctx context.Context, event string) ([]models.Webhook, error) {
+			if event != events.CategoryCreated {
+				t.Errorf("expected event %s, got %s", events.CategoryCreated, event)
+			}
+			return webhooks, nil
+		},
+	}
+	return NewWebhookDispatcher(repo, http.DefaultClient, time.Millisecond)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Reference value: printf 'hello' | openssl dgst -sha256 -hmac key
+	expected := "sha256=9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b"
+
+	if got := SignWebhookPayload("key", []byte("hello")); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestWebhookDispatcher_DeliversSignedPayload(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	d := newTestDispatcher(t, models.Webhook{ID: 1, URL: server.URL, Secret: "s3cret", Event: events.CategoryCreated})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Dispatch(ctx, events.CategoryCreated, CategoryEvent{Code: "BAGS", Name: "Bags"})
+	// The delivery must not depend on the request context.
+	cancel()
+	d.Wait()
+
+	if len(receiver.bodies) != 1 {
+		t.Fatalf("expected one delivery, got %d", len(receiver.bodies))
+	}
+	body := receiver.bodies[0]
+	if receiver.signatures[0] != SignWebhookPayload("s3cret", body) {
+		t.Errorf("signature %q does not match the body", receiver.signatures[0])
+	}
+	if receiver.events[0] != events.CategoryCreated {
+		t.Errorf("expected event header %s, got %q", events.CategoryCreated, receiver.events[0])
+	}
+
+	var payload struct {
+		Event string        `json:"event"`
+		Data  CategoryEvent `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.Event != events.CategoryCreated || payload.Data != (CategoryEvent{Code: "BAGS", Name: "Bags"}) {
+		t.Errorf("unexpected payload %s", body)
+	}
+}
+
+func TestWebhookDispatcher_RetriesFailedDeliveries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		expected int
+	}{
+		{"succeeds on the last attempt", 2, 3},
+		{"gives up after three attempts", 5, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := &webhookReceiver{failures: tt.failures}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			d := newTestDispatcher(t, models.Webhook{ID: 1, URL: server.URL, Secret: "s3cret", Event: events.CategoryCreated})
+			d.Dispatch(context.Background(), events.CategoryCreated, CategoryEvent{Code: "BAGS", Name: "Bags"})
+			d.Wait()
+
+			if len(receiver.bodies) != tt.expected {
+				t.Errorf("expected %d attempts, got %d", tt.expected, len(receiver.bodies))
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcher_StopAbandonsRetries(t *testing.T) {
+	receiver := &webhookReceiver{failures: webhookAttempts}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := &mockWebhookRepository{
+		listWebhooksFunc: func(ctx context.Context, event string) ([]models.Webhook, error) {
+			return []models.Webhook{{ID: 1, URL: server.URL, Secret: "s3cret", Event: events.CategoryCreated}}, nil
+		},
+	}
+	d := NewWebhookDispatcher(repo, http.DefaultClient, time.Hour)
+	d.Dispatch(context.Background(), events.CategoryCreated, CategoryEvent{Code: "BAGS", Name: "Bags"})
+
+	// Wait for the first attempt, after which the delivery waits an hour to retry.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		receiver.mu.Lock()
+		attempts := len(receiver.bodies)
+		receiver.mu.Unlock()
+		if attempts > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected a first delivery attempt")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	d.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		d.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Wait to return once the dispatcher is stopped")
+	}
+
+	if len(receiver.bodies) != 1 {
+		t.Errorf("expected a single attempt, got %d", len(receiver.bodies))
+	}
+}
+
+func TestWebhookDispatcher_ListError(t *testing.T) {
+	repo := &mockWebhookRepository{
+		listWebhooksFunc: func(ctx context.Context, event string) ([]models.Webhook, error) {
+			return nil, errors.New("database error")
+		},
+	}
+	d := NewWebhookDispatcher(repo, http.DefaultClient, time.Millisecond)
+
+	// A failure to look up the webhooks is logged, never surfaced.
+	d.Dispatch(context.Background(), events.CategoryCreated, CategoryEvent{Code: "BAGS"})
+	d.Wait()
+}
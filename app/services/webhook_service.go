@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+// WebhookEvents lists the events webhooks can be registered for.
+var WebhookEvents = []string{events.CategoryCreated, events.ProductCreated}
+
+// RegisterWebhookInput represents the input for registering a webhook.
+type RegisterWebhookInput struct {
+	URL    string
+	Event  string
+	Secret string
+}
+
+// WebhookDTO represents a registered webhook. The secret is never exposed.
+type WebhookDTO struct {
+	ID    uint
+	URL   string
+	Event string
+}
+
+// WebhookRepository defines the interface for webhook data access.
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	ListWebhooks(ctx context.Context, event string) ([]models.Webhook, error)
+}
+
+// WebhookService handles webhook registration.
+type WebhookService struct {
+	repo WebhookRepository
+}
+
+// NewWebhookService creates a new WebhookService instance.
+func NewWebhookService(repo WebhookRepository) *WebhookService {
+	return &WebhookService{repo: repo}
+}
+
+// RegisterWebhook registers a webhook for an event.
+// Returns ErrInvalidWebhookURL if the URL is not an absolute http(s) URL,
+// ErrInvalidWebhookEvent if the event is not one of WebhookEvents, and
+// ErrInvalidWebhookSecret if the secret is blank.
+func (s *WebhookService) RegisterWebhook(ctx context.Context, input RegisterWebhookInput) (*WebhookDTO, error) {
+	webhookURL := strings.TrimSpace(input.URL)
+	if !isHTTPURL(webhookURL) {
+		return nil, ErrInvalidWebhookURL
+	}
+	if !slices.Contains(WebhookEvents, input.Event) {
+		return nil, ErrInvalidWebhookEvent
+	}
+	if strings.TrimSpace(input.Secret) == "" {
+		return nil, ErrInvalidWebhookSecret
+	}
+
+	webhook := models.Webhook{URL: webhookURL, Event: input.Event, Secret: input.Secret}
+	if err := s.repo.CreateWebhook(ctx, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &WebhookDTO{ID: webhook.ID, URL: webhook.URL, Event: webhook.Event}, nil
+}
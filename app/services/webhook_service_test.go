@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+// mockWebhookRepository is a mock implementation of WebhookRepository for testing.
+type mockWebhookRepository struct {
+	createWebhookFunc func(ctx context.Context, webhook *models.Webhook) error
+	listWebhooksFunc  func(ctx context.Context, event string) ([]models.Webhook, error)
+}
+
+func (m *mockWebhookRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if m.createWebhookFunc != nil {
+		return m.createWebhookFunc(ctx, webhook)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockWebhookRepository) ListWebhooks(ctx context.Context, event string) ([]models.Webhook, error) {
+	if m.listWebhooksFunc != nil {
+		return m.listWebhooksFunc(ctx, event)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestRegisterWebhook_Success(t *testing.T) {
+	mockRepo := &mockWebhookRepository{
+		createWebhookFunc: func(ctx context.Context, webhook *models.Webhook) error {
+			if webhook.URL != "https://cms.example.com/hooks" || webhook.Event != events.CategoryCreated || webhook.Secret != "s3cret" {
+				t.Errorf("unexpected webhook %+v", webhook)
+			}
+			webhook.ID = 7
+			return nil
+		},
+	}
+
+	svc := NewWebhookService(mockRepo)
+
+	result, err := svc.RegisterWebhook(context.Background(), RegisterWebhookInput{
+		URL:    " https://cms.example.com/hooks ",
+		Event:  events.CategoryCreated,
+		Secret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *result != (WebhookDTO{ID: 7, URL: "https://cms.example.com/hooks", Event: events.CategoryCreated}) {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestRegisterWebhook_ProductCreated(t *testing.T) {
+	mockRepo := &mockWebhookRepository{
+		createWebhookFunc: func(ctx context.Context, webhook *models.Webhook) error {
+			if webhook.Event != events.ProductCreated {
+				t.Errorf("expected event %q, got %q", events.ProductCreated, webhook.Event)
+			}
+			return nil
+		},
+	}
+
+	svc := NewWebhookService(mockRepo)
+
+	if _, err := svc.RegisterWebhook(context.Background(), RegisterWebhookInput{
+		URL:    "https://cms.example.com/hooks",
+		Event:  events.ProductCreated,
+		Secret: "s3cret",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRegisterWebhook_InvalidInput(t *testing.T) {
+	valid := RegisterWebhookInput{URL: "https://cms.example.com/hooks", Event: events.CategoryCreated, Secret: "s3cret"}
+
+	tests := []struct {
+		name     string
+		modify   func(in *RegisterWebhookInput)
+		expected error
+	}{
+		{"relative url", func(in *RegisterWebhookInput) { in.URL = "/hooks" }, ErrInvalidWebhookURL},
+		{"unsupported scheme", func(in *RegisterWebhookInput) { in.URL = "ftp://cms.example.com/hooks" }, ErrInvalidWebhookURL},
+		{"unknown event", func(in *RegisterWebhookInput) { in.Event = "category.deleted" }, ErrInvalidWebhookEvent},
+		{"blank secret", func(in *RegisterWebhookInput) { in.Secret = "  " }, ErrInvalidWebhookSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockWebhookRepository{
+				createWebhookFunc: func(ctx context.Context, webhook *models.Webhook) error {
+					t.Error("expected repository not to be called")
+					return nil
+				},
+			}
+			input := valid
+			tt.modify(&input)
+
+			svc := NewWebhookService(mockRepo)
+			_, err := svc.RegisterWebhook(context.Background(), input)
+
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Package webhooks provides HTTP handlers for webhook registration.
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// WebhookResponse represents a registered webhook in API responses.
+// The secret is never returned.
+type WebhookResponse struct {
+	ID    uint   `json:"id"`
+	URL   string `json:"url"`
+	Event string `json:"event"`
+}
+
+// RegisterWebhookRequest represents the request body for registering a webhook.
+type RegisterWebhookRequest struct {
+	URL    string `json:"url"`
+	Event  string `json:"event"`
+	Secret string `json:"secret"`
+}
+
+// WebhookService defines the interface for webhook business logic.
+type WebhookService interface {
+	RegisterWebhook(ctx context.Context, input services.RegisterWebhookInput) (*services.WebhookDTO, error)
+}
+
+// WebhooksHandler handles HTTP requests for the webhook endpoints.
+type WebhooksHandler struct {
+	service WebhookService
+}
+
+// NewWebhooksHandler creates a new WebhooksHandler instance.
+func NewWebhooksHandler(s WebhookService) *WebhooksHandler {
+	return &WebhooksHandler{service: s}
+}
+
+// HandlePost handles POST /webhooks requests for registering a webhook.
+func (h *WebhooksHandler) HandlePost(w http.ResponseWriter, r *http.Request) error {
+	var req RegisterWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return invalidBodyError(err)
+	}
+
+	webhook, err := h.service.RegisterWebhook(r.Context(), services.RegisterWebhookInput{
+		URL:    req.URL,
+		Event:  req.Event,
+		Secret: req.Secret,
+	})
+	if err != nil {
+		return err
+	}
+
+	api.CreatedResponse(w, r, WebhookResponse{
+		ID:    webhook.ID,
+		URL:   webhook.URL,
+		Event: webhook.Event,
+	})
+	return nil
+}
+
+// invalidBodyError reports a request body that could not be decoded as JSON.
+func invalidBodyError(err error) error {
+	return &api.HandlerError{
+		Status:  http.StatusBadRequest,
+		Code:    api.ErrCodeInvalidInput,
+		Message: "request body must be valid JSON",
+		Err:     err,
+	}
+}
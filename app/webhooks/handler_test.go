@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+)
+
+// mockWebhookService is a mock implementation of WebhookService for testing.
+type mockWebhookService struct {
+	registerWebhookFunc func(ctx context.Context, input services.RegisterWebhookInput) (*services.WebhookDTO, error)
+}
+
+func (m *mockWebhookService) RegisterWebhook(ctx context.Context, input services.RegisterWebhookInput) (*services.WebhookDTO, error) {
+	if m.registerWebhookFunc != nil {
+		return m.registerWebhookFunc(ctx, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestHandlePost_Success(t *testing.T) {
+	mockSvc := &mockWebhookService{
+		registerWebhookFunc: func(ctx context.Context, input services.RegisterWebhookInput) (*services.WebhookDTO, error) {
+			expected := services.RegisterWebhookInput{URL: "https://cms.example.com/hooks", Event: "category.created", Secret: "s3cret"}
+			if input != expected {
+				t.Errorf("expected input %+v, got %+v", expected, input)
+			}
+			return &services.WebhookDTO{ID: 1, URL: input.URL, Event: input.Event}, nil
+		},
+	}
+
+	handler := NewWebhooksHandler(mockSvc)
+
+	body := `{"url":"https://cms.example.com/hooks","event":"category.created","secret":"s3cret"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhooks", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("webhooks.HandlePost", handler.HandlePost).ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+	if strings.Contains(w.Body.String(), "s3cret") {
+		t.Errorf("expected the secret not to be returned, got %s", w.Body.String())
+	}
+
+	var response WebhookResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response != (WebhookResponse{ID: 1, URL: "https://cms.example.com/hooks", Event: "category.created"}) {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestHandlePost_InvalidJSON(t *testing.T) {
+	handler := NewWebhooksHandler(&mockWebhookService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhooks", bytes.NewReader([]byte(`{"url":`)))
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("webhooks.HandlePost", handler.HandlePost).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "request body must be valid JSON") {
+		t.Errorf("expected the invalid body message, got %s", w.Body.String())
+	}
+}
+
+func TestHandlePost_ValidationError(t *testing.T) {
+	mockSvc := &mockWebhookService{
+		registerWebhookFunc: func(ctx context.Context, input services.RegisterWebhookInput) (*services.WebhookDTO, error) {
+			return nil, services.ErrInvalidWebhookEvent
+		},
+	}
+
+	handler := NewWebhooksHandler(mockSvc)
+
+	body := `{"url":"https://cms.example.com/hooks","event":"product.created","secret":"s3cret"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhooks", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	api.ErrorHandler("webhooks.HandlePost", handler.HandlePost).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), services.ErrInvalidWebhookEvent.Error()) {
+		t.Errorf("expected the validation message, got %s", w.Body.String())
+	}
+}
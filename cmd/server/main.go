@@ -3,7 +3,7 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
@@ -12,13 +12,19 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/mytheresa/go-hiring-challenge/app/admin"
 	"github.com/mytheresa/go-hiring-challenge/app/api"
+	"github.com/mytheresa/go-hiring-challenge/app/audit"
+	"github.com/mytheresa/go-hiring-challenge/app/cache"
 	"github.com/mytheresa/go-hiring-challenge/app/catalog"
 	"github.com/mytheresa/go-hiring-challenge/app/categories"
+	"github.com/mytheresa/go-hiring-challenge/app/config"
 	"github.com/mytheresa/go-hiring-challenge/app/database"
+	"github.com/mytheresa/go-hiring-challenge/app/events"
 	"github.com/mytheresa/go-hiring-challenge/app/logger"
 	"github.com/mytheresa/go-hiring-challenge/app/middleware"
 	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/mytheresa/go-hiring-challenge/app/webhooks"
 	"github.com/mytheresa/go-hiring-challenge/models"
 )
 
@@ -33,20 +39,38 @@ func main() {
 	if env == "" {
 		env = "development"
 	}
-	logger.Init(env)
+	logWriters := []io.Writer{os.Stdout}
+	if logFilePath := os.Getenv("LOG_FILE_PATH"); logFilePath != "" {
+		logFile, err := os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Fatalf("Error opening log file: %s", err)
+		}
+		// Registered first so it runs last, after every other shutdown log entry.
+		defer func() {
+			if err := logFile.Close(); err != nil {
+				log.Printf("Error closing log file: %s", err)
+			}
+		}()
+		logWriters = append(logWriters, logFile)
+	}
+	logger.Init(env, logWriters...)
 	logger.Info("Starting application", "env", env)
 
+	// Load and validate the whole configuration before connecting to anything.
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+
 	// Set up signal handling for graceful shutdown.
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
 	// Initialize database connection.
-	db, close, err := database.New(
-		os.Getenv("POSTGRES_USER"),
-		os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("POSTGRES_DB"),
-		os.Getenv("POSTGRES_PORT"),
-	)
+	dbCfg := cfg.Database
+	dsn := database.RedactDSN(database.DSN(dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Name, dbCfg.Port))
+	db, close, err := database.New(dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Name, dbCfg.Port)
 	if err != nil {
 		logger.Error("Failed to connect to database", "error", err)
 		os.Exit(1)
@@ -56,47 +80,135 @@ func main() {
 			logger.Error("Failed to close database", "error", err)
 		}
 	}()
+
+	// database.New already connected and ran the migrations; checking again is a
+	// cheap guard that the pool still hands out working connections before serving.
+	if err := database.Ping(ctx, db); err != nil {
+		logger.Error("Failed to reach database", "dsn", dsn, "error", err)
+		os.Exit(1)
+	}
 	logger.Info("Database connected successfully")
+	logger.Debug("Database connection", "dsn", dsn)
+
+	// Initialize cache connection.
+	redisCache, closeCache, err := cache.New(cfg.RedisAddr, cfg.RedisPassword, 0)
+	if err != nil {
+		logger.Error("Failed to connect to cache", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := closeCache(); err != nil {
+			logger.Error("Failed to close cache", "error", err)
+		}
+	}()
+	logger.Info("Cache connected successfully")
 
 	// Initialize repositories.
 	prodRepo := models.NewProductsRepository(db)
 	catRepo := models.NewCategoriesRepository(db)
+	priceHistoryRepo := models.NewPriceHistoryRepository(db)
+	imageRepo := models.NewProductImageRepository(db)
+	auditRepo := models.NewAuditLogRepository(db)
+	webhookRepo := models.NewWebhooksRepository(db)
+
+	// Services publish their domain events on the bus, to which side effects
+	// such as cache invalidation and webhook deliveries subscribe.
+	bus := events.NewInMemoryBus()
+
+	// Webhook deliveries are retried after 1s and 2s before giving up.
+	webhookDispatcher := services.NewWebhookDispatcher(webhookRepo, &http.Client{Timeout: 10 * time.Second}, time.Second)
+	bus.Subscribe(events.CategoryCreated, webhookDispatcher.Handle)
+	bus.Subscribe(events.ProductCreated, webhookDispatcher.Handle)
 
 	// Initialize services.
-	catalogService := services.NewCatalogService(prodRepo)
-	categoriesService := services.NewCategoriesService(catRepo)
+	baseCatalogService := services.NewCatalogService(prodRepo, catRepo, services.CatalogServiceConfig{
+		DefaultLimit: cfg.DefaultPageLimit,
+		MaxLimit:     cfg.MaxPageLimit,
+	}, bus)
+	catalogService := services.NewCachedCatalogService(baseCatalogService, redisCache, bus, cfg.CatalogCacheTTL, cfg.ProductCacheTTL)
+	categoriesService := services.NewCategoriesService(catRepo, bus)
+	priceHistoryService := services.NewPriceHistoryService(prodRepo, priceHistoryRepo)
+	imageService := services.NewProductImageService(prodRepo, imageRepo)
+	auditService := services.NewAuditService(auditRepo)
+	webhookService := services.NewWebhookService(webhookRepo)
 
 	// Initialize handlers.
-	catalogHandler := catalog.NewCatalogHandler(catalogService)
+	catalogHandler := catalog.NewCatalogHandler(catalogService, catalog.CatalogHandlerConfig{
+		BaseURL:  cfg.BaseURL,
+		Currency: cfg.Currency,
+	})
 	categoriesHandler := categories.NewCategoriesHandler(categoriesService)
+	priceHistoryHandler := catalog.NewPriceHistoryHandler(priceHistoryService)
+	imageHandler := catalog.NewProductImageHandler(imageService)
+	auditHandler := audit.NewAuditHandler(auditService)
+	adminHandler := admin.NewAdminHandler(logger.SetLevel)
+	webhooksHandler := webhooks.NewWebhooksHandler(webhookService)
+
+	// Mutation routes require a valid JWT.
+	requireAuth := middleware.JWTAuth([]byte(cfg.JWTSecret))
+
+	// Mutation routes are audited (including rejected attempts) and authenticated.
+	// Those with a request body must also declare it as JSON.
+	auditLog := middleware.AuditLogger(auditRepo)
+	requireJSON := middleware.RequireContentType("application/json")
+	mutation := middleware.Chain(auditLog, requireAuth)
+	jsonMutation := middleware.Chain(auditLog, requireAuth, requireJSON)
 
 	// Set up routing.
 	mux := http.NewServeMux()
 
 	// API v1 routes
-	mux.Handle("GET /v1/catalog", api.ErrorHandler(catalogHandler.HandleGet))
-	mux.Handle("GET /v1/catalog/{code}", api.ErrorHandler(catalogHandler.HandleGetByCode))
-	mux.Handle("GET /v1/categories", api.ErrorHandler(categoriesHandler.HandleGet))
-	mux.Handle("POST /v1/categories", api.ErrorHandler(categoriesHandler.HandlePost))
+	mux.Handle("GET /v1/catalog", api.ErrorHandler("catalog.HandleGet", catalogHandler.HandleGet))
+	mux.Handle("GET /v2/catalog", api.ErrorHandler("catalog.HandleGetV2", catalogHandler.HandleGetV2))
+	mux.Handle("GET /v1/catalog/new-arrivals", api.ErrorHandler("catalog.HandleNewArrivals", catalogHandler.HandleNewArrivals))
+	mux.Handle("GET /v1/catalog/featured", api.ErrorHandler("catalog.HandleFeatured", catalogHandler.HandleFeatured))
+	mux.Handle("GET /v1/catalog/sitemap.xml", api.ErrorHandler("catalog.HandleSitemap", catalogHandler.HandleSitemap))
+	mux.Handle("GET /v1/catalog/feed.json", api.ErrorHandler("catalog.HandleFeed", catalogHandler.HandleFeed))
+	mux.Handle("GET /v1/catalog/{code}", api.ErrorHandler("catalog.HandleGetByCode", catalogHandler.HandleGetByCode))
+	mux.Handle("PUT /v1/catalog/{code}", jsonMutation(api.ErrorHandler("catalog.HandleUpdate", catalogHandler.HandleUpdate)))
+	mux.Handle("GET /v1/catalog/{code}/price-history", api.ErrorHandler("catalog.PriceHistoryHandler.HandleGet", priceHistoryHandler.HandleGet))
+	mux.Handle("GET /v1/catalog/{code}/images", api.ErrorHandler("catalog.ProductImageHandler.HandleGet", imageHandler.HandleGet))
+	mux.Handle("POST /v1/catalog/{code}/images", jsonMutation(api.ErrorHandler("catalog.ProductImageHandler.HandlePost", imageHandler.HandlePost)))
+	mux.Handle("DELETE /v1/catalog/{code}/images/{id}", mutation(api.ErrorHandler("catalog.ProductImageHandler.HandleDelete", imageHandler.HandleDelete)))
+	mux.Handle("PATCH /v1/catalog/{code}/variants/{sku}/stock", jsonMutation(api.ErrorHandler("catalog.HandleAdjustStock", catalogHandler.HandleAdjustStock)))
+	mux.Handle("GET /v1/categories", api.ErrorHandler("categories.HandleGet", categoriesHandler.HandleGet))
+	mux.Handle("GET /v1/audit", requireAuth(api.ErrorHandler("audit.HandleGet", auditHandler.HandleGet)))
+	mux.Handle("POST /v1/categories", jsonMutation(api.ErrorHandler("categories.HandlePost", categoriesHandler.HandlePost)))
+	mux.Handle("PUT /v1/categories/{code}", jsonMutation(api.ErrorHandler("categories.HandleUpdate", categoriesHandler.HandleUpdate)))
+	mux.Handle("DELETE /v1/categories/{code}", mutation(api.ErrorHandler("categories.HandleDelete", categoriesHandler.HandleDelete)))
+	mux.Handle("PUT /v1/categories/{code}/restore", mutation(api.ErrorHandler("categories.HandleRestore", categoriesHandler.HandleRestore)))
+
+	// Admin routes are protected by a separate admin token and disabled without one.
+	if cfg.AdminToken != "" {
+		requireAdmin := middleware.AdminToken(cfg.AdminToken)
+		mux.Handle("POST /v1/admin/log-level", middleware.Chain(requireAdmin, requireJSON)(api.ErrorHandler("admin.HandleSetLogLevel", adminHandler.HandleSetLogLevel)))
+		mux.Handle("POST /v1/webhooks", middleware.Chain(requireAdmin, requireJSON)(api.ErrorHandler("webhooks.HandlePost", webhooksHandler.HandlePost)))
+	} else {
+		logger.Warn("ADMIN_TOKEN is not set, admin routes are disabled")
+	}
 
 	// Legacy routes (kept for assignment compatibility)
-	mux.Handle("GET /catalog", api.ErrorHandler(catalogHandler.HandleGet))
-	mux.Handle("GET /catalog/{code}", api.ErrorHandler(catalogHandler.HandleGetByCode))
-	mux.Handle("GET /categories", api.ErrorHandler(categoriesHandler.HandleGet))
-	mux.Handle("POST /categories", api.ErrorHandler(categoriesHandler.HandlePost))
+	mux.Handle("GET /catalog", api.ErrorHandler("catalog.HandleGet", catalogHandler.HandleGet))
+	mux.Handle("GET /catalog/{code}", api.ErrorHandler("catalog.HandleGetByCode", catalogHandler.HandleGetByCode))
+	mux.Handle("GET /categories", api.ErrorHandler("categories.HandleGet", categoriesHandler.HandleGet))
+	mux.Handle("POST /categories", jsonMutation(api.ErrorHandler("categories.HandlePost", categoriesHandler.HandlePost)))
+
+	// Slug and barcode lookups are served from a root mux in front of the main one, because
+	// "by-slug/{slug}" and "by-barcode/{barcode}" would conflict with the "{code}/..." sub-resource routes.
+	root := http.NewServeMux()
+	root.Handle("GET /v1/catalog/by-slug/{slug}", api.ErrorHandler("catalog.HandleGetBySlug", catalogHandler.HandleGetBySlug))
+	root.Handle("GET /v1/catalog/by-barcode/{barcode}", api.ErrorHandler("catalog.HandleGetByBarcode", catalogHandler.HandleGetByBarcode))
+	root.Handle("/", mux)
 
 	logger.Info("Routes registered", "version", "v1", "legacy_routes_enabled", true)
 
-	// Set up the HTTP server with middlewares.
-	// Middlewares are applied in reverse order (last = innermost)
-	// Final order: RequestID -> Logger -> Recovery -> mux
-	var handler http.Handler = mux
-	handler = middleware.Recovery(handler)
-	handler = middleware.Logger(handler)
-	handler = middleware.RequestID(handler)
+	// Set up the HTTP server with middlewares (first = outermost).
+	// REQUEST_ID_HEADER lets the request ID follow the infrastructure's header, e.g. X-Trace-Id.
+	requestID := middleware.NewRequestID(cfg.RequestIDHeader)
+	handler := middleware.Chain(requestID, middleware.Logger, middleware.Recovery)(root)
 
 	srv := &http.Server{
-		Addr:    fmt.Sprintf("localhost:%s", os.Getenv("HTTP_PORT")),
+		Addr:    "localhost:" + cfg.HTTPPort,
 		Handler: handler,
 	}
 
@@ -113,7 +225,7 @@ func main() {
 	logger.Info("Shutting down server...")
 
 	// Create a new context with timeout for graceful shutdown.
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
@@ -122,5 +234,10 @@ func main() {
 		logger.Info("Server stopped gracefully")
 	}
 
+	// Let in-flight webhook deliveries finish before the database is closed,
+	// abandoning those still retrying once the shutdown timeout expires.
+	context.AfterFunc(shutdownCtx, webhookDispatcher.Stop)
+	webhookDispatcher.Wait()
+
 	stop()
 }
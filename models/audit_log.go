@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// AuditLog records a single mutation request for security auditing.
+type AuditLog struct {
+	ID          uint      `gorm:"primaryKey"`
+	RequestID   string    `gorm:"index"`
+	Method      string    `gorm:"not null"`
+	Path        string    `gorm:"not null;index"`
+	StatusCode  int       `gorm:"not null"`
+	ActorID     string    `gorm:"not null"`
+	Timestamp   time.Time `gorm:"not null;index"`
+	RequestBody []byte
+}
+
+// TableName returns the database table name for AuditLog.
+func (a *AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository provides database access for audit log entries.
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new AuditLogRepository instance.
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+// Append persists a new audit log entry.
+func (r *AuditLogRepository) Append(ctx context.Context, entry *AuditLog) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// List retrieves the most recent audit log entries, newest first.
+// When path is non-empty only entries for that exact path are returned.
+func (r *AuditLogRepository) List(ctx context.Context, path string, limit int) ([]AuditLog, error) {
+	var entries []AuditLog
+	query := r.db.WithContext(ctx)
+	if path != "" {
+		query = query.Where("path = ?", path)
+	}
+	if err := query.Order("timestamp DESC, id DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
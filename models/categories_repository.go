@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"fmt"
 
 	"gorm.io/gorm"
 )
@@ -18,16 +19,27 @@ func NewCategoriesRepository(db *gorm.DB) *CategoriesRepository {
 	}
 }
 
-// GetAllCategories retrieves all categories from the database.
-func (r *CategoriesRepository) GetAllCategories(ctx context.Context) ([]Category, error) {
+// GetAllCategories retrieves all categories that have not been soft-deleted,
+// together with the number of products in each.
+// order is used as the ORDER BY clause and must come from a trusted whitelist,
+// e.g. "categories.name ASC". An empty order sorts by code, so the result never
+// depends on the physical row order.
+func (r *CategoriesRepository) GetAllCategories(ctx context.Context, order string) ([]Category, error) {
+	if order == "" {
+		order = "categories.code ASC"
+	}
+
 	var categories []Category
-	if err := r.db.WithContext(ctx).Find(&categories).Error; err != nil {
+	query := withProductCount(r.db.WithContext(ctx)).Where("categories.deleted_at IS NULL").Order(order)
+	if err := query.Find(&categories).Error; err != nil {
 		return nil, err
 	}
 	return categories, nil
 }
 
 // CreateCategory creates a new category with the given code and name.
+// Returns ErrDuplicateCode if a category with the same code already exists, and
+// ErrInvalidCategoryInput if the code or name violates a check constraint (e.g. is blank).
 func (r *CategoriesRepository) CreateCategory(ctx context.Context, code, name string) (*Category, error) {
 	category := Category{
 		Code: code,
@@ -35,8 +47,98 @@ func (r *CategoriesRepository) CreateCategory(ctx context.Context, code, name st
 	}
 
 	if err := r.db.WithContext(ctx).Create(&category).Error; err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateCode, code)
+		}
+		if isPgError(err, pgCheckViolation) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCategoryInput, err)
+		}
 		return nil, err
 	}
 
 	return &category, nil
 }
+
+// UpdateCategory renames the active category with the given code.
+// Returns gorm.ErrRecordNotFound if no active category matches.
+func (r *CategoriesRepository) UpdateCategory(ctx context.Context, code, name string) (*Category, error) {
+	var category Category
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Category{}).
+			Where("code = ? AND deleted_at IS NULL", code).
+			Update("name", name)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return withProductCount(tx).Where("categories.code = ?", code).First(&category).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteCategory soft-deletes the active category with the given code by setting deleted_at.
+// Products keep referencing a soft-deleted category, so categories in use can be deleted.
+// Returns gorm.ErrRecordNotFound if no active category matches, or
+// ErrCategoryInUse if the database refuses the change with a foreign key violation.
+func (r *CategoriesRepository) DeleteCategory(ctx context.Context, code string) error {
+	result := r.db.WithContext(ctx).Model(&Category{}).
+		Where("code = ? AND deleted_at IS NULL", code).
+		Update("deleted_at", gorm.Expr("NOW()"))
+	if result.Error != nil {
+		if isPgError(result.Error, pgForeignKeyViolation) {
+			return fmt.Errorf("%w: %s", ErrCategoryInUse, code)
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetCategoryIDByCode returns the ID of the category with the given code.
+// Soft-deleted categories are included, as their products still reference them.
+// Returns gorm.ErrRecordNotFound if no category has the code.
+func (r *CategoriesRepository) GetCategoryIDByCode(ctx context.Context, code string) (uint, error) {
+	var category Category
+	if err := r.db.WithContext(ctx).Select("id").Where("code = ?", code).Take(&category).Error; err != nil {
+		return 0, err
+	}
+	return category.ID, nil
+}
+
+// RestoreCategory clears deleted_at on the soft-deleted category with the given code.
+// Returns gorm.ErrRecordNotFound if no soft-deleted category matches.
+func (r *CategoriesRepository) RestoreCategory(ctx context.Context, code string) (*Category, error) {
+	var category Category
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Category{}).
+			Where("code = ? AND deleted_at IS NOT NULL", code).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return withProductCount(tx).Where("categories.code = ?", code).First(&category).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// withProductCount selects categories along with the number of products referencing each.
+// Products are hard-deleted, so every joined product row counts.
+func withProductCount(query *gorm.DB) *gorm.DB {
+	return query.Model(&Category{}).
+		Select("categories.*, COUNT(products.id) AS product_count").
+		Joins("LEFT JOIN products ON products.category_id = categories.id").
+		Group("categories.id")
+}
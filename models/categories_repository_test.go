@@ -0,0 +1,121 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestGetAllCategories_Order(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    string
+		expected string
+	}{
+		{name: "defaults to code", order: "", expected: "categories.code ASC"},
+		{name: "requested order", order: "categories.name DESC", expected: "categories.name DESC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			repo := NewCategoriesRepository(db)
+
+			mock.ExpectQuery(`WHERE categories.deleted_at IS NULL .*ORDER BY ` + regexp.QuoteMeta(tt.expected) + `$`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "product_count"}).
+					AddRow(3, "ACCESSORIES", "Accessories", 0).
+					AddRow(1, "CLOTHING", "Clothing", 2))
+
+			categories, err := repo.GetAllCategories(context.Background(), tt.order)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(categories) != 2 || categories[0].Code != "ACCESSORIES" || categories[1].Code != "CLOTHING" {
+				t.Errorf("expected categories in query order, got %+v", categories)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unexpected statements: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetCategoryIDByCode(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCategoriesRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "categories" WHERE code = $1 LIMIT $2`)).
+		WithArgs("SHOES", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	id, err := repo.GetCategoryIDByCode(context.Background(), "SHOES")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id != 3 {
+		t.Errorf("expected ID 3, got %d", id)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetCategoryIDByCode_NotFound(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCategoriesRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "categories" WHERE code = $1 LIMIT $2`)).
+		WithArgs("MISSING", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.GetCategoryIDByCode(context.Background(), "MISSING")
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected gorm.ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestCreateCategory_CheckViolationIsInvalidInput(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCategoriesRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "categories"`)).
+		WillReturnError(&pgconn.PgError{Code: pgCheckViolation, ConstraintName: "chk_categories_code_not_blank"})
+	mock.ExpectRollback()
+
+	_, err := repo.CreateCategory(context.Background(), " ", "Blank")
+
+	if !errors.Is(err, ErrInvalidCategoryInput) {
+		t.Errorf("expected ErrInvalidCategoryInput, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestDeleteCategory_SoftDeletesWithoutCheckingProducts(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCategoriesRepository(db)
+
+	// A single UPDATE: products referencing the category do not prevent the soft delete.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "categories" SET "deleted_at"=NOW() WHERE code = $1 AND deleted_at IS NULL`)).
+		WithArgs("CLOTHING").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.DeleteCategory(context.Background(), "CLOTHING"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
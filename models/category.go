@@ -1,12 +1,20 @@
 // Package models defines database models and repositories.
 package models
 
+import "time"
+
 // Category represents a product category in the catalog.
 // It includes a unique code and a human-readable name.
+// Categories are soft-deleted: DeletedAt is set instead of removing the row,
+// so products keep their historical category reference.
 type Category struct {
-	ID   uint   `gorm:"primaryKey"`
-	Code string `gorm:"uniqueIndex;not null"`
-	Name string `gorm:"not null"`
+	ID        uint       `gorm:"primaryKey"`
+	Code      string     `gorm:"uniqueIndex;not null"`
+	Name      string     `gorm:"not null"`
+	DeletedAt *time.Time `gorm:"index"`
+	// ProductCount is the number of products in the category. It is read-only
+	// and only populated by queries that select it, such as GetAllCategories.
+	ProductCount int64 `gorm:"->;-:migration"`
 }
 
 // TableName returns the database table name for Category.
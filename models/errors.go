@@ -0,0 +1,65 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrInsufficientStock indicates that a stock adjustment would make a variant's quantity negative.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// ErrInvalidOrderQuantity indicates that a product's order quantity bounds would become inconsistent.
+var ErrInvalidOrderQuantity = errors.New("invalid order quantity bounds")
+
+// ErrInvalidSalePrice indicates that a product's sale price would not be below its price.
+var ErrInvalidSalePrice = errors.New("sale price must be below the price")
+
+// ErrNegativePrice indicates that the database rejected a product's price because it is negative.
+var ErrNegativePrice = errors.New("price must not be negative")
+
+// ErrDuplicateCode indicates that a record with the same unique code already exists.
+var ErrDuplicateCode = errors.New("duplicate code")
+
+// ErrInvalidCategoryInput indicates that the database rejected a category's code or name, e.g. because it is blank.
+var ErrInvalidCategoryInput = errors.New("invalid category input")
+
+// ErrCategoryInUse indicates that a category cannot be removed because products reference it.
+var ErrCategoryInUse = errors.New("category in use")
+
+// RowError reports which row of a bulk operation failed.
+type RowError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the failing row index and its error.
+func (e *RowError) Error() string {
+	return "row " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the row's error.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+)
+
+// isPgError reports whether err is a Postgres error with the given SQLSTATE code.
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+// isPgConstraintError reports whether err is a Postgres error with the given
+// SQLSTATE code raised by the named constraint.
+func isPgConstraintError(err error, code, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code && pgErr.ConstraintName == constraint
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceHistory records a single change of a product's base price.
+type PriceHistory struct {
+	ID        uint            `gorm:"primaryKey"`
+	ProductID uint            `gorm:"not null;index"`
+	OldPrice  decimal.Decimal `gorm:"type:decimal(10,2);not null"`
+	NewPrice  decimal.Decimal `gorm:"type:decimal(10,2);not null"`
+	ChangedAt time.Time       `gorm:"not null"`
+}
+
+// TableName returns the database table name for PriceHistory.
+func (p *PriceHistory) TableName() string {
+	return "product_price_history"
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// PriceHistoryRepository provides database access for product price history.
+type PriceHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceHistoryRepository creates a new PriceHistoryRepository instance.
+func NewPriceHistoryRepository(db *gorm.DB) *PriceHistoryRepository {
+	return &PriceHistoryRepository{
+		db: db,
+	}
+}
+
+// GetHistory retrieves the price changes of the product with the given code, oldest first.
+func (r *PriceHistoryRepository) GetHistory(ctx context.Context, productCode string) ([]PriceHistory, error) {
+	var history []PriceHistory
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN products ON products.id = product_price_history.product_id").
+		Where("products.code = ?", productCode).
+		Order("product_price_history.changed_at ASC, product_price_history.id ASC").
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
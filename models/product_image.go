@@ -0,0 +1,15 @@
+package models
+
+// ProductImage is a single image in a product's gallery.
+// Images are displayed in ascending Position order.
+type ProductImage struct {
+	ID        uint   `gorm:"primaryKey"`
+	ProductID uint   `gorm:"not null;index"`
+	URL       string `gorm:"size:2048;not null"`
+	Position  int    `gorm:"not null;default:0"`
+}
+
+// TableName returns the database table name for ProductImage.
+func (p *ProductImage) TableName() string {
+	return "product_images"
+}
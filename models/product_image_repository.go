@@ -0,0 +1,52 @@
+package models
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ProductImageRepository provides database access for product image galleries.
+type ProductImageRepository struct {
+	db *gorm.DB
+}
+
+// NewProductImageRepository creates a new ProductImageRepository instance.
+func NewProductImageRepository(db *gorm.DB) *ProductImageRepository {
+	return &ProductImageRepository{
+		db: db,
+	}
+}
+
+// GetImages retrieves the gallery of the product with the given code, ordered by position.
+func (r *ProductImageRepository) GetImages(ctx context.Context, productCode string) ([]ProductImage, error) {
+	var images []ProductImage
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN products ON products.id = product_images.product_id").
+		Where("products.code = ?", productCode).
+		Order("product_images.position ASC, product_images.id ASC").
+		Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// CreateImage inserts a new image. The generated ID is set on image.
+func (r *ProductImageRepository) CreateImage(ctx context.Context, image *ProductImage) error {
+	return r.db.WithContext(ctx).Create(image).Error
+}
+
+// DeleteImage removes the image with the given ID from the gallery of the product with the given code.
+// Returns gorm.ErrRecordNotFound if no such image belongs to the product.
+func (r *ProductImageRepository) DeleteImage(ctx context.Context, productCode string, id uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND product_id = (SELECT id FROM products WHERE code = ?)", id, productCode).
+		Delete(&ProductImage{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
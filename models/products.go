@@ -2,18 +2,55 @@
 package models
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
 // Product represents a product in the catalog.
-// It includes a unique code, a price, and belongs to a category.
+// It includes a unique code and slug, a price, an optional brand and primary image URL,
+// and belongs to a category. Further images live in the ProductImage gallery.
 type Product struct {
 	ID         uint            `gorm:"primaryKey"`
 	Code       string          `gorm:"uniqueIndex;not null"`
-	Price      decimal.Decimal `gorm:"type:decimal(10,2);not null"`
+	Slug       string          `gorm:"uniqueIndex;size:256;not null"`
+	Price      decimal.Decimal `gorm:"type:decimal(10,2);not null;check:chk_products_price_non_negative,price >= 0"`
+	Brand      *string         `gorm:"size:256"`
+	ImageURL   *string         `gorm:"size:2048"`
 	CategoryID *uint           `gorm:"index"`
-	Category   *Category       `gorm:"foreignKey:CategoryID"`
+	Category   *Category       `gorm:"foreignKey:CategoryID;constraint:fk_products_category,OnDelete:SET NULL"`
 	Variants   []Variant       `gorm:"foreignKey:ProductID"`
+	// MinOrderQty and MaxOrderQty bound the quantity of a single order line.
+	// A nil MaxOrderQty means there is no upper limit.
+	MinOrderQty int `gorm:"not null;default:1"`
+	MaxOrderQty *int
+	// Label flags the product for merchandising, e.g. homepage carousels.
+	// It is one of the Label* constants and defaults to LabelNone.
+	Label string `gorm:"size:16;not null;default:'none';check:chk_products_label,label IN ('new', 'sale', 'featured', 'none')"`
+	// SalePrice is the discounted price while the product is on sale, nil otherwise.
+	// Price keeps the original price.
+	SalePrice *decimal.Decimal `gorm:"type:decimal(10,2)"`
+	// CreatedAt and UpdatedAt are maintained by GORM on create and update.
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;index"`
+}
+
+// Product labels.
+const (
+	LabelNew      = "new"
+	LabelSale     = "sale"
+	LabelFeatured = "featured"
+	LabelNone     = "none"
+)
+
+// validOrderQuantities reports whether the product's order quantity bounds are consistent.
+func (p *Product) validOrderQuantities() bool {
+	return p.MinOrderQty >= 1 && (p.MaxOrderQty == nil || *p.MaxOrderQty >= p.MinOrderQty)
+}
+
+// validSalePrice reports whether the product's sale price, if any, is below its price.
+func (p *Product) validSalePrice() bool {
+	return p.SalePrice == nil || (!p.SalePrice.IsNegative() && p.SalePrice.LessThan(p.Price))
 }
 
 // TableName returns the database table name for Product.
@@ -2,15 +2,44 @@ package models
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ProductUpdate holds the fields to change on a product. Nil fields are left untouched.
+// An empty Brand clears the product's brand, an empty ImageURL its image, a zero
+// MaxOrderQty removes the upper limit and a zero SalePrice ends the product's sale.
+type ProductUpdate struct {
+	Price       *decimal.Decimal
+	Brand       *string
+	ImageURL    *string
+	MinOrderQty *int
+	MaxOrderQty *int
+	SalePrice   *decimal.Decimal
+}
+
 // ProductFilter holds filter criteria for product queries.
 type ProductFilter struct {
-	Category      string
+	// Category keeps products of the category with the given code, joining categories to match it.
+	Category string
+	// CategoryIDs keeps products whose category_id is one of the IDs, without joining categories.
+	// Nil or empty means no restriction.
+	CategoryIDs   []uint
+	Brand         string
 	PriceLessThan *decimal.Decimal
+	InStock       bool
+	UpdatedAfter  *time.Time
+	// MinOrderQtyLessThan keeps products whose minimum order quantity is below the value.
+	MinOrderQtyLessThan *int
+	Label               string
+	// OnSale keeps products whose sale price is below their price.
+	OnSale bool
+	// CreatedWithinDays keeps products created in the last given number of days.
+	CreatedWithinDays int
 }
 
 // ProductsRepository provides database access for product operations.
@@ -25,45 +54,113 @@ func NewProductsRepository(db *gorm.DB) *ProductsRepository {
 	}
 }
 
+// pagedProduct is a product row along with the number of rows matching the query.
+type pagedProduct struct {
+	Product
+	TotalCount int64
+}
+
 // GetAllProducts retrieves paginated products with their categories and variants.
-// Results are ordered by ID for deterministic pagination.
+// Results are ordered by ID for deterministic pagination. The Category filter is
+// an exact (case-sensitive) match on the category code.
+//
+// The total is read from a COUNT(*) OVER() window on the page query, so a page
+// costs a single statement. Only a page past the end, which has no row to carry
+// the total, falls back to a separate count.
 func (r *ProductsRepository) GetAllProducts(ctx context.Context, offset, limit int, filter ProductFilter) ([]Product, int64, error) {
-	var products []Product
+	var rows []pagedProduct
 	var total int64
 
-	// Build base query with filters applied
-	baseQuery := r.applyFilters(r.db.WithContext(ctx).Model(&Product{}), filter)
+	// Both queries derive from the same filtered base. The session lets each
+	// of them add its own clauses without leaking into the other, so the count
+	// never carries the fetch's ORDER BY, OFFSET or LIMIT.
+	base := r.applyFilters(r.db.WithContext(ctx).Model(&Product{}), filter).Session(&gorm.Session{})
+	countQuery := base
+	fetchQuery := base.Select("products.*", "COUNT(*) OVER() AS total_count").Preload("Variants")
 
-	// Get total count with filters applied
-	if err := baseQuery.Count(&total).Error; err != nil {
-		return nil, 0, err
+	// The category join is needed in both queries: the count must only include
+	// products of the category, and the fetch must return the same rows.
+	// The fetch uses an eager Joins("Category"), which both filters and fills
+	// Product.Category in one query. Without the filter, Preload runs a second
+	// small IN query instead of widening every product row with category columns.
+	// The join selects the category columns explicitly because ProductCount is
+	// not a real column.
+	if filter.Category != "" {
+		countQuery = countQuery.Joins("JOIN categories ON categories.id = products.category_id").
+			Where("categories.code = ?", filter.Category)
+		fetchQuery = fetchQuery.InnerJoins("Category", r.db.Select("id", "code", "name", "deleted_at")).
+			Where(`"Category".code = ?`, filter.Category)
+	} else {
+		fetchQuery = fetchQuery.Preload("Category")
 	}
 
 	// Get paginated products with deterministic ordering
-	findQuery := r.applyFilters(r.db.WithContext(ctx).Preload("Category").Preload("Variants"), filter)
-	if err := findQuery.
+	if err := fetchQuery.
 		Order("products.id ASC").
 		Offset(offset).
 		Limit(limit).
-		Find(&products).Error; err != nil {
+		Find(&rows).Error; err != nil {
 		return nil, 0, err
 	}
 
+	if len(rows) > 0 {
+		total = rows[0].TotalCount
+	} else if offset > 0 {
+		// The window is computed over the rows the page skipped, so an empty
+		// page past the end still needs the real total.
+		if err := countQuery.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	products := make([]Product, len(rows))
+	for i, row := range rows {
+		products[i] = row.Product
+	}
 	return products, total, nil
 }
 
-// applyFilters applies filter criteria to a query.
-// Note: Category filter uses exact match (case-sensitive) on category code.
+// applyFilters applies the filter criteria other than Category to a query;
+// GetAllProducts joins categories itself because its count and find queries
+// do it differently. Note: Brand filter matches case-insensitively.
 func (r *ProductsRepository) applyFilters(query *gorm.DB, filter ProductFilter) *gorm.DB {
-	if filter.Category != "" {
-		query = query.Joins("JOIN categories ON categories.id = products.category_id").
-			Where("categories.code = ?", filter.Category)
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("products.category_id IN ?", filter.CategoryIDs)
+	}
+
+	if filter.Brand != "" {
+		query = query.Where("LOWER(products.brand) = LOWER(?)", filter.Brand)
 	}
 
 	if filter.PriceLessThan != nil {
 		query = query.Where("products.price < ?", *filter.PriceLessThan)
 	}
 
+	if filter.UpdatedAfter != nil {
+		query = query.Where("products.updated_at > ?", *filter.UpdatedAfter)
+	}
+
+	if filter.MinOrderQtyLessThan != nil {
+		query = query.Where("products.min_order_qty < ?", *filter.MinOrderQtyLessThan)
+	}
+
+	if filter.Label != "" {
+		query = query.Where("products.label = ?", filter.Label)
+	}
+
+	if filter.OnSale {
+		query = query.Where("products.sale_price IS NOT NULL AND products.sale_price < products.price")
+	}
+
+	if filter.CreatedWithinDays > 0 {
+		query = query.Where("products.created_at >= NOW() - make_interval(days => ?)", filter.CreatedWithinDays)
+	}
+
+	if filter.InStock {
+		// EXISTS avoids duplicating product rows when several variants are in stock.
+		query = query.Where("EXISTS (SELECT 1 FROM product_variants WHERE product_variants.product_id = products.id AND product_variants.stock_quantity > 0)")
+	}
+
 	return query
 }
 
@@ -77,3 +174,211 @@ func (r *ProductsRepository) GetProductByCode(ctx context.Context, code string)
 	}
 	return &product, nil
 }
+
+// GetProductBySlug retrieves a product by its unique slug.
+func (r *ProductsRepository) GetProductBySlug(ctx context.Context, slug string) (*Product, error) {
+	var product Product
+	if err := r.db.WithContext(ctx).Preload("Category").Preload("Variants").
+		Where("slug = ?", slug).
+		First(&product).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetProductSlugs retrieves a page of product slugs ordered by ID, along with
+// the total number of products. A limit of 0 only counts the products.
+func (r *ProductsRepository) GetProductSlugs(ctx context.Context, offset, limit int) ([]string, int64, error) {
+	var slugs []string
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&Product{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if limit == 0 {
+		return nil, total, nil
+	}
+	if err := query.Order("id ASC").Offset(offset).Limit(limit).Pluck("slug", &slugs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return slugs, total, nil
+}
+
+// BulkCreate inserts the given products in a single transaction, filling in
+// their IDs and slugs. If any insert fails, nothing is inserted and a *RowError
+// with the index of the failing product is returned; a duplicate code is
+// reported as ErrDuplicateCode and a negative price as ErrNegativePrice.
+func (r *ProductsRepository) BulkCreate(ctx context.Context, products []Product) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range products {
+			if err := tx.Create(&products[i]).Error; err != nil {
+				switch {
+				case isPgError(err, pgUniqueViolation):
+					err = fmt.Errorf("%w: %s", ErrDuplicateCode, products[i].Code)
+				case isPgConstraintError(err, pgCheckViolation, "chk_products_price_non_negative"):
+					err = fmt.Errorf("%w: %w", ErrNegativePrice, err)
+				}
+				return &RowError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// GetProductByBarcode retrieves the product owning the variant with the given barcode.
+func (r *ProductsRepository) GetProductByBarcode(ctx context.Context, barcode string) (*Product, error) {
+	var product Product
+	if err := r.db.WithContext(ctx).Preload("Category").Preload("Variants").
+		Where("id = (?)", r.db.Model(&Variant{}).Select("product_id").Where("barcode = ?", barcode)).
+		First(&product).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// CreateVariant inserts the given variant.
+// Returns ErrDuplicateCode naming the barcode or SKU if another variant already has it.
+func (r *ProductsRepository) CreateVariant(ctx context.Context, variant *Variant) error {
+	if err := r.db.WithContext(ctx).Create(variant).Error; err != nil {
+		switch {
+		case isPgConstraintError(err, pgUniqueViolation, variantBarcodeIndex) && variant.Barcode != nil:
+			return fmt.Errorf("%w: barcode %s", ErrDuplicateCode, *variant.Barcode)
+		case isPgError(err, pgUniqueViolation):
+			return fmt.Errorf("%w: sku %s", ErrDuplicateCode, variant.SKU)
+		}
+		return err
+	}
+	return nil
+}
+
+// AdjustVariantStock adds delta to the stock quantity of the variant identified by
+// product code and SKU. The variant row is locked for the duration of the update.
+// Returns gorm.ErrRecordNotFound if the variant does not exist and
+// ErrInsufficientStock if the resulting quantity would be negative.
+func (r *ProductsRepository) AdjustVariantStock(ctx context.Context, code, sku string, delta int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var variant Variant
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Joins("JOIN products ON products.id = product_variants.product_id").
+			Where("products.code = ? AND product_variants.sku = ?", code, sku).
+			First(&variant).Error; err != nil {
+			return err
+		}
+
+		newQuantity := variant.StockQuantity + delta
+		if newQuantity < 0 {
+			return ErrInsufficientStock
+		}
+
+		return tx.Model(&variant).Update("stock_quantity", newQuantity).Error
+	})
+}
+
+// UpdateProduct applies the given changes to the product with the given code.
+// When the price changes, a PriceHistory record is written in the same transaction.
+// Returns gorm.ErrRecordNotFound if the product does not exist,
+// ErrInvalidOrderQuantity if the resulting order quantity bounds are inconsistent, and
+// ErrInvalidSalePrice if the resulting sale price would not be below the price.
+func (r *ProductsRepository) UpdateProduct(ctx context.Context, code string, update ProductUpdate) (*Product, error) {
+	var product Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SELECT ... FOR UPDATE locks the product row until the transaction ends,
+		// so concurrent updates of one product run one after the other and each
+		// validates against, and records price history from, the committed state
+		// instead of overwriting the other's changes. The cost is that writers of
+		// the same product wait for each other; plain reads take no lock and are
+		// unaffected, so this stays cheap for a catalog that is mostly read.
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code = ?", code).
+			First(&product).Error; err != nil {
+			return err
+		}
+
+		if update.Price != nil || update.SalePrice != nil {
+			// The sale price must stay below the price, whichever of the two changes.
+			candidate := product
+			if update.Price != nil {
+				candidate.Price = *update.Price
+			}
+			if update.SalePrice != nil {
+				candidate.SalePrice = nil
+				if !update.SalePrice.IsZero() {
+					candidate.SalePrice = update.SalePrice
+				}
+			}
+			if !candidate.validSalePrice() {
+				return ErrInvalidSalePrice
+			}
+			if update.SalePrice != nil {
+				if err := tx.Model(&product).Update("sale_price", candidate.SalePrice).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if update.Price != nil && !update.Price.Equal(product.Price) {
+			history := PriceHistory{
+				ProductID: product.ID,
+				OldPrice:  product.Price,
+				NewPrice:  *update.Price,
+				ChangedAt: time.Now().UTC(),
+			}
+			if err := tx.Create(&history).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&product).Update("price", *update.Price).Error; err != nil {
+				return err
+			}
+		}
+
+		if update.Brand != nil {
+			var brand *string
+			if *update.Brand != "" {
+				brand = update.Brand
+			}
+			if err := tx.Model(&product).Update("brand", brand).Error; err != nil {
+				return err
+			}
+		}
+
+		if update.ImageURL != nil {
+			var imageURL *string
+			if *update.ImageURL != "" {
+				imageURL = update.ImageURL
+			}
+			if err := tx.Model(&product).Update("image_url", imageURL).Error; err != nil {
+				return err
+			}
+		}
+
+		if update.MinOrderQty != nil || update.MaxOrderQty != nil {
+			if update.MinOrderQty != nil {
+				product.MinOrderQty = *update.MinOrderQty
+			}
+			if update.MaxOrderQty != nil {
+				product.MaxOrderQty = update.MaxOrderQty
+				if *update.MaxOrderQty == 0 {
+					product.MaxOrderQty = nil
+				}
+			}
+			if !product.validOrderQuantities() {
+				return ErrInvalidOrderQuantity
+			}
+			if err := tx.Model(&product).Updates(map[string]any{
+				"min_order_qty": product.MinOrderQty,
+				"max_order_qty": product.MaxOrderQty,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetProductByCode(ctx, code)
+}
@@ -0,0 +1,452 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMockRepository returns a ProductsRepository backed by sqlmock.
+func newMockRepository(t *testing.T) (*ProductsRepository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock := newMockDB(t)
+	return NewProductsRepository(db), mock
+}
+
+// newMockDB returns a GORM connection backed by sqlmock.
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:               logger.Discard,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	return db, mock
+}
+
+func TestGetAllProducts_PageReadsTotalFromWindow(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+
+	// A single statement returns the page and the total; no count query follows.
+	mock.ExpectQuery(`^SELECT products\.\*,COUNT\(\*\) OVER\(\) AS total_count FROM "products" WHERE LOWER\(products.brand\) = LOWER\(\$1\) ORDER BY products.id ASC LIMIT \$2 OFFSET \$3$`).
+		WithArgs("acme", 2, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "price", "min_order_qty", "created_at", "updated_at", "total_count"}).
+			AddRow(5, "PROD005", "10.99", 1, now, now, 12).
+			AddRow(6, "PROD006", "12.99", 1, now, now, 12))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_variants" WHERE "product_variants"."product_id" IN ($1,$2)`)).
+		WithArgs(5, 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "name", "sku"}))
+
+	products, total, err := repo.GetAllProducts(context.Background(), 4, 2, ProductFilter{Brand: "acme"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 12 {
+		t.Errorf("expected total 12, got %d", total)
+	}
+	if len(products) != 2 || products[0].Code != "PROD005" || products[1].Code != "PROD006" {
+		t.Errorf("expected PROD005 and PROD006, got %+v", products)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetAllProducts_EmptyFirstPageHasZeroTotal(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectQuery(`^SELECT products\.\*,COUNT\(\*\) OVER\(\) AS total_count FROM "products" ORDER BY products.id ASC LIMIT \$1$`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "total_count"}))
+
+	products, total, err := repo.GetAllProducts(context.Background(), 0, 10, ProductFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 0 {
+		t.Errorf("expected total 0, got %d", total)
+	}
+	if len(products) != 0 {
+		t.Errorf("expected empty page, got %d products", len(products))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetAllProducts_PagePastEndFallsBackToCount(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectQuery(`^SELECT products\.\*,COUNT\(\*\) OVER\(\) AS total_count,.+ FROM "products" INNER JOIN "categories" "Category" ON .+ WHERE LOWER\(products.brand\) = LOWER\(\$1\) AND "Category".code = \$2 ORDER BY products.id ASC LIMIT \$3 OFFSET \$4$`).
+		WithArgs("acme", "SHOES", 5, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "total_count"}))
+	// The count must not inherit the fetch's ordering or pagination.
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "products" JOIN categories ON categories.id = products.category_id WHERE LOWER\(products.brand\) = LOWER\(\$1\) AND categories.code = \$2$`).
+		WithArgs("acme", "SHOES").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+	products, total, err := repo.GetAllProducts(context.Background(), 10, 5, ProductFilter{Category: "SHOES", Brand: "acme"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 12 {
+		t.Errorf("expected total 12, got %d", total)
+	}
+	if len(products) != 0 {
+		t.Errorf("expected empty page, got %d products", len(products))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetAllProducts_CategoryIDsFilterWithoutJoin(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectQuery(`^SELECT products\.\*,COUNT\(\*\) OVER\(\) AS total_count FROM "products" WHERE products.category_id IN \(\$1,\$2\) ORDER BY products.id ASC LIMIT \$3$`).
+		WithArgs(3, 4, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "price", "category_id", "min_order_qty", "created_at", "updated_at", "total_count"}).
+			AddRow(1, "PROD001", "10.99", 3, 1, now, now, 1))
+	// Without the join, the category is preloaded like in an unfiltered listing.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."id" = $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name"}).AddRow(3, "SHOES", "Shoes"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_variants" WHERE "product_variants"."product_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "name", "sku"}))
+
+	products, total, err := repo.GetAllProducts(context.Background(), 0, 10, ProductFilter{CategoryIDs: []uint{3, 4}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 1 {
+		t.Errorf("expected total 1, got %d", total)
+	}
+	if len(products) != 1 || products[0].Category == nil || products[0].Category.Code != "SHOES" {
+		t.Errorf("expected PROD001 in SHOES, got %+v", products)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetAllProducts_CategoryJoinFillsCategory(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectQuery(`^SELECT .+ FROM "products" INNER JOIN "categories" "Category"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "price", "category_id", "min_order_qty", "created_at", "updated_at", "total_count", "Category__id", "Category__code", "Category__name"}).
+			AddRow(1, "PROD001", "10.99", 3, 1, now, now, 1, 3, "SHOES", "Shoes"))
+	// Variants are the only relation still loaded by a separate query.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_variants" WHERE "product_variants"."product_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "name", "sku"}).AddRow(7, 1, "Size 42", "SKU001-42"))
+
+	products, total, err := repo.GetAllProducts(context.Background(), 0, 10, ProductFilter{Category: "SHOES"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 1 {
+		t.Errorf("expected total 1, got %d", total)
+	}
+
+	if len(products) != 1 {
+		t.Fatalf("expected 1 product, got %d", len(products))
+	}
+	product := products[0]
+	if !product.Price.Equal(decimal.RequireFromString("10.99")) {
+		t.Errorf("expected price 10.99, got %s", product.Price)
+	}
+	if product.Category == nil || product.Category.Code != "SHOES" || product.Category.Name != "Shoes" {
+		t.Errorf("expected SHOES category, got %+v", product.Category)
+	}
+	if len(product.Variants) != 1 || product.Variants[0].SKU != "SKU001-42" {
+		t.Errorf("expected variant SKU001-42, got %+v", product.Variants)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestBulkCreate_RollsBackWhenAnInsertFails(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	products := []Product{
+		{Code: "PROD001", Price: decimal.NewFromInt(10)},
+		{Code: "PROD002", Price: decimal.NewFromInt(20)},
+		{Code: "PROD003", Price: decimal.NewFromInt(30)},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT "slug" FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+	mock.ExpectQuery(`^INSERT INTO "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`^SELECT "slug" FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+	mock.ExpectQuery(`^INSERT INTO "products"`).
+		WillReturnError(&pgconn.PgError{Code: pgUniqueViolation})
+	// The third product must never be inserted.
+	mock.ExpectRollback()
+
+	err := repo.BulkCreate(context.Background(), products)
+
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) {
+		t.Fatalf("expected *RowError, got %v", err)
+	}
+	if rowErr.Index != 1 {
+		t.Errorf("expected failing row 1, got %d", rowErr.Index)
+	}
+	if !errors.Is(err, ErrDuplicateCode) {
+		t.Errorf("expected ErrDuplicateCode, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestBulkCreate_NegativePriceViolatesCheck(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	products := []Product{{Code: "PROD001", Price: decimal.NewFromInt(-1)}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT "slug" FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+	mock.ExpectQuery(`^INSERT INTO "products"`).
+		WillReturnError(&pgconn.PgError{Code: pgCheckViolation, ConstraintName: "chk_products_price_non_negative"})
+	mock.ExpectRollback()
+
+	err := repo.BulkCreate(context.Background(), products)
+
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) {
+		t.Fatalf("expected *RowError, got %v", err)
+	}
+	if rowErr.Index != 0 {
+		t.Errorf("expected failing row 0, got %d", rowErr.Index)
+	}
+	if !errors.Is(err, ErrNegativePrice) {
+		t.Errorf("expected ErrNegativePrice, got %v", err)
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		t.Errorf("expected the Postgres error to be wrapped, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestBulkCreate_OtherCheckViolationIsNotNegativePrice(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	products := []Product{{Code: "PROD001", Price: decimal.NewFromInt(10)}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT "slug" FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+	mock.ExpectQuery(`^INSERT INTO "products"`).
+		WillReturnError(&pgconn.PgError{Code: pgCheckViolation, ConstraintName: "chk_products_label"})
+	mock.ExpectRollback()
+
+	err := repo.BulkCreate(context.Background(), products)
+
+	if err == nil || errors.Is(err, ErrNegativePrice) {
+		t.Errorf("expected an error other than ErrNegativePrice, got %v", err)
+	}
+}
+
+func TestBulkCreate_CommitsWhenAllInsertsSucceed(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	products := []Product{
+		{Code: "PROD001", Price: decimal.NewFromInt(10)},
+		{Code: "PROD002", Price: decimal.NewFromInt(20)},
+	}
+
+	mock.ExpectBegin()
+	for i := range products {
+		mock.ExpectQuery(`^SELECT "slug" FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(`^INSERT INTO "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i + 1))
+	}
+	mock.ExpectCommit()
+
+	if err := repo.BulkCreate(context.Background(), products); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if products[0].ID != 1 || products[1].ID != 2 {
+		t.Errorf("expected IDs 1 and 2, got %d and %d", products[0].ID, products[1].ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestUpdateProduct_RejectsSalePriceNotBelowStoredPrice(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	salePrice := decimal.NewFromInt(12)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT \* FROM "products" WHERE code = \$1 .+ FOR UPDATE$`).
+		WithArgs("PROD001", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "price"}).AddRow(1, "PROD001", "10.00"))
+	// Nothing may be written once the sale price is rejected.
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateProduct(context.Background(), "PROD001", ProductUpdate{SalePrice: &salePrice})
+
+	if !errors.Is(err, ErrInvalidSalePrice) {
+		t.Errorf("expected ErrInvalidSalePrice, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetAllProducts_CreatedWithinDays(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectQuery(`^SELECT products\.\*,COUNT\(\*\) OVER\(\) AS total_count FROM "products" WHERE products.created_at >= NOW\(\) - make_interval\(days => \$1\) ORDER BY products.id ASC LIMIT \$2$`).
+		WithArgs(7, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code"}))
+
+	if _, _, err := repo.GetAllProducts(context.Background(), 0, 10, ProductFilter{CreatedWithinDays: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetProductSlugs_CountsAndPlucksPage(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "products"$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`^SELECT "slug" FROM "products" ORDER BY id ASC LIMIT \$1 OFFSET \$2$`).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("prod002").AddRow("prod003"))
+
+	slugs, total, err := repo.GetProductSlugs(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("expected total 3, got %d", total)
+	}
+	if len(slugs) != 2 || slugs[0] != "prod002" || slugs[1] != "prod003" {
+		t.Errorf("expected slugs [prod002 prod003], got %v", slugs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetProductSlugs_ZeroLimitOnlyCounts(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "products"$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	slugs, total, err := repo.GetProductSlugs(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 || slugs != nil {
+		t.Errorf("expected total 3 and no slugs, got %d and %v", total, slugs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestGetProductByCode_NeverAttachesOtherProductsVariants(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectQuery(`^SELECT \* FROM "products" WHERE code = \$1 ORDER BY "products"."id" LIMIT \$2$`).
+		WithArgs("PROD001", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "category_id"}).AddRow(1, "PROD001", nil))
+	// The preload filters on the product's ID. Should a row of another product
+	// come back all the same, GORM refuses to assign it rather than attaching it.
+	mock.ExpectQuery(`^SELECT \* FROM "product_variants" WHERE "product_variants"."product_id" = \$1$`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "name", "sku"}).
+			AddRow(1, 1, "Small", "SKU001S").
+			AddRow(2, 2, "Stray", "SKU002S"))
+
+	product, err := repo.GetProductByCode(context.Background(), "PROD001")
+	if err == nil {
+		for _, v := range product.Variants {
+			if v.ProductID != product.ID {
+				t.Errorf("expected only variants of product %d, got %+v", product.ID, v)
+			}
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected statements: %v", err)
+	}
+}
+
+func TestCreateVariant_DuplicateNamesConstraint(t *testing.T) {
+	barcode := "4006381333931"
+	tests := []struct {
+		name       string
+		constraint string
+		expected   string
+	}{
+		{name: "barcode", constraint: "idx_product_variants_barcode", expected: "duplicate code: barcode 4006381333931"},
+		{name: "sku", constraint: "idx_product_variants_sku", expected: "duplicate code: sku SKU001A"},
+		{name: "unnamed constraint", constraint: "", expected: "duplicate code: sku SKU001A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`^INSERT INTO "product_variants"`).
+				WillReturnError(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: tt.constraint})
+			mock.ExpectRollback()
+
+			err := repo.CreateVariant(context.Background(), &Variant{ProductID: 1, Name: "Small", SKU: "SKU001A", Barcode: &barcode})
+
+			if !errors.Is(err, ErrDuplicateCode) {
+				t.Fatalf("expected ErrDuplicateCode, got %v", err)
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, err.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unexpected statements: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestVariantBarcodeIndex(t *testing.T) {
+	s, err := schema.Parse(&Variant{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	// CreateVariant names the barcode only when this index is violated.
+	for _, index := range s.ParseIndexes() {
+		if index.Name != variantBarcodeIndex {
+			continue
+		}
+		if index.Class != "UNIQUE" || len(index.Fields) != 1 || index.Fields[0].DBName != "barcode" {
+			t.Errorf("expected a unique index on barcode, got %+v", index)
+		}
+		return
+	}
+	t.Errorf("expected an index named %s", variantBarcodeIndex)
+}
+
+func TestProductCategoryConstraint(t *testing.T) {
+	s, err := schema.Parse(&Product{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	rel, ok := s.Relationships.Relations["Category"]
+	if !ok {
+		t.Fatal("expected a Category relationship")
+	}
+	constraint := rel.ParseConstraint()
+	if constraint == nil {
+		t.Fatal("expected a foreign key constraint")
+	}
+
+	// The name is matched by migration 005, so changing it must be deliberate.
+	if constraint.Name != "fk_products_category" {
+		t.Errorf("expected constraint fk_products_category, got %s", constraint.Name)
+	}
+	if constraint.OnDelete != "SET NULL" {
+		t.Errorf("expected ON DELETE SET NULL, got %q", constraint.OnDelete)
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// defaultSlug is used when neither the given slug nor the product code yield any characters.
+const defaultSlug = "product"
+
+// Slugify converts s into a URL-friendly slug: lowercased, whitespace replaced
+// with hyphens and any other non-alphanumeric characters stripped.
+func Slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r), r == '-':
+			pendingHyphen = true
+		}
+	}
+	return b.String()
+}
+
+// BeforeCreate generates the product's slug from its code when none was given,
+// and appends -2, -3, ... when the slug is already taken by another product.
+// Products have no display name yet, so the code is the only source for the slug.
+func (p *Product) BeforeCreate(tx *gorm.DB) error {
+	base := Slugify(p.Slug)
+	if base == "" {
+		base = Slugify(p.Code)
+	}
+	if base == "" {
+		base = defaultSlug
+	}
+
+	var taken []string
+	if err := tx.Session(&gorm.Session{NewDB: true}).Model(&Product{}).
+		Where("slug = ? OR slug LIKE ?", base, base+"-%").
+		Pluck("slug", &taken).Error; err != nil {
+		return err
+	}
+
+	p.Slug = nextFreeSlug(base, taken)
+	return nil
+}
+
+// nextFreeSlug returns base, or base with the lowest numeric suffix from 2 upwards
+// that does not appear in taken.
+func nextFreeSlug(base string, taken []string) string {
+	used := make(map[string]struct{}, len(taken))
+	for _, s := range taken {
+		used[s] = struct{}{}
+	}
+
+	if _, ok := used[base]; !ok {
+		return base
+	}
+	for n := 2; ; n++ {
+		candidate := base + "-" + strconv.Itoa(n)
+		if _, ok := used[candidate]; !ok {
+			return candidate
+		}
+	}
+}
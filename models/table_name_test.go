@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+// Table names are also spelled out in raw SQL, the sql/ scripts and the e2e
+// ClearDatabase helper, so renaming one must be a deliberate change.
+
+func TestVariantTableName(t *testing.T) {
+	if got := (&Variant{}).TableName(); got != "product_variants" {
+		t.Errorf("expected table product_variants, got %s", got)
+	}
+}
+
+func TestProductTableName(t *testing.T) {
+	if got := (&Product{}).TableName(); got != "products" {
+		t.Errorf("expected table products, got %s", got)
+	}
+}
+
+func TestCategoryTableName(t *testing.T) {
+	if got := (&Category{}).TableName(); got != "categories" {
+		t.Errorf("expected table categories, got %s", got)
+	}
+}
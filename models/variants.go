@@ -4,8 +4,12 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// variantBarcodeIndex is the unique index on Barcode, as named in its struct tag.
+// CreateVariant matches it to tell a duplicate barcode from a duplicate SKU.
+const variantBarcodeIndex = "idx_product_variants_barcode"
+
 // Variant represents a product variant in the catalog.
-// It includes a unique name, SKU, and an optional price.
+// It includes a unique name, SKU, an optional barcode, and an optional price.
 // When Price is nil, the variant inherits the product's base price.
 // When Price is set (even to 0.00), that value is used as the variant's price.
 type Variant struct {
@@ -14,6 +18,10 @@ type Variant struct {
 	Name      string           `gorm:"not null"`
 	SKU       string           `gorm:"uniqueIndex;not null"`
 	Price     *decimal.Decimal `gorm:"type:decimal(10,2);null"`
+	// Barcode is the EAN scanned in the warehouse; it is unique when set.
+	Barcode *string `gorm:"size:64;uniqueIndex:idx_product_variants_barcode,where:barcode IS NOT NULL"`
+	// StockQuantity is the number of units available; it never goes below zero.
+	StockQuantity int `gorm:"not null;default:0"`
 }
 
 // TableName returns the database table name for Variant.
@@ -0,0 +1,16 @@
+package models
+
+// Webhook is an integration endpoint notified of a domain event, such as
+// "category.created".
+// Deliveries are signed with Secret.
+type Webhook struct {
+	ID     uint   `gorm:"primaryKey"`
+	URL    string `gorm:"size:2048;not null"`
+	Secret string `gorm:"not null"`
+	Event  string `gorm:"size:64;not null;index"`
+}
+
+// TableName returns the database table name for Webhook.
+func (w *Webhook) TableName() string {
+	return "webhooks"
+}
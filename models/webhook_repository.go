@@ -0,0 +1,76 @@
+package models
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// WebhooksRepository provides database access for webhook registrations.
+type WebhooksRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhooksRepository creates a new WebhooksRepository instance.
+func NewWebhooksRepository(db *gorm.DB) *WebhooksRepository {
+	return &WebhooksRepository{
+		db: db,
+	}
+}
+
+// CreateWebhook inserts a new webhook. The generated ID is set on webhook.
+func (r *WebhooksRepository) CreateWebhook(ctx context.Context, webhook *Webhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+// GetWebhook retrieves the webhook with the given ID.
+// Returns gorm.ErrRecordNotFound if it doesn't exist.
+func (r *WebhooksRepository) GetWebhook(ctx context.Context, id uint) (*Webhook, error) {
+	var webhook Webhook
+	if err := r.db.WithContext(ctx).First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks retrieves the webhooks registered for event, ordered by ID.
+// An empty event lists all webhooks.
+func (r *WebhooksRepository) ListWebhooks(ctx context.Context, event string) ([]Webhook, error) {
+	var webhooks []Webhook
+	query := r.db.WithContext(ctx)
+	if event != "" {
+		query = query.Where("event = ?", event)
+	}
+	if err := query.Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook saves the URL, secret and event of an existing webhook.
+// Returns gorm.ErrRecordNotFound if it doesn't exist.
+func (r *WebhooksRepository) UpdateWebhook(ctx context.Context, webhook *Webhook) error {
+	result := r.db.WithContext(ctx).Model(&Webhook{ID: webhook.ID}).
+		Select("url", "secret", "event").
+		Updates(webhook)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteWebhook removes the webhook with the given ID.
+// Returns gorm.ErrRecordNotFound if it doesn't exist.
+func (r *WebhooksRepository) DeleteWebhook(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&Webhook{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
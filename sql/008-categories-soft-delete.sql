@@ -0,0 +1,5 @@
+-- Soft delete support for categories
+ALTER TABLE categories
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories(deleted_at);
@@ -0,0 +1,3 @@
+-- Stock quantity tracking for variants
+ALTER TABLE product_variants
+ADD COLUMN IF NOT EXISTS stock_quantity INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,15 @@
+-- Audit trail of mutation requests
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id SERIAL PRIMARY KEY,
+    request_id VARCHAR(64),
+    method VARCHAR(16) NOT NULL,
+    path TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    actor_id VARCHAR(256) NOT NULL,
+    timestamp TIMESTAMP NOT NULL DEFAULT NOW(),
+    request_body BYTEA
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_request_id ON audit_logs(request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_path ON audit_logs(path);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_timestamp ON audit_logs(timestamp);
@@ -0,0 +1,6 @@
+-- Creation and last modification timestamps for products
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_products_updated_at ON products(updated_at);
@@ -0,0 +1,5 @@
+-- Optional brand for products, filtered case-insensitively
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS brand VARCHAR(256);
+
+CREATE INDEX IF NOT EXISTS idx_products_brand_lower ON products(LOWER(brand));
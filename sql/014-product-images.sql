@@ -0,0 +1,12 @@
+-- Primary product image and ordered image gallery
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS image_url VARCHAR(2048);
+
+CREATE TABLE IF NOT EXISTS product_images (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    url VARCHAR(2048) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_images_product_id ON product_images(product_id);
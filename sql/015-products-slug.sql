@@ -0,0 +1,21 @@
+-- Human-readable product slugs, backfilled from product codes
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS slug VARCHAR(256);
+
+WITH base AS (
+    SELECT id,
+           trim(both '-' FROM regexp_replace(regexp_replace(regexp_replace(lower(code), '\s+', '-', 'g'), '[^a-z0-9-]', '', 'g'), '-+', '-', 'g')) AS slug
+    FROM products
+    WHERE slug IS NULL
+), numbered AS (
+    SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n
+    FROM base
+)
+UPDATE products
+SET slug = CASE WHEN numbered.n = 1 THEN numbered.slug ELSE numbered.slug || '-' || numbered.n END
+FROM numbered
+WHERE products.id = numbered.id;
+
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_slug ON products(slug);
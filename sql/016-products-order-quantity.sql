@@ -0,0 +1,4 @@
+-- Order quantity constraints for products; a NULL maximum means unlimited
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS min_order_qty INTEGER NOT NULL DEFAULT 1,
+ADD COLUMN IF NOT EXISTS max_order_qty INTEGER;
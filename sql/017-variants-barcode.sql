@@ -0,0 +1,5 @@
+-- Optional EAN barcodes for variants, unique among variants that have one
+ALTER TABLE product_variants
+ADD COLUMN IF NOT EXISTS barcode VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_variants_barcode ON product_variants(barcode) WHERE barcode IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Merchandising label for homepage carousels
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS label VARCHAR(16) NOT NULL DEFAULT 'none'
+    CONSTRAINT chk_products_label CHECK (label IN ('new', 'sale', 'featured', 'none'));
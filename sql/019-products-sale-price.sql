@@ -0,0 +1,3 @@
+-- Optional discounted price; the regular price is kept for strikethrough display
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS sale_price DECIMAL(10, 2);
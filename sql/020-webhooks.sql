@@ -0,0 +1,9 @@
+-- Webhooks notified of catalog events
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret TEXT NOT NULL,
+    event VARCHAR(64) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_event ON webhooks(event);
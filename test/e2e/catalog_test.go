@@ -1,10 +1,25 @@
 package e2e
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/mytheresa/go-hiring-challenge/app/api"
 	"github.com/mytheresa/go-hiring-challenge/app/catalog"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"github.com/shopspring/decimal"
 )
 
 func TestCatalogEndpoint_ListProducts(t *testing.T) {
@@ -72,6 +87,92 @@ func TestCatalogEndpoint_ListProducts(t *testing.T) {
 	})
 }
 
+func TestCatalogEndpoint_PagesFollowInsertionOrder(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+
+	// Codes are inserted out of alphabetical order, so the pages only come back
+	// in this order if the listing is sorted by ID.
+	inserted := []string{"PROD300", "PROD100", "PROD200"}
+	for _, code := range inserted {
+		AssertNoError(t, ts.DB.Create(&models.Product{Code: code, Price: decimal.NewFromInt(10)}).Error)
+	}
+
+	for offset, expected := range inserted {
+		resp, err := ts.GET("/v1/catalog?limit=1&offset=" + strconv.Itoa(offset))
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+		if len(response.Products) != 1 || response.Products[0].Code != expected {
+			t.Errorf("page %d: expected %s, got %+v", offset+1, expected, response.Products)
+		}
+	}
+}
+
+func TestCatalogEndpoint_ConcurrentRequests(t *testing.T) {
+	// Parallel top-level tests only start once all sequential tests are done,
+	// so this test does not race with the others over the shared database.
+	t.Parallel()
+
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	const requests = 50
+
+	type result struct {
+		status int
+		total  int64
+		err    error
+	}
+	results := make([]result, requests)
+
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			resp, err := ts.GET("/v1/catalog")
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			results[i].status = resp.StatusCode
+
+			var response catalog.Response
+			results[i].err = DecodeJSON(resp, &response)
+			results[i].total = response.Total
+		}(i)
+	}
+	wg.Wait()
+
+	for i, r := range results {
+		if r.err != nil {
+			t.Errorf("request %d: unexpected error: %v", i, r.err)
+			continue
+		}
+		if r.status != http.StatusOK {
+			t.Errorf("request %d: expected status code %d, got %d", i, http.StatusOK, r.status)
+		}
+		if r.total != results[0].total {
+			t.Errorf("request %d: expected total %d, got %d", i, results[0].total, r.total)
+		}
+	}
+
+	if results[0].total != 3 {
+		t.Errorf("expected total 3, got %d", results[0].total)
+	}
+}
+
 func TestCatalogEndpoint_GetProductByCode(t *testing.T) {
 	ts := SetupTestServer(t)
 	defer ts.Cleanup()
@@ -94,8 +195,8 @@ func TestCatalogEndpoint_GetProductByCode(t *testing.T) {
 			t.Errorf("expected code PROD001, got %s", response.Code)
 		}
 
-		if response.Price != 10.99 {
-			t.Errorf("expected price 10.99, got %f", response.Price)
+		if !response.Price.Decimal().Equal(decimal.RequireFromString("10.99")) {
+			t.Errorf("expected price 10.99, got %s", response.Price)
 		}
 
 		// Verify category
@@ -113,13 +214,13 @@ func TestCatalogEndpoint_GetProductByCode(t *testing.T) {
 		}
 
 		// First variant should have its own price
-		if response.Variants[0].Price != 11.99 {
-			t.Errorf("expected variant price 11.99, got %f", response.Variants[0].Price)
+		if !response.Variants[0].Price.Decimal().Equal(decimal.RequireFromString("11.99")) {
+			t.Errorf("expected variant price 11.99, got %s", response.Variants[0].Price)
 		}
 
 		// Second variant should inherit product price (0 becomes 10.99)
-		if response.Variants[1].Price != 10.99 {
-			t.Errorf("expected variant to inherit price 10.99, got %f", response.Variants[1].Price)
+		if !response.Variants[1].Price.Decimal().Equal(decimal.RequireFromString("10.99")) {
+			t.Errorf("expected variant to inherit price 10.99, got %s", response.Variants[1].Price)
 		}
 	})
 
@@ -195,8 +296,8 @@ func TestCatalogEndpoint_Integration(t *testing.T) {
 			t.Errorf("expected code %s, got %s", firstProductCode, detailResponse.Code)
 		}
 
-		if detailResponse.Price != listResponse.Products[0].Price {
-			t.Errorf("expected price %f, got %f", listResponse.Products[0].Price, detailResponse.Price)
+		if !detailResponse.Price.Decimal().Equal(listResponse.Products[0].Price.Decimal()) {
+			t.Errorf("expected price %s, got %s", listResponse.Products[0].Price, detailResponse.Price)
 		}
 	})
 }
@@ -256,8 +357,47 @@ func TestCatalogEndpoint_Filters(t *testing.T) {
 
 		// Verify all products have price < 11
 		for _, p := range response.Products {
-			if p.Price >= 11 {
-				t.Errorf("product %s has price %f, expected less than 11", p.Code, p.Price)
+			if p.Price.Decimal().GreaterThanOrEqual(decimal.NewFromInt(11)) {
+				t.Errorf("product %s has price %s, expected less than 11", p.Code, p.Price)
+			}
+		}
+	})
+
+	t.Run("total is also sent in X-Total-Count", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?limit=1")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if got := resp.Header.Get(api.TotalCountHeader); got != strconv.FormatInt(response.Total, 10) {
+			t.Errorf("expected %s header %d, got %q", api.TotalCountHeader, response.Total, got)
+		}
+	})
+
+	t.Run("priceLessThan is a strict bound", func(t *testing.T) {
+		// PROD001 costs exactly 10.99.
+		tests := []struct {
+			query    string
+			included bool
+		}{
+			{query: "10.99", included: false},
+			{query: "11.00", included: true},
+			{query: "10.98", included: false},
+		}
+
+		for _, tt := range tests {
+			resp, err := ts.GET("/v1/catalog?priceLessThan=" + tt.query)
+			AssertNoError(t, err)
+			AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+			var response catalog.Response
+			AssertNoError(t, DecodeJSON(resp, &response))
+
+			included := slices.ContainsFunc(response.Products, func(p catalog.Product) bool { return p.Code == "PROD001" })
+			if included != tt.included {
+				t.Errorf("priceLessThan=%s: expected PROD001 included=%v, got %v", tt.query, tt.included, included)
 			}
 		}
 	})
@@ -373,9 +513,988 @@ func TestCatalogEndpoint_InvalidPagination(t *testing.T) {
 		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("offset above the maximum returns bad request", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?offset=9223372036854775807")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+
+		var body api.ErrorResponseBody
+		AssertNoError(t, DecodeJSON(resp, &body))
+		if body.Message != services.ErrOffsetTooLarge.Error() {
+			t.Errorf("expected message %q, got %q", services.ErrOffsetTooLarge.Error(), body.Message)
+		}
+	})
+
+	t.Run("offset past the last product returns bad request", func(t *testing.T) {
+		AssertNoError(t, ts.ClearDatabase())
+		AssertNoError(t, ts.SeedCategories())
+		AssertNoError(t, ts.SeedProducts())
+
+		resp, err := ts.GET("/v1/catalog?offset=3")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
 	t.Run("negative priceLessThan returns bad request", func(t *testing.T) {
 		resp, err := ts.GET("/v1/catalog?priceLessThan=-10")
 		AssertNoError(t, err)
 		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
+
+func TestCatalogEndpoint_Stock(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("variants expose stock quantity", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/PROD001")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if response.Variants[0].StockQuantity != 5 {
+			t.Errorf("expected SKU001A stock 5, got %d", response.Variants[0].StockQuantity)
+		}
+		if response.Variants[1].StockQuantity != 0 {
+			t.Errorf("expected SKU001B stock 0, got %d", response.Variants[1].StockQuantity)
+		}
+	})
+
+	t.Run("filter by inStock", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?inStock=true")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		// Only PROD001 has a variant with stock
+		if response.Total != 1 {
+			t.Errorf("expected total 1, got %d", response.Total)
+		}
+		if len(response.Products) != 1 || response.Products[0].Code != "PROD001" {
+			t.Errorf("expected only PROD001, got %+v", response.Products)
+		}
+	})
+
+	t.Run("adjust stock", func(t *testing.T) {
+		resp, err := ts.PATCH("/v1/catalog/PROD001/variants/SKU001B/stock", map[string]int{"delta": 3})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var variant catalog.Variant
+		AssertNoError(t, DecodeJSON(resp, &variant))
+
+		if variant.StockQuantity != 3 {
+			t.Errorf("expected stock 3, got %d", variant.StockQuantity)
+		}
+	})
+
+	t.Run("adjust stock below zero returns bad request", func(t *testing.T) {
+		resp, err := ts.PATCH("/v1/catalog/PROD001/variants/SKU001A/stock", map[string]int{"delta": -6})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("adjust stock of unknown variant returns not found", func(t *testing.T) {
+		resp, err := ts.PATCH("/v1/catalog/PROD001/variants/UNKNOWN/stock", map[string]int{"delta": 1})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("adjust stock of malformed sku returns bad request", func(t *testing.T) {
+		resp, err := ts.PATCH("/v1/catalog/PROD001/variants/sku001a/stock", map[string]int{"delta": 1})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_PriceHistory(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	updatePrice := func(t *testing.T, code, price string) {
+		t.Helper()
+		resp, err := ts.PUT("/v1/catalog/"+code, map[string]json.Number{"price": json.Number(price)})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+	}
+
+	t.Run("no history before any update", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/PROD001/price-history")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var history []catalog.PriceChange
+		AssertNoError(t, DecodeJSON(resp, &history))
+
+		if len(history) != 0 {
+			t.Errorf("expected empty history, got %d entries", len(history))
+		}
+	})
+
+	t.Run("two price updates produce two history records", func(t *testing.T) {
+		updatePrice(t, "PROD001", "12.99")
+		updatePrice(t, "PROD001", "14.50")
+
+		resp, err := ts.GET("/v1/catalog/PROD001/price-history")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var history []catalog.PriceChange
+		AssertNoError(t, DecodeJSON(resp, &history))
+
+		if len(history) != 2 {
+			t.Fatalf("expected 2 history records, got %d", len(history))
+		}
+
+		if history[0].OldPrice != "10.99" || history[0].NewPrice != "12.99" {
+			t.Errorf("unexpected first change: %+v", history[0])
+		}
+		if history[1].OldPrice != "12.99" || history[1].NewPrice != "14.50" {
+			t.Errorf("unexpected second change: %+v", history[1])
+		}
+	})
+
+	t.Run("updating to the same price records nothing", func(t *testing.T) {
+		updatePrice(t, "PROD001", "14.50")
+
+		resp, err := ts.GET("/v1/catalog/PROD001/price-history")
+		AssertNoError(t, err)
+
+		var history []catalog.PriceChange
+		AssertNoError(t, DecodeJSON(resp, &history))
+
+		if len(history) != 2 {
+			t.Errorf("expected history to stay at 2 records, got %d", len(history))
+		}
+	})
+
+	t.Run("history of unknown product returns not found", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/INVALID/price-history")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_Timestamps(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("product detail includes timestamps", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/PROD001")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var product catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &product))
+
+		if product.CreatedAt.IsZero() {
+			t.Error("expected created_at to be set")
+		}
+		if product.UpdatedAt.Before(product.CreatedAt) {
+			t.Errorf("expected updated_at %s not to be before created_at %s", product.UpdatedAt, product.CreatedAt)
+		}
+	})
+
+	t.Run("updatedAfter returns only recently updated products", func(t *testing.T) {
+		cutoff := time.Now().UTC()
+		time.Sleep(10 * time.Millisecond)
+
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]json.Number{"price": json.Number("19.99")})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var updated catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &updated))
+
+		resp, err = ts.GET("/v1/catalog?updatedAfter=" + url.QueryEscape(cutoff.Format(time.RFC3339Nano)))
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if response.Total != 1 || len(response.Products) != 1 {
+			t.Fatalf("expected 1 product, got total %d and %d products", response.Total, len(response.Products))
+		}
+		if response.Products[0].Code != "PROD002" {
+			t.Errorf("expected PROD002, got %s", response.Products[0].Code)
+		}
+
+		// A client polling with the timestamp of the update sees no further changes.
+		resp, err = ts.GET("/v1/catalog?updatedAfter=" + url.QueryEscape(updated.UpdatedAt.Format(time.RFC3339Nano)))
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		AssertNoError(t, DecodeJSON(resp, &response))
+		if response.Total != 0 || len(response.Products) != 0 {
+			t.Errorf("expected no products updated after %s, got total %d", updated.UpdatedAt, response.Total)
+		}
+	})
+
+	t.Run("invalid updatedAfter returns bad request", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?updatedAfter=yesterday")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_BrandFilter(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("filter by brand is case-insensitive", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?brand=aCmE")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if response.Total != 2 {
+			t.Errorf("expected 2 Acme products, got %d", response.Total)
+		}
+		for _, product := range response.Products {
+			if product.Brand != "Acme" {
+				t.Errorf("expected brand Acme, got %q for %s", product.Brand, product.Code)
+			}
+		}
+	})
+
+	t.Run("filter by unknown brand returns empty list", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?brand=Initech")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if response.Total != 0 || len(response.Products) != 0 {
+			t.Errorf("expected no products, got total %d", response.Total)
+		}
+	})
+
+	t.Run("brand filter combines with category", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?brand=acme&category=CLOTHING")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if response.Total != 1 || response.Products[0].Code != "PROD001" {
+			t.Errorf("expected only PROD001, got %+v", response.Products)
+		}
+	})
+
+	t.Run("updating the brand changes filter results", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]string{"brand": "Acme"})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var detail catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &detail))
+		if detail.Brand != "Acme" {
+			t.Errorf("expected updated brand Acme, got %q", detail.Brand)
+		}
+
+		resp, err = ts.GET("/v1/catalog?brand=ACME")
+		AssertNoError(t, err)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if response.Total != 3 {
+			t.Errorf("expected 3 Acme products after update, got %d", response.Total)
+		}
+	})
+}
+
+func TestCatalogEndpoint_OrderQuantity(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("products default to a minimum of one and no maximum", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/PROD001")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var detail catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &detail))
+		if detail.MinOrderQty != 1 || detail.MaxOrderQty != nil {
+			t.Errorf("expected min 1 and no max, got %d and %v", detail.MinOrderQty, detail.MaxOrderQty)
+		}
+	})
+
+	t.Run("updating order quantities", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]int{"min_order_quantity": 3, "max_order_quantity": 12})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var detail catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &detail))
+		if detail.MinOrderQty != 3 || detail.MaxOrderQty == nil || *detail.MaxOrderQty != 12 {
+			t.Errorf("expected min 3 and max 12, got %d and %v", detail.MinOrderQty, detail.MaxOrderQty)
+		}
+	})
+
+	t.Run("max below stored min is rejected", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]int{"max_order_quantity": 2})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("zero max removes the limit", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]int{"max_order_quantity": 0})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var detail catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &detail))
+		if detail.MinOrderQty != 3 || detail.MaxOrderQty != nil {
+			t.Errorf("expected min 3 and no max, got %d and %v", detail.MinOrderQty, detail.MaxOrderQty)
+		}
+	})
+
+	t.Run("zero min is rejected", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD001", map[string]int{"min_order_quantity": 0})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("filter by minOrderQtyLessThan", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?minOrderQtyLessThan=2")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+		for _, product := range response.Products {
+			if product.Code == "PROD002" {
+				t.Errorf("expected PROD002 with min 3 to be filtered out")
+			}
+		}
+		if response.Total != 2 {
+			t.Errorf("expected 2 products, got %d", response.Total)
+		}
+	})
+
+	t.Run("invalid minOrderQtyLessThan returns bad request", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?minOrderQtyLessThan=lots")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_Slug(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("slug is generated from the product code", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/PROD001")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var product catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &product))
+
+		if product.Slug != "prod001" {
+			t.Errorf("expected slug prod001, got %q", product.Slug)
+		}
+	})
+
+	t.Run("get product by slug", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/by-slug/prod002")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var product catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &product))
+
+		if product.Code != "PROD002" {
+			t.Errorf("expected PROD002, got %s", product.Code)
+		}
+	})
+
+	t.Run("explicit slug is normalized", func(t *testing.T) {
+		product := models.Product{Code: "PROD010", Slug: "Summer Dress!", Price: decimal.NewFromInt(30)}
+		AssertNoError(t, ts.DB.Create(&product).Error)
+
+		if product.Slug != "summer-dress" {
+			t.Errorf("expected slug summer-dress, got %q", product.Slug)
+		}
+	})
+
+	t.Run("duplicate slugs get a numeric suffix", func(t *testing.T) {
+		second := models.Product{Code: "PROD011", Slug: "summer dress", Price: decimal.NewFromInt(30)}
+		AssertNoError(t, ts.DB.Create(&second).Error)
+		third := models.Product{Code: "PROD012", Slug: "Summer-Dress", Price: decimal.NewFromInt(30)}
+		AssertNoError(t, ts.DB.Create(&third).Error)
+
+		if second.Slug != "summer-dress-2" {
+			t.Errorf("expected slug summer-dress-2, got %q", second.Slug)
+		}
+		if third.Slug != "summer-dress-3" {
+			t.Errorf("expected slug summer-dress-3, got %q", third.Slug)
+		}
+
+		resp, err := ts.GET("/v1/catalog/by-slug/summer-dress-3")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var product catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &product))
+
+		if product.Code != "PROD012" {
+			t.Errorf("expected PROD012, got %s", product.Code)
+		}
+	})
+
+	t.Run("sub-resource routes still resolve by code", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/PROD001/price-history")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("unknown slug returns not found", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/by-slug/does-not-exist")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_ByBarcode(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	barcode := "4006381333931"
+	AssertNoError(t, ts.SeedVariants("PROD002", []models.Variant{
+		{Name: "Size 42", SKU: "SKU002-42", Barcode: &barcode},
+		{Name: "Size 43", SKU: "SKU002-43"},
+	}))
+
+	t.Run("found returns the owning product", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/by-barcode/" + barcode)
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var detail catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &detail))
+
+		if detail.Code != "PROD002" {
+			t.Errorf("expected PROD002, got %s", detail.Code)
+		}
+		if len(detail.Variants) != 2 {
+			t.Fatalf("expected 2 variants, got %d", len(detail.Variants))
+		}
+		for _, v := range detail.Variants {
+			if v.SKU == "SKU002-42" && v.Barcode != barcode {
+				t.Errorf("expected barcode %s on SKU002-42, got %q", barcode, v.Barcode)
+			}
+		}
+	})
+
+	t.Run("unknown barcode returns not found", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/by-barcode/0000000000000")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("duplicate barcode is rejected", func(t *testing.T) {
+		err := ts.SeedVariants("PROD003", []models.Variant{{Name: "One Size", SKU: "SKU003-OS", Barcode: &barcode}})
+		if !errors.Is(err, models.ErrDuplicateCode) {
+			t.Errorf("expected ErrDuplicateCode, got %v", err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "barcode "+barcode) {
+			t.Errorf("expected the error to name the barcode, got %v", err)
+		}
+	})
+
+	t.Run("variants without barcode do not conflict", func(t *testing.T) {
+		AssertNoError(t, ts.SeedVariants("PROD003", []models.Variant{{Name: "One Size", SKU: "SKU003-OS"}}))
+	})
+}
+
+func TestCatalogEndpoint_V2(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("products are listed under items with pagination", func(t *testing.T) {
+		resp, err := ts.GET("/v2/catalog?offset=1&limit=1")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var page api.PaginatedResponse[catalog.Product]
+		AssertNoError(t, DecodeJSON(resp, &page))
+		if page.Offset != 1 || page.Limit != 1 || page.Total != 3 {
+			t.Errorf("expected offset 1, limit 1 and total 3, got %d, %d and %d", page.Offset, page.Limit, page.Total)
+		}
+		if len(page.Items) != 1 || page.Items[0].Code != "PROD002" {
+			t.Errorf("expected PROD002 as the only item, got %+v", page.Items)
+		}
+	})
+
+	t.Run("v1 keeps the products field", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?limit=3")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		AssertNoError(t, DecodeJSON(resp, &body))
+		if _, ok := body["products"]; !ok {
+			t.Errorf("expected products field in v1 response, got %v", body)
+		}
+		if _, ok := body["items"]; ok {
+			t.Errorf("expected no items field in v1 response, got %v", body)
+		}
+	})
+}
+
+func TestCatalogEndpoint_LegacyRoutes(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	readBody := func(t *testing.T, path string) []byte {
+		t.Helper()
+		resp, err := ts.GET(path)
+		AssertNoError(t, err)
+		defer resp.Body.Close()
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		AssertNoError(t, err)
+		return body
+	}
+
+	tests := []struct {
+		name   string
+		legacy string
+		v1     string
+	}{
+		{name: "catalog", legacy: "/catalog?limit=3", v1: "/v1/catalog?limit=3"},
+		{name: "product by code", legacy: "/catalog/PROD001", v1: "/v1/catalog/PROD001"},
+		{name: "categories", legacy: "/categories", v1: "/v1/categories"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy := readBody(t, tt.legacy)
+			v1 := readBody(t, tt.v1)
+			if !bytes.Equal(legacy, v1) {
+				t.Errorf("expected %s to match %s, got %s and %s", tt.legacy, tt.v1, legacy, v1)
+			}
+		})
+	}
+}
+
+func TestCatalogEndpoint_Fields(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("only requested fields are returned", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?fields=code,price")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var body catalog.SparseResponse
+		AssertNoError(t, DecodeJSON(resp, &body))
+		if len(body.Products) == 0 {
+			t.Fatal("expected products")
+		}
+		for _, product := range body.Products {
+			if len(product) != 2 || product["code"] == nil || product["price"] == nil {
+				t.Errorf("expected only code and price, got %v", product)
+			}
+		}
+	})
+
+	t.Run("unknown field is rejected", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?fields=code,color")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_LabelFilter(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database: PROD001 is featured, PROD002 on sale and PROD003 unlabelled.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+	AssertNoError(t, ts.DB.Create(&models.Product{Code: "PROD004", Price: decimal.NewFromInt(5), Label: models.LabelNew}).Error)
+
+	tests := []struct {
+		label    string
+		expected []string
+	}{
+		{label: "new", expected: []string{"PROD004"}},
+		{label: "sale", expected: []string{"PROD002"}},
+		{label: "featured", expected: []string{"PROD001"}},
+		{label: "none", expected: []string{"PROD003"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			resp, err := ts.GET("/v1/catalog?label=" + tt.label)
+			AssertNoError(t, err)
+			AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+			var response catalog.Response
+			AssertNoError(t, DecodeJSON(resp, &response))
+
+			codes := make([]string, len(response.Products))
+			for i, p := range response.Products {
+				codes[i] = p.Code
+				if p.Label != tt.label {
+					t.Errorf("expected label %s for %s, got %s", tt.label, p.Code, p.Label)
+				}
+			}
+			if !slices.Equal(codes, tt.expected) {
+				t.Errorf("expected products %v, got %v", tt.expected, codes)
+			}
+		})
+	}
+
+	t.Run("unknown label is rejected", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?label=clearance")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("the database rejects an unknown label", func(t *testing.T) {
+		err := ts.DB.Create(&models.Product{Code: "PROD005", Price: decimal.NewFromInt(5), Label: "clearance"}).Error
+		if err == nil {
+			t.Error("expected the check constraint to reject the label")
+		}
+	})
+}
+
+func TestCatalogEndpoint_SalePrice(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database: PROD002 costs 12.49.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	onSale := func(t *testing.T) []string {
+		t.Helper()
+		resp, err := ts.GET("/v1/catalog?onSale=true")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.Response
+		AssertNoError(t, DecodeJSON(resp, &response))
+		codes := make([]string, len(response.Products))
+		for i, p := range response.Products {
+			codes[i] = p.Code
+		}
+		return codes
+	}
+
+	t.Run("no product is on sale initially", func(t *testing.T) {
+		if codes := onSale(t); len(codes) != 0 {
+			t.Errorf("expected no products on sale, got %v", codes)
+		}
+	})
+
+	t.Run("setting a sale price", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]json.Number{"sale_price": json.Number("9.99")})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var detail catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &detail))
+		if detail.SalePrice == nil || !detail.SalePrice.Decimal().Equal(decimal.RequireFromString("9.99")) {
+			t.Errorf("expected sale price 9.99, got %v", detail.SalePrice)
+		}
+		if !detail.Price.Decimal().Equal(decimal.RequireFromString("12.49")) {
+			t.Errorf("expected the original price 12.49 to be kept, got %s", detail.Price.Decimal())
+		}
+	})
+
+	t.Run("filter by onSale", func(t *testing.T) {
+		if codes := onSale(t); !slices.Equal(codes, []string{"PROD002"}) {
+			t.Errorf("expected [PROD002] on sale, got %v", codes)
+		}
+	})
+
+	t.Run("sale price not below the price is rejected", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]json.Number{"sale_price": json.Number("12.49")})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("price at or below the sale price is rejected", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]json.Number{"price": json.Number("9.00")})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("a zero sale price ends the sale", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]json.Number{"sale_price": json.Number("0")})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var detail catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &detail))
+		if detail.SalePrice != nil {
+			t.Errorf("expected no sale price, got %v", detail.SalePrice)
+		}
+		if codes := onSale(t); len(codes) != 0 {
+			t.Errorf("expected no products on sale, got %v", codes)
+		}
+	})
+
+	t.Run("invalid onSale is rejected", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog?onSale=maybe")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_NewArrivals(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database: PROD001 was added today, PROD002 10 days ago and PROD003 100 days ago.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+	AssertNoError(t, ts.DB.Exec("UPDATE products SET created_at = NOW() - INTERVAL '10 days' WHERE code = 'PROD002'").Error)
+	AssertNoError(t, ts.DB.Exec("UPDATE products SET created_at = NOW() - INTERVAL '100 days' WHERE code = 'PROD003'").Error)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "default window of 7 days", query: "", expected: []string{"PROD001"}},
+		{name: "30 days", query: "?days=30", expected: []string{"PROD001", "PROD002"}},
+		{name: "window capped at 90 days", query: "?days=365", expected: []string{"PROD001", "PROD002"}},
+		{name: "paginated", query: "?days=30&offset=1&limit=1", expected: []string{"PROD002"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ts.GET("/v1/catalog/new-arrivals" + tt.query)
+			AssertNoError(t, err)
+			AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+			var response catalog.Response
+			AssertNoError(t, DecodeJSON(resp, &response))
+
+			codes := make([]string, len(response.Products))
+			for i, p := range response.Products {
+				codes[i] = p.Code
+			}
+			if !slices.Equal(codes, tt.expected) {
+				t.Errorf("expected products %v, got %v", tt.expected, codes)
+			}
+		})
+	}
+
+	t.Run("invalid days is rejected", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/new-arrivals?days=week")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_Featured(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database: PROD001 and PROD004 are featured.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+	AssertNoError(t, ts.DB.Create(&models.Product{Code: "PROD004", Price: decimal.NewFromInt(5), Label: models.LabelFeatured}).Error)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "all featured products", query: "", expected: []string{"PROD001", "PROD004"}},
+		{name: "paginated", query: "?offset=1&limit=1", expected: []string{"PROD004"}},
+		{name: "label is implied", query: "?label=sale", expected: []string{"PROD001", "PROD004"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ts.GET("/v1/catalog/featured" + tt.query)
+			AssertNoError(t, err)
+			AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+			var response catalog.Response
+			AssertNoError(t, DecodeJSON(resp, &response))
+
+			if response.Total != 2 {
+				t.Errorf("expected 2 featured products in total, got %d", response.Total)
+			}
+			codes := make([]string, len(response.Products))
+			for i, p := range response.Products {
+				codes[i] = p.Code
+			}
+			if !slices.Equal(codes, tt.expected) {
+				t.Errorf("expected products %v, got %v", tt.expected, codes)
+			}
+		})
+	}
+
+	t.Run("offset past the last featured product is rejected", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/featured?offset=2")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCatalogEndpoint_Sitemap(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	resp, err := ts.GET("/v1/catalog/sitemap.xml")
+	AssertNoError(t, err)
+	defer resp.Body.Close()
+	AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/xml" {
+		t.Errorf("expected Content-Type application/xml, got %q", ct)
+	}
+
+	var urlSet catalog.URLSet
+	AssertNoError(t, xml.NewDecoder(resp.Body).Decode(&urlSet))
+
+	locs := make([]string, len(urlSet.URLs))
+	for i, u := range urlSet.URLs {
+		locs[i] = u.Loc
+	}
+	expected := []string{
+		TestBaseURL + "/v1/catalog/by-slug/prod001",
+		TestBaseURL + "/v1/catalog/by-slug/prod002",
+		TestBaseURL + "/v1/catalog/by-slug/prod003",
+	}
+	if !slices.Equal(locs, expected) {
+		t.Errorf("expected URLs %v, got %v", expected, locs)
+	}
+
+	// The whole catalog fits in one sitemap, so there is no second page.
+	resp, err = ts.GET("/v1/catalog/sitemap.xml?page=2")
+	AssertNoError(t, err)
+	defer resp.Body.Close()
+	AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCatalogEndpoint_Feed(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database: PROD001 has two variants, the others none.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	resp, err := ts.GET("/v1/catalog/feed.json")
+	AssertNoError(t, err)
+	AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+	var feed struct {
+		Items []catalog.FeedItem `json:"items"`
+	}
+	AssertNoError(t, DecodeJSON(resp, &feed))
+
+	expected := []catalog.FeedItem{
+		{ID: "SKU001A", ItemGroupID: "PROD001", Title: "Acme PROD001 Variant A", Link: TestBaseURL + "/v1/catalog/by-slug/prod001",
+			Brand: "Acme", Price: "11.99 USD", Availability: "in stock"},
+		{ID: "SKU001B", ItemGroupID: "PROD001", Title: "Acme PROD001 Variant B", Link: TestBaseURL + "/v1/catalog/by-slug/prod001",
+			Brand: "Acme", Price: "10.99 USD", Availability: "out of stock"},
+		{ID: "PROD002", Title: "Globex PROD002", Link: TestBaseURL + "/v1/catalog/by-slug/prod002",
+			Brand: "Globex", Price: "12.49 USD", Availability: "out of stock"},
+		{ID: "PROD003", Title: "Acme PROD003", Link: TestBaseURL + "/v1/catalog/by-slug/prod003",
+			Brand: "Acme", Price: "8.75 USD", Availability: "out of stock"},
+	}
+	if !slices.Equal(feed.Items, expected) {
+		t.Errorf("expected items %+v, got %+v", expected, feed.Items)
+	}
+}
@@ -0,0 +1,48 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+func TestCategoriesRepository_CreateCategory_RejectsBlankInput(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+
+	// The repository is used directly so that only the database constraints apply.
+	repo := models.NewCategoriesRepository(ts.DB)
+
+	tests := []struct {
+		name     string
+		code     string
+		catName  string
+		expected error
+	}{
+		{name: "empty code", code: "", catName: "Shoes", expected: models.ErrInvalidCategoryInput},
+		{name: "whitespace code", code: "   ", catName: "Shoes", expected: models.ErrInvalidCategoryInput},
+		{name: "empty name", code: "SHOES", catName: "", expected: models.ErrInvalidCategoryInput},
+		{name: "whitespace name", code: "SHOES", catName: " \t", expected: models.ErrInvalidCategoryInput},
+		{name: "valid", code: "SHOES", catName: "Shoes", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.CreateCategory(context.Background(), tt.code, tt.catName)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	t.Run("raw insert", func(t *testing.T) {
+		err := ts.DB.Exec("INSERT INTO categories (code, name) VALUES ('', 'Blank')").Error
+		if err == nil {
+			t.Error("expected the check constraint to reject a blank code")
+		}
+	})
+}
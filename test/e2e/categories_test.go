@@ -2,9 +2,12 @@ package e2e
 
 import (
 	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/mytheresa/go-hiring-challenge/app/categories"
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"github.com/shopspring/decimal"
 )
 
 func TestCategoriesEndpoint_ListCategories(t *testing.T) {
@@ -103,6 +106,18 @@ func TestCategoriesEndpoint_CreateCategory(t *testing.T) {
 		}
 	})
 
+	t.Run("create category with duplicate code returns 409", func(t *testing.T) {
+		duplicate := categories.CreateCategoryRequest{
+			Code: "ELECTRONICS",
+			Name: "Electronics Again",
+		}
+
+		resp, err := ts.POST("/v1/categories", duplicate)
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusConflict, resp.StatusCode)
+		resp.Body.Close()
+	})
+
 	t.Run("create category with missing code", func(t *testing.T) {
 		invalidCategory := map[string]string{
 			"name": "Invalid Category",
@@ -248,3 +263,291 @@ func TestCategoriesEndpoint_WithProducts(t *testing.T) {
 		}
 	})
 }
+
+func TestCategoriesEndpoint_SoftDelete(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+
+	listCodes := func(t *testing.T) map[string]bool {
+		t.Helper()
+		resp, err := ts.GET("/v1/categories")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response []categories.CategoryResponse
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		codes := make(map[string]bool)
+		for _, cat := range response {
+			codes[cat.Code] = true
+		}
+		return codes
+	}
+
+	t.Run("delete category hides it from the list", func(t *testing.T) {
+		resp, err := ts.DELETE("/v1/categories/SHOES")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNoContent, resp.StatusCode)
+
+		codes := listCodes(t)
+		if codes["SHOES"] {
+			t.Error("expected SHOES to be absent after delete")
+		}
+		if len(codes) != 2 {
+			t.Errorf("expected 2 categories after delete, got %d", len(codes))
+		}
+	})
+
+	t.Run("deleting an already deleted category returns 404", func(t *testing.T) {
+		resp, err := ts.DELETE("/v1/categories/SHOES")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("restore category makes it reappear", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/categories/SHOES/restore", nil)
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var restored categories.CategoryResponse
+		AssertNoError(t, DecodeJSON(resp, &restored))
+		if restored.Code != "SHOES" {
+			t.Errorf("expected restored code SHOES, got %s", restored.Code)
+		}
+
+		codes := listCodes(t)
+		if !codes["SHOES"] {
+			t.Error("expected SHOES to be present after restore")
+		}
+		if len(codes) != 3 {
+			t.Errorf("expected 3 categories after restore, got %d", len(codes))
+		}
+	})
+
+	t.Run("restoring an active category returns 404", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/categories/SHOES/restore", nil)
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("deleting a category with products keeps them linked", func(t *testing.T) {
+		AssertNoError(t, ts.SeedProducts())
+
+		resp, err := ts.DELETE("/v1/categories/CLOTHING")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNoContent, resp.StatusCode)
+
+		var linked int64
+		AssertNoError(t, ts.DB.Raw(`SELECT count(*) FROM products p JOIN categories c ON c.id = p.category_id
+			WHERE c.code = 'CLOTHING'`).Scan(&linked).Error)
+		if linked == 0 {
+			t.Error("expected products to keep referencing the deleted category")
+		}
+	})
+}
+
+func TestCategoriesEndpoint_Sort(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database. BAGS sorts first by code but last by name.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	resp, err := ts.POST("/v1/categories", categories.CreateCategoryRequest{Code: "BAGS", Name: "Travel Bags"})
+	AssertNoError(t, err)
+	resp.Body.Close()
+	AssertStatusCode(t, http.StatusCreated, resp.StatusCode)
+
+	listCodes := func(t *testing.T, path string) []string {
+		t.Helper()
+		resp, err := ts.GET(path)
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response []categories.CategoryResponse
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		codes := make([]string, len(response))
+		for i, c := range response {
+			codes[i] = c.Code
+		}
+		return codes
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{"default sorts by code ascending", "/v1/categories", []string{"ACCESSORIES", "BAGS", "CLOTHING", "SHOES"}},
+		{"code_asc", "/v1/categories?sort=code_asc", []string{"ACCESSORIES", "BAGS", "CLOTHING", "SHOES"}},
+		{"code_desc", "/v1/categories?sort=code_desc", []string{"SHOES", "CLOTHING", "BAGS", "ACCESSORIES"}},
+		{"name_asc", "/v1/categories?sort=name_asc", []string{"ACCESSORIES", "CLOTHING", "SHOES", "BAGS"}},
+		{"name_desc", "/v1/categories?sort=name_desc", []string{"BAGS", "SHOES", "CLOTHING", "ACCESSORIES"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codes := listCodes(t, tt.path)
+			if !slices.Equal(codes, tt.expected) {
+				t.Errorf("expected order %v, got %v", tt.expected, codes)
+			}
+		})
+	}
+
+	t.Run("invalid sort returns bad request", func(t *testing.T) {
+		resp, err := ts.GET("/v1/categories?sort=price_asc")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCategoriesEndpoint_ProductCount(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database: one product each in CLOTHING, SHOES and ACCESSORIES.
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	// Two more clothing products, and an empty category.
+	var clothing models.Category
+	AssertNoError(t, ts.DB.Where("code = ?", "CLOTHING").First(&clothing).Error)
+	for _, code := range []string{"PROD004", "PROD005"} {
+		AssertNoError(t, ts.DB.Create(&models.Product{Code: code, Price: decimal.NewFromInt(20), CategoryID: &clothing.ID}).Error)
+	}
+	AssertNoError(t, ts.DB.Create(&models.Category{Code: "BAGS", Name: "Bags"}).Error)
+
+	resp, err := ts.GET("/v1/categories")
+	AssertNoError(t, err)
+	AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+	var response []categories.CategoryResponse
+	AssertNoError(t, DecodeJSON(resp, &response))
+
+	expected := map[string]int64{
+		"ACCESSORIES": 1,
+		"BAGS":        0,
+		"CLOTHING":    3,
+		"SHOES":       1,
+	}
+
+	if len(response) != len(expected) {
+		t.Fatalf("expected %d categories, got %d", len(expected), len(response))
+	}
+	for _, cat := range response {
+		if cat.ProductCount != expected[cat.Code] {
+			t.Errorf("expected %d products in %s, got %d", expected[cat.Code], cat.Code, cat.ProductCount)
+		}
+	}
+}
+
+func TestCategoriesEndpoint_UpdateCategory(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+
+	t.Run("rename category", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/categories/SHOES", categories.UpdateCategoryRequest{Name: "Footwear"})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var category categories.CategoryResponse
+		AssertNoError(t, DecodeJSON(resp, &category))
+
+		if category.Code != "SHOES" || category.Name != "Footwear" {
+			t.Errorf("unexpected category: %+v", category)
+		}
+	})
+
+	t.Run("changing the code is rejected", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/categories/SHOES", map[string]string{"code": "FOOTWEAR", "name": "Footwear"})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body map[string]string
+		AssertNoError(t, DecodeJSON(resp, &body))
+		if body["code"] != "unprocessable_entity" {
+			t.Errorf("expected error code unprocessable_entity, got %q", body["code"])
+		}
+
+		// The category must be unchanged.
+		resp, err = ts.GET("/v1/categories")
+		AssertNoError(t, err)
+
+		var list []categories.CategoryResponse
+		AssertNoError(t, DecodeJSON(resp, &list))
+		for _, c := range list {
+			if c.Code == "FOOTWEAR" {
+				t.Error("category code was changed")
+			}
+		}
+	})
+
+	t.Run("unknown category returns not found", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/categories/MISSING", categories.UpdateCategoryRequest{Name: "Missing"})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCategoriesEndpoint_RequiresJSONContentType(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+
+	resp, err := ts.POST("/v1/categories", categories.CreateCategoryRequest{Code: "BAGS", Name: "Bags"},
+		http.Header{"Content-Type": {"text/plain"}})
+	AssertNoError(t, err)
+	AssertStatusCode(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	var body map[string]string
+	AssertNoError(t, DecodeJSON(resp, &body))
+	if body["code"] != "unsupported_media_type" {
+		t.Errorf("expected error code unsupported_media_type, got %q", body["code"])
+	}
+
+	// Nothing must have been created.
+	var count int64
+	AssertNoError(t, ts.DB.Model(&models.Category{}).Count(&count).Error)
+	if count != 0 {
+		t.Errorf("expected no categories, got %d", count)
+	}
+}
+
+func TestCategoriesEndpoint_CreateCategory_NormalizesCode(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+
+	resp, err := ts.POST("/v1/categories", categories.CreateCategoryRequest{Code: " electronics ", Name: " Electronics "})
+	AssertNoError(t, err)
+	AssertStatusCode(t, http.StatusCreated, resp.StatusCode)
+
+	var created categories.CategoryResponse
+	AssertNoError(t, DecodeJSON(resp, &created))
+	if created.Code != "ELECTRONICS" || created.Name != "Electronics" {
+		t.Errorf("expected ELECTRONICS/Electronics, got %s/%s", created.Code, created.Name)
+	}
+
+	// The same code in another case is a duplicate, not a new category.
+	resp, err = ts.POST("/v1/categories", categories.CreateCategoryRequest{Code: "Electronics", Name: "Gadgets"})
+	AssertNoError(t, err)
+	resp.Body.Close()
+	AssertStatusCode(t, http.StatusConflict, resp.StatusCode)
+}
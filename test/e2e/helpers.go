@@ -3,6 +3,7 @@ package e2e
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,31 +11,43 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mytheresa/go-hiring-challenge/app/api"
 	"github.com/mytheresa/go-hiring-challenge/app/catalog"
 	"github.com/mytheresa/go-hiring-challenge/app/categories"
 	"github.com/mytheresa/go-hiring-challenge/app/database"
+	"github.com/mytheresa/go-hiring-challenge/app/events"
+	"github.com/mytheresa/go-hiring-challenge/app/middleware"
 	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/mytheresa/go-hiring-challenge/app/webhooks"
 	"github.com/mytheresa/go-hiring-challenge/models"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
+// TestBaseURL is the public URL the test server builds its absolute links from.
+const TestBaseURL = "http://catalog.test"
+
 // TestServer represents a test HTTP server with database.
 type TestServer struct {
-	Server    *httptest.Server
-	DB        *gorm.DB
+	Server *httptest.Server
+	DB     *gorm.DB
+	// Webhooks delivers the server's webhook events; Wait on it before
+	// checking what a webhook received.
+	Webhooks  *services.WebhookDispatcher
 	CleanupFn func()
 }
 
 // SetupTestServer creates a test server with a PostgreSQL test database.
-func SetupTestServer(t *testing.T) *TestServer {
+func SetupTestServer(t testing.TB) *TestServer {
 	// Use test database configuration.
 	db, cleanup, err := database.New(
 		getEnv("POSTGRES_USER", "postgres"),
 		getEnv("POSTGRES_PASSWORD", "password"),
+		getEnv("POSTGRES_HOST", "localhost"),
 		getEnv("POSTGRES_DB_TEST", "go_challenge_test"),
 		getEnv("POSTGRES_PORT", "5432"),
 	)
@@ -43,42 +56,101 @@ func SetupTestServer(t *testing.T) *TestServer {
 	}
 
 	// Drop existing tables to ensure clean state.
-	if err := db.Migrator().DropTable(&models.Variant{}, &models.Product{}, &models.Category{}); err != nil {
+	if err := db.Migrator().DropTable(&models.Webhook{}, &models.ProductImage{}, &models.PriceHistory{}, &models.Variant{}, &models.Product{}, &models.Category{}); err != nil {
 		t.Logf("warning: failed to drop tables (may not exist): %v", err)
 	}
 
 	// Auto-migrate tables.
-	if err := db.AutoMigrate(&models.Category{}, &models.Product{}, &models.Variant{}); err != nil {
+	if err := db.AutoMigrate(&models.Category{}, &models.Product{}, &models.Variant{}, &models.PriceHistory{}, &models.ProductImage{}, &models.Webhook{}); err != nil {
 		t.Fatalf("failed to auto-migrate tables: %v", err)
 	}
 
+	// Re-apply the versioned migrations to the recreated tables, as database.New
+	// ran them before the tables were dropped.
+	if err := db.Exec("DELETE FROM schema_migrations").Error; err != nil {
+		t.Fatalf("failed to reset schema migrations: %v", err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
 	// Initialize repositories.
 	prodRepo := models.NewProductsRepository(db)
 	catRepo := models.NewCategoriesRepository(db)
+	priceHistoryRepo := models.NewPriceHistoryRepository(db)
+	imageRepo := models.NewProductImageRepository(db)
+	webhookRepo := models.NewWebhooksRepository(db)
+
+	// Wire the event bus as in the server. Webhook retries back off quickly to keep tests fast.
+	bus := events.NewInMemoryBus()
+	webhookDispatcher := services.NewWebhookDispatcher(webhookRepo, &http.Client{Timeout: 5 * time.Second}, 10*time.Millisecond)
+	bus.Subscribe(events.CategoryCreated, webhookDispatcher.Handle)
+	bus.Subscribe(events.ProductCreated, webhookDispatcher.Handle)
 
 	// Initialize services.
-	catalogService := services.NewCatalogService(prodRepo)
-	categoriesService := services.NewCategoriesService(catRepo)
+	catalogService := services.NewCatalogService(prodRepo, catRepo, services.CatalogServiceConfig{}, bus)
+	categoriesService := services.NewCategoriesService(catRepo, bus)
+	priceHistoryService := services.NewPriceHistoryService(prodRepo, priceHistoryRepo)
+	imageService := services.NewProductImageService(prodRepo, imageRepo)
+	webhookService := services.NewWebhookService(webhookRepo)
 
 	// Initialize handlers.
-	catHandler := catalog.NewCatalogHandler(catalogService)
+	catHandler := catalog.NewCatalogHandler(catalogService, catalog.CatalogHandlerConfig{BaseURL: TestBaseURL})
 	categoriesHandler := categories.NewCategoriesHandler(categoriesService)
+	priceHistoryHandler := catalog.NewPriceHistoryHandler(priceHistoryService)
+	imageHandler := catalog.NewProductImageHandler(imageService)
+	webhooksHandler := webhooks.NewWebhooksHandler(webhookService)
+
+	// Routes with a request body require JSON, as in the server.
+	requireJSON := middleware.RequireContentType("application/json")
 
 	// Set up routing.
 	mux := http.NewServeMux()
-	mux.Handle("GET /v1/catalog", api.ErrorHandler(catHandler.HandleGet))
-	mux.Handle("GET /v1/catalog/{code}", api.ErrorHandler(catHandler.HandleGetByCode))
-	mux.Handle("GET /v1/categories", api.ErrorHandler(categoriesHandler.HandleGet))
-	mux.Handle("POST /v1/categories", api.ErrorHandler(categoriesHandler.HandlePost))
+	mux.Handle("GET /v1/catalog", api.ErrorHandler("catalog.HandleGet", catHandler.HandleGet))
+	mux.Handle("GET /v2/catalog", api.ErrorHandler("catalog.HandleGetV2", catHandler.HandleGetV2))
+	mux.Handle("GET /v1/catalog/new-arrivals", api.ErrorHandler("catalog.HandleNewArrivals", catHandler.HandleNewArrivals))
+	mux.Handle("GET /v1/catalog/featured", api.ErrorHandler("catalog.HandleFeatured", catHandler.HandleFeatured))
+	mux.Handle("GET /v1/catalog/sitemap.xml", api.ErrorHandler("catalog.HandleSitemap", catHandler.HandleSitemap))
+	mux.Handle("GET /v1/catalog/feed.json", api.ErrorHandler("catalog.HandleFeed", catHandler.HandleFeed))
+	mux.Handle("GET /v1/catalog/{code}", api.ErrorHandler("catalog.HandleGetByCode", catHandler.HandleGetByCode))
+	mux.Handle("PUT /v1/catalog/{code}", requireJSON(api.ErrorHandler("catalog.HandleUpdate", catHandler.HandleUpdate)))
+	mux.Handle("GET /v1/catalog/{code}/price-history", api.ErrorHandler("catalog.PriceHistoryHandler.HandleGet", priceHistoryHandler.HandleGet))
+	mux.Handle("GET /v1/catalog/{code}/images", api.ErrorHandler("catalog.ProductImageHandler.HandleGet", imageHandler.HandleGet))
+	mux.Handle("POST /v1/catalog/{code}/images", requireJSON(api.ErrorHandler("catalog.ProductImageHandler.HandlePost", imageHandler.HandlePost)))
+	mux.Handle("DELETE /v1/catalog/{code}/images/{id}", api.ErrorHandler("catalog.ProductImageHandler.HandleDelete", imageHandler.HandleDelete))
+	mux.Handle("PATCH /v1/catalog/{code}/variants/{sku}/stock", requireJSON(api.ErrorHandler("catalog.HandleAdjustStock", catHandler.HandleAdjustStock)))
+	mux.Handle("GET /v1/categories", api.ErrorHandler("categories.HandleGet", categoriesHandler.HandleGet))
+	mux.Handle("POST /v1/categories", requireJSON(api.ErrorHandler("categories.HandlePost", categoriesHandler.HandlePost)))
+	mux.Handle("PUT /v1/categories/{code}", requireJSON(api.ErrorHandler("categories.HandleUpdate", categoriesHandler.HandleUpdate)))
+	mux.Handle("DELETE /v1/categories/{code}", api.ErrorHandler("categories.HandleDelete", categoriesHandler.HandleDelete))
+	mux.Handle("PUT /v1/categories/{code}/restore", api.ErrorHandler("categories.HandleRestore", categoriesHandler.HandleRestore))
+	mux.Handle("POST /v1/webhooks", requireJSON(api.ErrorHandler("webhooks.HandlePost", webhooksHandler.HandlePost)))
+
+	// Legacy routes, as in the server.
+	mux.Handle("GET /catalog", api.ErrorHandler("catalog.HandleGet", catHandler.HandleGet))
+	mux.Handle("GET /catalog/{code}", api.ErrorHandler("catalog.HandleGetByCode", catHandler.HandleGetByCode))
+	mux.Handle("GET /categories", api.ErrorHandler("categories.HandleGet", categoriesHandler.HandleGet))
+	mux.Handle("POST /categories", requireJSON(api.ErrorHandler("categories.HandlePost", categoriesHandler.HandlePost)))
+
+	// Slug and barcode lookups live on a root mux, as in the server.
+	root := http.NewServeMux()
+	root.Handle("GET /v1/catalog/by-slug/{slug}", api.ErrorHandler("catalog.HandleGetBySlug", catHandler.HandleGetBySlug))
+	root.Handle("GET /v1/catalog/by-barcode/{barcode}", api.ErrorHandler("catalog.HandleGetByBarcode", catHandler.HandleGetByBarcode))
+	root.Handle("/", mux)
+
+	// Apply the same middleware stack as the server.
+	handler := middleware.Chain(middleware.RequestID, middleware.Logger, middleware.Recovery)(root)
 
 	// Create test server.
-	server := httptest.NewServer(mux)
+	server := httptest.NewServer(handler)
 
 	return &TestServer{
-		Server: server,
-		DB:     db,
+		Server:   server,
+		DB:       db,
+		Webhooks: webhookDispatcher,
 		CleanupFn: func() {
 			server.Close()
+			webhookDispatcher.Wait()
 			if err := cleanup(); err != nil {
 				log.Printf("failed to cleanup database: %v", err)
 			}
@@ -93,17 +165,24 @@ func (ts *TestServer) Cleanup() {
 	}
 }
 
+// clearedTables lists the tables emptied by ClearDatabase, children before parents.
+var clearedTables = []string{"webhooks", "product_images", "product_price_history", "product_variants", "products", "categories"}
+
 // ClearDatabase clears all data from test database.
+// On Postgres it truncates all tables in one statement; other databases fall
+// back to deleting rows table by table.
 func (ts *TestServer) ClearDatabase() error {
-	// Delete in order to respect foreign keys.
-	if err := ts.DB.Exec("DELETE FROM product_variants").Error; err != nil {
-		return err
+	if ts.DB.Dialector.Name() == "postgres" {
+		// RESTART IDENTITY resets the ID sequences, so seeded rows get the same
+		// IDs in every test. CASCADE covers foreign keys between the tables.
+		return ts.DB.Exec("TRUNCATE " + strings.Join(clearedTables, ", ") + " RESTART IDENTITY CASCADE").Error
 	}
-	if err := ts.DB.Exec("DELETE FROM products").Error; err != nil {
-		return err
-	}
-	if err := ts.DB.Exec("DELETE FROM categories").Error; err != nil {
-		return err
+
+	// Delete in order to respect foreign keys.
+	for _, table := range clearedTables {
+		if err := ts.DB.Exec("DELETE FROM " + table).Error; err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -138,28 +217,28 @@ func (ts *TestServer) SeedProducts() error {
 		return err
 	}
 
-	variantAPrice := decimal.NewFromFloat(11.99)
+	acme, globex := "Acme", "Globex"
+
 	products := []models.Product{
 		{
 			Code:       "PROD001",
 			Price:      decimal.NewFromFloat(10.99),
+			Brand:      &acme,
 			CategoryID: &clothing.ID,
-			Variants: []models.Variant{
-				{Name: "Variant A", SKU: "SKU001A", Price: &variantAPrice},
-				{Name: "Variant B", SKU: "SKU001B", Price: nil}, // nil = inherit product price
-			},
+			Label:      models.LabelFeatured,
 		},
 		{
 			Code:       "PROD002",
 			Price:      decimal.NewFromFloat(12.49),
+			Brand:      &globex,
 			CategoryID: &shoes.ID,
-			Variants:   []models.Variant{},
+			Label:      models.LabelSale,
 		},
 		{
 			Code:       "PROD003",
 			Price:      decimal.NewFromFloat(8.75),
+			Brand:      &acme,
 			CategoryID: &accessories.ID,
-			Variants:   []models.Variant{},
 		},
 	}
 
@@ -168,6 +247,30 @@ func (ts *TestServer) SeedProducts() error {
 			return err
 		}
 	}
+
+	variantAPrice := decimal.NewFromFloat(11.99)
+	return ts.SeedVariants("PROD001", []models.Variant{
+		{Name: "Variant A", SKU: "SKU001A", Price: &variantAPrice, StockQuantity: 5},
+		{Name: "Variant B", SKU: "SKU001B", Price: nil}, // nil = inherit product price
+	})
+}
+
+// SeedVariants adds the given variants to the product with the given code.
+// Returns an error if the product doesn't exist, and models.ErrDuplicateCode
+// if a variant's SKU or barcode is already taken.
+func (ts *TestServer) SeedVariants(productCode string, variants []models.Variant) error {
+	var product models.Product
+	if err := ts.DB.Where("code = ?", productCode).First(&product).Error; err != nil {
+		return fmt.Errorf("product %s: %w", productCode, err)
+	}
+
+	repo := models.NewProductsRepository(ts.DB)
+	for _, variant := range variants {
+		variant.ProductID = product.ID
+		if err := repo.CreateVariant(context.Background(), &variant); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -176,18 +279,54 @@ func (ts *TestServer) GET(path string) (*http.Response, error) {
 	return http.Get(ts.Server.URL + path)
 }
 
-// POST makes a POST request to the test server.
-func (ts *TestServer) POST(path string, body interface{}) (*http.Response, error) {
+// POST makes a POST request with a JSON body to the test server.
+// The given headers are added to the request and may override Content-Type.
+func (ts *TestServer) POST(path string, body interface{}, headers ...http.Header) (*http.Response, error) {
+	return ts.doJSON(http.MethodPost, path, body, headers...)
+}
+
+// PUT makes a PUT request with a JSON body to the test server.
+func (ts *TestServer) PUT(path string, body interface{}) (*http.Response, error) {
+	return ts.doJSON(http.MethodPut, path, body)
+}
+
+// PATCH makes a PATCH request with a JSON body to the test server.
+func (ts *TestServer) PATCH(path string, body interface{}) (*http.Response, error) {
+	return ts.doJSON(http.MethodPatch, path, body)
+}
+
+// DELETE makes a DELETE request without a body to the test server.
+func (ts *TestServer) DELETE(path string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodDelete, ts.Server.URL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+// doJSON makes a request with a JSON-encoded body and the given headers to the test server.
+func (ts *TestServer) doJSON(method, path string, body interface{}, headers ...http.Header) (*http.Response, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	return http.Post(
-		ts.Server.URL+path,
-		"application/json",
-		bytes.NewReader(jsonBody),
-	)
+	req, err := http.NewRequest(method, ts.Server.URL+path, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, header := range headers {
+		for key, values := range header {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+	}
+
+	return http.DefaultClient.Do(req)
 }
 
 // DecodeJSON decodes JSON response body.
@@ -217,7 +356,7 @@ func AssertStatusCode(t *testing.T, expected, actual int) {
 }
 
 // AssertNoError asserts no error occurred.
-func AssertNoError(t *testing.T, err error) {
+func AssertNoError(t testing.TB, err error) {
 	t.Helper()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -0,0 +1,80 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/catalog"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+func TestServerHelpers_Methods(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	// Each route is only registered for a single method, so a wrong method
+	// would be answered with 405 Method Not Allowed.
+	t.Run("PUT sends a JSON body", func(t *testing.T) {
+		resp, err := ts.PUT("/v1/catalog/PROD002", map[string]string{"price": "13.49"})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("PATCH sends a JSON body", func(t *testing.T) {
+		resp, err := ts.PATCH("/v1/catalog/PROD001/variants/SKU001A/stock", map[string]int{"delta": 1})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("DELETE sends no body", func(t *testing.T) {
+		resp, err := ts.DELETE("/v1/categories/ACCESSORIES")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestServerHelpers_SeedVariants(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	t.Run("adds variants to an existing product", func(t *testing.T) {
+		AssertNoError(t, ts.SeedVariants("PROD002", []models.Variant{
+			{Name: "Size 42", SKU: "SKU002-42", StockQuantity: 2},
+			{Name: "Size 43", SKU: "SKU002-43"},
+		}))
+
+		resp, err := ts.GET("/v1/catalog/PROD002")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var response catalog.ProductDetail
+		AssertNoError(t, DecodeJSON(resp, &response))
+
+		if len(response.Variants) != 2 {
+			t.Fatalf("expected 2 variants, got %d", len(response.Variants))
+		}
+		if response.Variants[0].StockQuantity != 2 {
+			t.Errorf("expected stock quantity 2, got %d", response.Variants[0].StockQuantity)
+		}
+	})
+
+	t.Run("fails for unknown product", func(t *testing.T) {
+		err := ts.SeedVariants("UNKNOWN", []models.Variant{{Name: "X", SKU: "SKU-X"}})
+		if err == nil {
+			t.Error("expected error for unknown product")
+		}
+	})
+}
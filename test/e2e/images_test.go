@@ -0,0 +1,94 @@
+package e2e
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/catalog"
+)
+
+func TestCatalogEndpoint_Images(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	addImage := func(t *testing.T, url string, position int) catalog.ProductImage {
+		t.Helper()
+		resp, err := ts.POST("/v1/catalog/PROD001/images", catalog.AddImageRequest{URL: url, Position: position})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusCreated, resp.StatusCode)
+
+		var image catalog.ProductImage
+		AssertNoError(t, DecodeJSON(resp, &image))
+		return image
+	}
+
+	listImages := func(t *testing.T) []catalog.ProductImage {
+		t.Helper()
+		resp, err := ts.GET("/v1/catalog/PROD001/images")
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusOK, resp.StatusCode)
+
+		var images []catalog.ProductImage
+		AssertNoError(t, DecodeJSON(resp, &images))
+		return images
+	}
+
+	t.Run("empty gallery", func(t *testing.T) {
+		if images := listImages(t); len(images) != 0 {
+			t.Errorf("expected empty gallery, got %d images", len(images))
+		}
+	})
+
+	var back catalog.ProductImage
+	t.Run("gallery is ordered by position", func(t *testing.T) {
+		back = addImage(t, "https://cdn.example.com/back.jpg", 1)
+		addImage(t, "https://cdn.example.com/front.jpg", 0)
+
+		images := listImages(t)
+		if len(images) != 2 {
+			t.Fatalf("expected 2 images, got %d", len(images))
+		}
+		if images[0].URL != "https://cdn.example.com/front.jpg" || images[1].URL != "https://cdn.example.com/back.jpg" {
+			t.Errorf("unexpected gallery order: %+v", images)
+		}
+	})
+
+	t.Run("invalid url returns bad request", func(t *testing.T) {
+		resp, err := ts.POST("/v1/catalog/PROD001/images", catalog.AddImageRequest{URL: "not-a-url"})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("image of another product cannot be deleted", func(t *testing.T) {
+		resp, err := ts.DELETE("/v1/catalog/PROD002/images/" + strconv.FormatUint(uint64(back.ID), 10))
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("delete removes image from gallery", func(t *testing.T) {
+		resp, err := ts.DELETE("/v1/catalog/PROD001/images/" + strconv.FormatUint(uint64(back.ID), 10))
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNoContent, resp.StatusCode)
+
+		images := listImages(t)
+		if len(images) != 1 || images[0].URL != "https://cdn.example.com/front.jpg" {
+			t.Errorf("expected only the front image to remain, got %+v", images)
+		}
+	})
+
+	t.Run("gallery of unknown product returns not found", func(t *testing.T) {
+		resp, err := ts.GET("/v1/catalog/INVALID/images")
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
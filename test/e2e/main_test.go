@@ -0,0 +1,53 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestMain skips the whole suite when the test database is unreachable,
+// instead of letting every test fail in SetupTestServer.
+func TestMain(m *testing.M) {
+	if err := pingTestDatabase(); err != nil {
+		fmt.Printf("skipping e2e tests: database unavailable: %v\n", err)
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// pingTestDatabase checks that the database SetupTestServer connects to accepts connections.
+func pingTestDatabase() error {
+	dsn := database.DSN(
+		getEnv("POSTGRES_USER", "postgres"),
+		getEnv("POSTGRES_PASSWORD", "password"),
+		getEnv("POSTGRES_HOST", "localhost"),
+		getEnv("POSTGRES_DB_TEST", "go_challenge_test"),
+		getEnv("POSTGRES_PORT", "5432"),
+	)
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger:               logger.Discard,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	return database.Ping(ctx, db)
+}
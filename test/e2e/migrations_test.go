@@ -0,0 +1,118 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/database"
+	"github.com/mytheresa/go-hiring-challenge/models"
+)
+
+func TestMigrate_IsIdempotent(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Simulate a products table created without the unique index, as by sql/001-products.sql.
+	AssertNoError(t, ts.DB.Exec("DROP INDEX IF EXISTS idx_products_code").Error)
+	AssertNoError(t, ts.DB.Exec("DELETE FROM schema_migrations").Error)
+
+	if err := database.Migrate(ts.DB); err != nil {
+		t.Fatalf("first migration failed: %v", err)
+	}
+	if err := database.Migrate(ts.DB); err != nil {
+		t.Fatalf("second migration failed: %v", err)
+	}
+
+	var indexes int64
+	AssertNoError(t, ts.DB.Raw("SELECT count(*) FROM pg_indexes WHERE tablename = 'products' AND indexname = 'idx_products_code'").Scan(&indexes).Error)
+	if indexes != 1 {
+		t.Fatalf("expected idx_products_code to exist, found %d", indexes)
+	}
+
+	var recorded int64
+	AssertNoError(t, ts.DB.Table("schema_migrations").Where("version = ?", "001").Count(&recorded).Error)
+	if recorded != 1 {
+		t.Errorf("expected migration 001 to be recorded once, got %d", recorded)
+	}
+
+	// The index is enforced even when the migration runs a second time with no version recorded.
+	AssertNoError(t, ts.DB.Exec("DELETE FROM schema_migrations").Error)
+	AssertNoError(t, database.Migrate(ts.DB))
+
+	AssertNoError(t, ts.DB.Create(&models.Product{Code: "PROD001"}).Error)
+	if err := ts.DB.Create(&models.Product{Code: "PROD001"}).Error; err == nil {
+		t.Error("expected a duplicate product code to be rejected")
+	}
+}
+
+func TestMigrate_NamesCategoryForeignKey(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+
+	// Simulate the unnamed key without ON DELETE SET NULL left by an older schema.
+	AssertNoError(t, ts.DB.Exec("ALTER TABLE products DROP CONSTRAINT fk_products_category").Error)
+	AssertNoError(t, ts.DB.Exec("ALTER TABLE products ADD FOREIGN KEY (category_id) REFERENCES categories(id)").Error)
+	AssertNoError(t, ts.DB.Exec("DELETE FROM schema_migrations WHERE version = ?", "005").Error)
+
+	AssertNoError(t, database.Migrate(ts.DB))
+
+	var keys []struct {
+		Conname     string
+		Confdeltype string
+	}
+	AssertNoError(t, ts.DB.Raw(`SELECT conname, confdeltype::text AS confdeltype FROM pg_constraint
+		WHERE conrelid = 'products'::regclass AND confrelid = 'categories'::regclass AND contype = 'f'`).Scan(&keys).Error)
+	if len(keys) != 1 || keys[0].Conname != "fk_products_category" || keys[0].Confdeltype != "n" {
+		t.Fatalf("expected only fk_products_category with ON DELETE SET NULL, got %+v", keys)
+	}
+
+	// Categories are soft-deleted by the API; a hard delete must unlink its products, not fail.
+	category := models.Category{Code: "BAGS", Name: "Bags"}
+	AssertNoError(t, ts.DB.Create(&category).Error)
+	product := models.Product{Code: "PROD001", CategoryID: &category.ID}
+	AssertNoError(t, ts.DB.Create(&product).Error)
+
+	AssertNoError(t, ts.DB.Delete(&category).Error)
+
+	var reloaded models.Product
+	AssertNoError(t, ts.DB.First(&reloaded, product.ID).Error)
+	if reloaded.CategoryID != nil {
+		t.Errorf("expected category_id to be NULL, got %d", *reloaded.CategoryID)
+	}
+}
+
+func TestMigrate_RebuildsInvalidCodeIndex(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.DB.Exec("DROP INDEX IF EXISTS idx_products_code").Error)
+	AssertNoError(t, ts.DB.Exec("DELETE FROM schema_migrations WHERE version = ?", "001").Error)
+
+	// Duplicate codes make the concurrent build fail and leave an INVALID index behind.
+	AssertNoError(t, ts.DB.Create(&models.Product{Code: "PROD001", Slug: "prod-001-a"}).Error)
+	duplicate := models.Product{Code: "PROD001", Slug: "prod-001-b"}
+	AssertNoError(t, ts.DB.Create(&duplicate).Error)
+
+	if err := database.Migrate(ts.DB); err == nil {
+		t.Fatal("expected the migration to fail on duplicate codes")
+	}
+
+	var recorded int64
+	AssertNoError(t, ts.DB.Table("schema_migrations").Where("version = ?", "001").Count(&recorded).Error)
+	if recorded != 0 {
+		t.Fatalf("expected migration 001 not to be recorded, got %d", recorded)
+	}
+
+	// Once the data is fixed, the next run replaces the invalid index.
+	AssertNoError(t, ts.DB.Unscoped().Delete(&duplicate).Error)
+	AssertNoError(t, database.Migrate(ts.DB))
+
+	var valid bool
+	AssertNoError(t, ts.DB.Raw(`SELECT i.indisvalid FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE c.relname = 'idx_products_code'`).Scan(&valid).Error)
+	if !valid {
+		t.Error("expected idx_products_code to be valid")
+	}
+}
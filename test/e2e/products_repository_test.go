@@ -0,0 +1,364 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/mytheresa/go-hiring-challenge/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestProductsRepository_GetAllProducts_CategoryJoin(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	repo := models.NewProductsRepository(ts.DB)
+
+	t.Run("category filter populates category and variants", func(t *testing.T) {
+		products, total, err := repo.GetAllProducts(context.Background(), 0, 10, models.ProductFilter{Category: "CLOTHING"})
+		AssertNoError(t, err)
+
+		if total != 1 || len(products) != 1 {
+			t.Fatalf("expected 1 product, got %d (total %d)", len(products), total)
+		}
+
+		product := products[0]
+		if product.Code != "PROD001" {
+			t.Errorf("expected PROD001, got %s", product.Code)
+		}
+		if product.Category == nil || product.Category.Code != "CLOTHING" || product.Category.Name != "Clothing" {
+			t.Errorf("expected CLOTHING category, got %+v", product.Category)
+		}
+		if len(product.Variants) != 2 {
+			t.Errorf("expected 2 variants, got %d", len(product.Variants))
+		}
+	})
+
+	t.Run("category filter combines with other filters", func(t *testing.T) {
+		products, total, err := repo.GetAllProducts(context.Background(), 0, 10, models.ProductFilter{Category: "CLOTHING", InStock: true})
+		AssertNoError(t, err)
+
+		if total != 1 || len(products) != 1 || products[0].Category == nil {
+			t.Errorf("expected PROD001 with its category, got %+v (total %d)", products, total)
+		}
+	})
+
+	t.Run("category IDs match the same products as the code", func(t *testing.T) {
+		id, err := models.NewCategoriesRepository(ts.DB).GetCategoryIDByCode(context.Background(), "CLOTHING")
+		AssertNoError(t, err)
+
+		products, total, err := repo.GetAllProducts(context.Background(), 0, 10, models.ProductFilter{CategoryIDs: []uint{id}})
+		AssertNoError(t, err)
+
+		if total != 1 || len(products) != 1 || products[0].Code != "PROD001" {
+			t.Fatalf("expected PROD001, got %+v (total %d)", products, total)
+		}
+		if products[0].Category == nil || products[0].Category.Code != "CLOTHING" {
+			t.Errorf("expected CLOTHING category, got %+v", products[0].Category)
+		}
+	})
+
+	t.Run("without filter categories are still loaded", func(t *testing.T) {
+		products, total, err := repo.GetAllProducts(context.Background(), 0, 10, models.ProductFilter{})
+		AssertNoError(t, err)
+
+		if int(total) != len(products) {
+			t.Errorf("expected total %d to match page size %d", total, len(products))
+		}
+		for _, product := range products {
+			if product.CategoryID != nil && product.Category == nil {
+				t.Errorf("expected category to be loaded for %s", product.Code)
+			}
+		}
+	})
+}
+
+// BenchmarkProductsRepository_GetAllProducts_CategoryFilter compares the eager
+// join used by GetAllProducts for a category code with the previous
+// join-plus-Preload query, and logs both query plans. It also compares
+// GetAllProducts filtering by code with filtering by category ID, as
+// CatalogService does after resolving the code, with and without the lookup.
+// The GetAllProducts cases also include the total count.
+func BenchmarkProductsRepository_GetAllProducts_CategoryFilter(b *testing.B) {
+	ts := SetupTestServer(b)
+	defer ts.Cleanup()
+
+	AssertNoError(b, ts.ClearDatabase())
+	AssertNoError(b, ts.SeedCategories())
+	AssertNoError(b, ts.SeedProducts())
+
+	ctx := context.Background()
+	repo := models.NewProductsRepository(ts.DB)
+	categories := models.NewCategoriesRepository(ts.DB)
+	filter := models.ProductFilter{Category: "CLOTHING"}
+	categoryID, err := categories.GetCategoryIDByCode(ctx, filter.Category)
+	AssertNoError(b, err)
+
+	preloadQuery := func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Category").Preload("Variants").
+			Joins("JOIN categories ON categories.id = products.category_id").
+			Where("categories.code = ?", filter.Category).
+			Order("products.id ASC").Limit(10)
+	}
+	joinQuery := func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Variants").
+			InnerJoins("Category", ts.DB.Select("id", "code", "name", "deleted_at")).
+			Where(`"Category".code = ?`, filter.Category).
+			Order("products.id ASC").Limit(10)
+	}
+
+	for name, query := range map[string]func(*gorm.DB) *gorm.DB{"preload": preloadQuery, "joins": joinQuery} {
+		sql := ts.DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			var products []models.Product
+			return query(tx).Find(&products)
+		})
+		var plan []string
+		AssertNoError(b, ts.DB.Raw("EXPLAIN "+sql).Scan(&plan).Error)
+		b.Logf("%s plan:\n%s", name, strings.Join(plan, "\n"))
+	}
+
+	b.Run("preload", func(b *testing.B) {
+		for b.Loop() {
+			var products []models.Product
+			if err := preloadQuery(ts.DB.WithContext(ctx)).Find(&products).Error; err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("joins", func(b *testing.B) {
+		for b.Loop() {
+			var products []models.Product
+			if err := joinQuery(ts.DB.WithContext(ctx)).Find(&products).Error; err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetAllProducts", func(b *testing.B) {
+		for b.Loop() {
+			if _, _, err := repo.GetAllProducts(ctx, 0, 10, filter); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetAllProducts by ID", func(b *testing.B) {
+		for b.Loop() {
+			if _, _, err := repo.GetAllProducts(ctx, 0, 10, models.ProductFilter{CategoryIDs: []uint{categoryID}}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetAllProducts by ID with lookup", func(b *testing.B) {
+		for b.Loop() {
+			id, err := categories.GetCategoryIDByCode(ctx, filter.Category)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, _, err := repo.GetAllProducts(ctx, 0, 10, models.ProductFilter{CategoryIDs: []uint{id}}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkProductsRepository_GetAllProducts_Total compares reading the total
+// from a COUNT(*) OVER() window, as GetAllProducts does, with the previous
+// separate count query followed by the page query.
+func BenchmarkProductsRepository_GetAllProducts_Total(b *testing.B) {
+	ts := SetupTestServer(b)
+	defer ts.Cleanup()
+
+	AssertNoError(b, ts.ClearDatabase())
+	AssertNoError(b, ts.SeedCategories())
+	AssertNoError(b, ts.SeedProducts())
+
+	ctx := context.Background()
+	repo := models.NewProductsRepository(ts.DB)
+
+	b.Run("count and select", func(b *testing.B) {
+		for b.Loop() {
+			var total int64
+			var products []models.Product
+			base := ts.DB.WithContext(ctx).Model(&models.Product{})
+			if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+				b.Fatal(err)
+			}
+			if err := base.Preload("Category").Preload("Variants").
+				Order("products.id ASC").Limit(10).Find(&products).Error; err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("window", func(b *testing.B) {
+		for b.Loop() {
+			if _, _, err := repo.GetAllProducts(ctx, 0, 10, models.ProductFilter{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestProductsRepository_UpdateProduct_WaitsForRowLock(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	ctx := context.Background()
+	repo := models.NewProductsRepository(ts.DB)
+
+	// A concurrent writer locks PROD002 (12.49) and changes its price.
+	tx := ts.DB.Begin()
+	defer tx.Rollback()
+	var locked models.Product
+	AssertNoError(t, tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", "PROD002").First(&locked).Error)
+	AssertNoError(t, tx.Model(&locked).Update("price", decimal.NewFromInt(20)).Error)
+
+	done := make(chan error, 1)
+	go func() {
+		price := decimal.NewFromInt(30)
+		_, err := repo.UpdateProduct(ctx, "PROD002", models.ProductUpdate{Price: &price})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected UpdateProduct to wait for the row lock, returned %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	AssertNoError(t, tx.Commit().Error)
+
+	select {
+	case err := <-done:
+		AssertNoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected UpdateProduct to finish once the lock was released")
+	}
+
+	// The update saw the committed 20, not the 12.49 it would have read without the lock.
+	history, err := models.NewPriceHistoryRepository(ts.DB).GetHistory(ctx, "PROD002")
+	AssertNoError(t, err)
+	if len(history) != 1 || !history[0].OldPrice.Equal(decimal.NewFromInt(20)) || !history[0].NewPrice.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("expected a single 20 -> 30 price change, got %+v", history)
+	}
+
+	product, err := repo.GetProductByCode(ctx, "PROD002")
+	AssertNoError(t, err)
+	if !product.Price.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("expected price 30, got %s", product.Price)
+	}
+}
+
+func TestProductsRepository_ContextCancellation(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	// Seed database
+	AssertNoError(t, ts.ClearDatabase())
+	AssertNoError(t, ts.SeedCategories())
+	AssertNoError(t, ts.SeedProducts())
+
+	repo := models.NewProductsRepository(ts.DB)
+
+	// assertAbortedPromptly fails unless err is a deadline error returned well
+	// before the blocking statement would have finished on its own.
+	assertAbortedPromptly := func(t *testing.T, err error, elapsed time.Duration) {
+		t.Helper()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed > 2*time.Second {
+			t.Errorf("expected the query to be aborted promptly, took %s", elapsed)
+		}
+	}
+
+	t.Run("slow insert is aborted", func(t *testing.T) {
+		// A trigger sleeping in Postgres keeps the INSERT running past the deadline.
+		AssertNoError(t, ts.DB.Exec(`CREATE OR REPLACE FUNCTION slow_variant_insert() RETURNS trigger AS $$
+BEGIN
+	PERFORM pg_sleep(5);
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql`).Error)
+		AssertNoError(t, ts.DB.Exec(`CREATE TRIGGER slow_variant_insert BEFORE INSERT ON product_variants
+FOR EACH ROW EXECUTE FUNCTION slow_variant_insert()`).Error)
+		t.Cleanup(func() {
+			ts.DB.Exec("DROP TRIGGER IF EXISTS slow_variant_insert ON product_variants")
+			ts.DB.Exec("DROP FUNCTION IF EXISTS slow_variant_insert()")
+		})
+
+		var product models.Product
+		AssertNoError(t, ts.DB.Where("code = ?", "PROD002").First(&product).Error)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := repo.CreateVariant(ctx, &models.Variant{ProductID: product.ID, Name: "Slow", SKU: "SKU-SLOW"})
+		assertAbortedPromptly(t, err, time.Since(start))
+
+		var count int64
+		AssertNoError(t, ts.DB.Model(&models.Variant{}).Where("sku = ?", "SKU-SLOW").Count(&count).Error)
+		if count != 0 {
+			t.Errorf("expected the aborted insert to be rolled back, found %d rows", count)
+		}
+	})
+
+	t.Run("blocked reads are aborted", func(t *testing.T) {
+		// An open transaction holding an exclusive lock blocks every read of products.
+		lock := ts.DB.Begin()
+		AssertNoError(t, lock.Error)
+		defer lock.Rollback()
+		AssertNoError(t, lock.Exec("LOCK TABLE products IN ACCESS EXCLUSIVE MODE").Error)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, _, err := repo.GetAllProducts(ctx, 0, 10, models.ProductFilter{})
+		assertAbortedPromptly(t, err, time.Since(start))
+
+		ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start = time.Now()
+		_, err = repo.GetProductByCode(ctx, "PROD001")
+		assertAbortedPromptly(t, err, time.Since(start))
+	})
+
+	t.Run("service does not mask the context error", func(t *testing.T) {
+		lock := ts.DB.Begin()
+		AssertNoError(t, lock.Error)
+		defer lock.Rollback()
+		AssertNoError(t, lock.Exec("LOCK TABLE products IN ACCESS EXCLUSIVE MODE").Error)
+
+		svc := services.NewCatalogService(repo, nil, services.CatalogServiceConfig{}, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := svc.GetProductByCode(ctx, "PROD001")
+		if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, services.ErrNotFound) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mytheresa/go-hiring-challenge/app/categories"
+	"github.com/mytheresa/go-hiring-challenge/app/services"
+	"github.com/mytheresa/go-hiring-challenge/app/webhooks"
+)
+
+func TestWebhooks_CategoryCreated(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Cleanup()
+
+	AssertNoError(t, ts.ClearDatabase())
+
+	// The receiver fails the first delivery, so the dispatcher has to retry.
+	var (
+		mu         sync.Mutex
+		bodies     [][]byte
+		signatures []string
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, body)
+		signatures = append(signatures, r.Header.Get(services.WebhookSignatureHeader))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	t.Run("registering a webhook", func(t *testing.T) {
+		resp, err := ts.POST("/v1/webhooks", webhooks.RegisterWebhookRequest{
+			URL:    receiver.URL,
+			Event:  "category.created",
+			Secret: "s3cret",
+		})
+		AssertNoError(t, err)
+		AssertStatusCode(t, http.StatusCreated, resp.StatusCode)
+
+		var webhook webhooks.WebhookResponse
+		AssertNoError(t, DecodeJSON(resp, &webhook))
+		if webhook.ID == 0 || webhook.URL != receiver.URL || webhook.Event != "category.created" {
+			t.Errorf("unexpected webhook %+v", webhook)
+		}
+	})
+
+	t.Run("creating a category notifies the webhook", func(t *testing.T) {
+		resp, err := ts.POST("/v1/categories", categories.CreateCategoryRequest{Code: "BAGS", Name: "Bags"})
+		AssertNoError(t, err)
+		resp.Body.Close()
+		AssertStatusCode(t, http.StatusCreated, resp.StatusCode)
+
+		ts.Webhooks.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(bodies) != 2 {
+			t.Fatalf("expected a failed and a successful delivery, got %d requests", len(bodies))
+		}
+		body := bodies[1]
+		if signatures[1] != services.SignWebhookPayload("s3cret", body) {
+			t.Errorf("signature %q does not match the body", signatures[1])
+		}
+
+		var payload struct {
+			Event string                 `json:"event"`
+			Data  services.CategoryEvent `json:"data"`
+		}
+		AssertNoError(t, json.Unmarshal(body, &payload))
+		if payload.Event != "category.created" || payload.Data != (services.CategoryEvent{Code: "BAGS", Name: "Bags"}) {
+			t.Errorf("unexpected payload %s", body)
+		}
+	})
+
+	t.Run("invalid registrations are rejected", func(t *testing.T) {
+		for _, req := range []webhooks.RegisterWebhookRequest{
+			{URL: "not a url", Event: "category.created", Secret: "s3cret"},
+			{URL: receiver.URL, Event: "category.deleted", Secret: "s3cret"},
+			{URL: receiver.URL, Event: "category.created"},
+		} {
+			resp, err := ts.POST("/v1/webhooks", req)
+			AssertNoError(t, err)
+			resp.Body.Close()
+			AssertStatusCode(t, http.StatusBadRequest, resp.StatusCode)
+		}
+	})
+}